@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"github.com/JasperRosales/aircraft-system-be/database"
+	"github.com/JasperRosales/aircraft-system-be/internal/config"
 	"github.com/JasperRosales/aircraft-system-be/internal/controller"
 	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
 	"github.com/JasperRosales/aircraft-system-be/internal/repository"
@@ -17,68 +23,163 @@ import (
 )
 
 func main() {
-	godotenv.Load()
+	cfg, err := config.Load(".env")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	logger, err := util.NewLoggerWithOptions(util.LoggerOptions{
+		Level:  cfg.Log.Level,
+		Format: cfg.Log.Format,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to configure logger:", err)
+		os.Exit(1)
+	}
 
-	logger := util.NewLogger()
-	db, err := initDatabase(logger)
+	db, err := initDatabase(logger, cfg.Database)
 	if err != nil {
 		logger.Fatal("Failed to initialize database", "error", err)
 	}
-	logger.Info("Database connected successfully")
+	logger.Info("Database connected successfully",
+		"max_open_conns", cfg.Database.MaxOpenConns,
+		"max_idle_conns", cfg.Database.MaxIdleConns,
+	)
 
+	latestMigration, err := database.LatestMigration()
+	if err != nil {
+		logger.Fatal("Failed to read embedded migrations", "error", err)
+	}
+	healthSvc := service.NewHealthService(repository.NewHealthRepository(db), latestMigration, logger)
+	if report := healthSvc.Ready(context.Background()); !report.Ready {
+		logger.Warn("Database schema is behind, /readyz will fail until migrations run",
+			"current", report.Migrations.Current,
+			"expected", report.Migrations.Expected,
+		)
+	}
+
+	txr := repository.NewTransactor(db)
 	userRepo := repository.NewUserRepository(db)
+	userInviteRepo := repository.NewUserInviteRepository(db)
 	planeRepo := repository.NewPlaneRepository(db)
 	planePartRepo := repository.NewPlanePartRepository(db)
-	jwtSvc := service.NewJWTService()
-	userSvc := service.NewUserService(userRepo, jwtSvc, logger)
-	planeSvc := service.NewPlaneService(planeRepo, logger)
-	planePartSvc := service.NewPlanePartService(planeRepo, planePartRepo, logger)
-	userCtrl := controller.NewUserController(userSvc, jwtSvc)
+	partUsageRepo := repository.NewPartUsageRepository(db)
+	partInstallRepo := repository.NewPartInstallationRepository(db)
+	flightRepo := repository.NewFlightRepository(db)
+	workOrderRepo := repository.NewWorkOrderRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	importRepo := repository.NewImportRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	limitExtensionRepo := repository.NewLimitExtensionRepository(db)
+	catalogRepo := repository.NewCatalogPartRepository(db)
+	aircraftModelRepo := repository.NewAircraftModelRepository(db)
+	auditSvc := service.NewAuditService(auditRepo, logger)
+	util.MetricsRegistry.MustRegister(service.NewFleetCollector(planeRepo, planePartRepo, logger))
+	jwtSvc := service.NewJWTService(cfg.Auth)
+	sessionSvc := service.NewSessionService(sessionRepo, userRepo, jwtSvc, cfg.Auth, logger)
+	userSvc := service.NewUserService(txr, userRepo, userInviteRepo, sessionSvc, auditSvc, logger)
+	planeSvc := service.NewPlaneService(txr, planeRepo, planePartRepo, aircraftModelRepo, auditSvc, logger)
+	airworthinessSvc := service.NewAirworthinessService(txr, planeRepo, planePartRepo, auditSvc, logger)
+	limitExtensionSvc := service.NewLimitExtensionService(txr, limitExtensionRepo, planePartRepo, auditSvc, logger)
+	catalogSvc := service.NewCatalogService(txr, catalogRepo, auditSvc, logger)
+	aircraftModelSvc := service.NewAircraftModelService(txr, aircraftModelRepo, planeRepo, planePartRepo, catalogRepo, importRepo, airworthinessSvc, auditSvc, logger)
+	planePartSvc := service.NewPlanePartService(txr, planeRepo, planePartRepo, partUsageRepo, partInstallRepo, catalogRepo, airworthinessSvc, auditSvc, logger)
+	flightSvc := service.NewFlightService(txr, planeRepo, flightRepo, airworthinessSvc, auditSvc, logger)
+	workOrderSvc := service.NewWorkOrderService(txr, workOrderRepo, planePartRepo, userRepo, catalogRepo, airworthinessSvc, auditSvc, logger)
+	searchSvc := service.NewSearchService(searchRepo, logger)
+	forecastSvc := service.NewForecastService(planeRepo, planePartRepo, flightRepo, logger)
+	importSvc := service.NewImportService(txr, importRepo, planeRepo, planePartRepo, catalogRepo, airworthinessSvc, auditSvc, logger)
+	exportSvc := service.NewExportService(planeRepo, planePartRepo, logger)
+	userCtrl := controller.NewUserController(userSvc, sessionSvc)
 	planeCtrl := controller.NewPlaneController(planeSvc)
 	planePartCtrl := controller.NewPlanePartController(planePartSvc)
+	flightCtrl := controller.NewFlightController(flightSvc)
+	workOrderCtrl := controller.NewWorkOrderController(workOrderSvc)
+	searchCtrl := controller.NewSearchController(searchSvc)
+	forecastCtrl := controller.NewForecastController(forecastSvc)
+	importCtrl := controller.NewImportController(importSvc)
+	exportCtrl := controller.NewExportController(exportSvc)
+	auditCtrl := controller.NewAuditController(auditSvc)
+	airworthinessCtrl := controller.NewAirworthinessController(airworthinessSvc)
+	limitExtensionCtrl := controller.NewLimitExtensionController(limitExtensionSvc)
+	catalogCtrl := controller.NewCatalogController(catalogSvc)
+	healthCtrl := controller.NewHealthController(healthSvc)
+	aircraftModelCtrl := controller.NewAircraftModelController(aircraftModelSvc)
 
 	router := gin.New()
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORSMiddleware())
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.MetricsMiddleware())
+	router.Use(middleware.CORSMiddleware(cfg.CORS.Origins))
 	router.Use(middleware.LoggerMiddleware(logger))
 
-	router.GET("/ping", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"message":     "Aircraft API is running...",
-			"version":     "1.0.0",
-			"description": "This API provides information about aircrafts, including their specifications, performance, and history.",
-		})
-	})
+	routers.SetupHealthRoutes(&router.RouterGroup, healthCtrl)
 
 	api := router.Group("/api")
-	routers.SetupUserRoutes(api, userCtrl, jwtSvc, logger)
-	routers.SetupPlaneRoutes(api, planeCtrl, planePartCtrl, jwtSvc, logger)
-
-	port := os.Getenv("PORT")
-	if port == "" {
- 	   	port = "8080"
-    	logger.Warn("PORT not set, defaulting to 8080")
+	routers.SetupUserRoutes(api, userCtrl, sessionSvc, logger)
+	routers.SetupPlaneRoutes(api, planeCtrl, planePartCtrl, flightCtrl, forecastCtrl, sessionSvc, logger)
+	routers.SetupWorkOrderRoutes(api, workOrderCtrl, sessionSvc, logger)
+	routers.SetupSearchRoutes(api, searchCtrl, sessionSvc, logger)
+	routers.SetupImportRoutes(api, importCtrl, sessionSvc, logger)
+	routers.SetupExportRoutes(api, exportCtrl, sessionSvc, logger)
+	routers.SetupAuditRoutes(api, auditCtrl, sessionSvc, logger)
+	routers.SetupAirworthinessRoutes(api, airworthinessCtrl, sessionSvc, logger)
+	routers.SetupLimitExtensionRoutes(api, limitExtensionCtrl, sessionSvc, logger)
+	routers.SetupCatalogRoutes(api, catalogCtrl, sessionSvc, logger)
+	routers.SetupAircraftModelRoutes(api, aircraftModelCtrl, sessionSvc, logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := util.NewHTTPServer(router, util.ServerOptions{
+		Addr:              cfg.Server.Addr(),
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	})
+	metricsSrv := util.NewMetricsServer(cfg.Server.MetricsAddr)
+	serveErr := util.RunServers(ctx, cfg.Server.ShutdownTimeout, logger, srv, metricsSrv)
+	if serveErr != nil {
+		logger.Error("HTTP server did not stop cleanly", "error", serveErr)
 	}
 
-	router.Run(":" + port)
-
-}
-
-func initDatabase(logger *util.Logger) (*gorm.DB, error) {
-	dsn := os.Getenv("GOOSE_DBSTRING")
-	if dsn == "" {
-		logger.Warn("GOOSE_DBSTRING not set, database connection skipped")
-		return nil, nil
+	if err := database.Close(db); err != nil {
+		logger.Error("Failed to close database", "error", err)
+	}
+	if serveErr != nil {
+		os.Exit(1)
 	}
+	logger.Info("Shutdown complete")
+}
 
+// initDatabase connects and pings the database. Every endpoint but the
+// probes needs it, so there is no running without one.
+func initDatabase(logger *util.Logger, cfg config.DatabaseConfig) (*gorm.DB, error) {
 	gormConfig := &gorm.Config{
-		Logger: util.NewGormLogger(),
+		Logger: util.NewGormLogger(logger),
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
+	db, err := gorm.Open(postgres.Open(cfg.DSN), gormConfig)
 	if err != nil {
 		return nil, err
 	}
+	if err := database.ConfigurePool(db, database.PoolOptions{
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: cfg.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.ConnMaxIdleTime,
+	}); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := repository.NewHealthRepository(db).Ping(ctx); err != nil {
+		return nil, err
+	}
 
 	return db, nil
 }
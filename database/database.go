@@ -0,0 +1,43 @@
+// Package database embeds the goose migrations, so the binary knows which
+// schema version it was built for without the SQL files on disk.
+package database
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// LatestMigration returns the version of the newest migration: the numeric
+// prefix of its file name, as goose records it in goose_db_version.
+func LatestMigration() (int64, error) {
+	files, err := fs.Glob(Migrations, "migrations/*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, file := range files {
+		prefix, _, ok := strings.Cut(path.Base(file), "_")
+		if !ok {
+			return 0, fmt.Errorf("migration %s has no version prefix", file)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s has an invalid version: %w", file, err)
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations embedded")
+	}
+	return latest, nil
+}
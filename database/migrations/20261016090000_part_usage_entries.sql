@@ -0,0 +1,38 @@
+-- +goose Up
+SELECT 'up SQL query';
+CREATE TABLE part_usage_entries (
+    id SERIAL PRIMARY KEY,
+    part_id INTEGER NOT NULL REFERENCES plane_parts(id) ON DELETE CASCADE,
+    delta_hours NUMERIC(10,2) NOT NULL,
+    recorded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    source VARCHAR(50) NOT NULL DEFAULT 'manual',
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_part_usage_entries_part_id
+ON part_usage_entries(part_id, recorded_at);
+
+-- Seed the ledger with the hours already logged so usage_hours stays equal
+-- to the sum of each part's entries.
+INSERT INTO part_usage_entries (part_id, delta_hours, source, recorded_at)
+SELECT id, usage_hours, 'initial', installed_at
+FROM plane_parts
+WHERE usage_hours <> 0;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reject_part_usage_entry_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'part_usage_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_part_usage_entries_append_only
+BEFORE UPDATE ON part_usage_entries
+FOR EACH ROW EXECUTE FUNCTION reject_part_usage_entry_update();
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TRIGGER IF EXISTS trg_part_usage_entries_append_only ON part_usage_entries;
+DROP FUNCTION IF EXISTS reject_part_usage_entry_update();
+DROP TABLE IF EXISTS part_usage_entries;
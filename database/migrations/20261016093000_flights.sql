@@ -0,0 +1,54 @@
+-- +goose Up
+SELECT 'up SQL query';
+CREATE TABLE flights (
+    id SERIAL PRIMARY KEY,
+    plane_id INTEGER NOT NULL REFERENCES planes(id) ON DELETE CASCADE,
+    departure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    arrival_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    block_hours NUMERIC(10,2) NOT NULL CHECK (block_hours > 0),
+    cycles INTEGER NOT NULL DEFAULT 1 CHECK (cycles >= 1),
+    recorded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (arrival_at > departure_at)
+);
+
+CREATE INDEX idx_flights_plane_id
+ON flights(plane_id, departure_at);
+
+ALTER TABLE part_usage_entries
+ADD COLUMN flight_id INTEGER REFERENCES flights(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_part_usage_entries_flight_id
+ON part_usage_entries(flight_id);
+
+-- Allow ON DELETE SET NULL on recorded_by/flight_id while keeping the
+-- recorded values themselves immutable.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reject_part_usage_entry_update() RETURNS trigger AS $$
+BEGIN
+    IF NEW.part_id <> OLD.part_id
+        OR NEW.delta_hours <> OLD.delta_hours
+        OR NEW.source <> OLD.source
+        OR NEW.recorded_at IS DISTINCT FROM OLD.recorded_at
+        OR (NEW.recorded_by IS NOT NULL AND NEW.recorded_by IS DISTINCT FROM OLD.recorded_by)
+        OR (NEW.flight_id IS NOT NULL AND NEW.flight_id IS DISTINCT FROM OLD.flight_id) THEN
+        RAISE EXCEPTION 'part_usage_entries is append-only';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+SELECT 'down SQL query';
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reject_part_usage_entry_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'part_usage_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_part_usage_entries_flight_id;
+ALTER TABLE part_usage_entries
+DROP COLUMN IF EXISTS flight_id;
+DROP TABLE IF EXISTS flights;
@@ -0,0 +1,52 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE plane_parts
+ADD COLUMN usage_cycles INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN usage_limit_cycles INTEGER CHECK (usage_limit_cycles > 0),
+ADD COLUMN calendar_limit_days INTEGER CHECK (calendar_limit_days > 0);
+
+ALTER TABLE part_usage_entries
+ADD COLUMN delta_cycles INTEGER NOT NULL DEFAULT 0;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reject_part_usage_entry_update() RETURNS trigger AS $$
+BEGIN
+    IF NEW.part_id <> OLD.part_id
+        OR NEW.delta_hours <> OLD.delta_hours
+        OR NEW.delta_cycles <> OLD.delta_cycles
+        OR NEW.source <> OLD.source
+        OR NEW.recorded_at IS DISTINCT FROM OLD.recorded_at
+        OR (NEW.recorded_by IS NOT NULL AND NEW.recorded_by IS DISTINCT FROM OLD.recorded_by)
+        OR (NEW.flight_id IS NOT NULL AND NEW.flight_id IS DISTINCT FROM OLD.flight_id) THEN
+        RAISE EXCEPTION 'part_usage_entries is append-only';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+SELECT 'down SQL query';
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION reject_part_usage_entry_update() RETURNS trigger AS $$
+BEGIN
+    IF NEW.part_id <> OLD.part_id
+        OR NEW.delta_hours <> OLD.delta_hours
+        OR NEW.source <> OLD.source
+        OR NEW.recorded_at IS DISTINCT FROM OLD.recorded_at
+        OR (NEW.recorded_by IS NOT NULL AND NEW.recorded_by IS DISTINCT FROM OLD.recorded_by)
+        OR (NEW.flight_id IS NOT NULL AND NEW.flight_id IS DISTINCT FROM OLD.flight_id) THEN
+        RAISE EXCEPTION 'part_usage_entries is append-only';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+ALTER TABLE part_usage_entries
+DROP COLUMN IF EXISTS delta_cycles;
+
+ALTER TABLE plane_parts
+DROP COLUMN IF EXISTS calendar_limit_days,
+DROP COLUMN IF EXISTS usage_limit_cycles,
+DROP COLUMN IF EXISTS usage_cycles;
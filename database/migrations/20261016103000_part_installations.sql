@@ -0,0 +1,49 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE plane_parts
+ALTER COLUMN plane_id DROP NOT NULL;
+
+CREATE TABLE part_installations (
+    id SERIAL PRIMARY KEY,
+    part_id INTEGER NOT NULL REFERENCES plane_parts(id) ON DELETE CASCADE,
+    plane_id INTEGER NOT NULL REFERENCES planes(id) ON DELETE CASCADE,
+    installed_at TIMESTAMP NOT NULL,
+    removed_at TIMESTAMP,
+    installed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    removed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    removal_reason VARCHAR(255),
+    hours_at_install NUMERIC(10,2) NOT NULL DEFAULT 0,
+    cycles_at_install INTEGER NOT NULL DEFAULT 0,
+    hours_at_removal NUMERIC(10,2),
+    cycles_at_removal INTEGER,
+    CHECK (removed_at IS NULL OR removed_at >= installed_at)
+);
+
+CREATE INDEX idx_part_installations_part_id
+ON part_installations(part_id, installed_at);
+
+CREATE INDEX idx_part_installations_plane_id
+ON part_installations(plane_id);
+
+-- A serial can only be fitted to one plane at a time.
+CREATE UNIQUE INDEX idx_part_installations_open
+ON part_installations(part_id)
+WHERE removed_at IS NULL;
+
+-- Open an installation for every part that is already fitted. Usage logged
+-- before the part was tracked counts as time accrued before installation.
+INSERT INTO part_installations (part_id, plane_id, installed_at, hours_at_install, cycles_at_install)
+SELECT p.id, p.plane_id, p.installed_at,
+    COALESCE((SELECT SUM(e.delta_hours) FROM part_usage_entries e
+              WHERE e.part_id = p.id AND e.source = 'initial'), 0),
+    COALESCE((SELECT SUM(e.delta_cycles) FROM part_usage_entries e
+              WHERE e.part_id = p.id AND e.source = 'initial'), 0)
+FROM plane_parts p
+WHERE p.plane_id IS NOT NULL;
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS part_installations;
+DELETE FROM plane_parts WHERE plane_id IS NULL;
+ALTER TABLE plane_parts
+ALTER COLUMN plane_id SET NOT NULL;
@@ -0,0 +1,39 @@
+-- +goose Up
+SELECT 'up SQL query';
+CREATE TABLE work_orders (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'in_progress', 'signed_off', 'closed')),
+    assigned_to INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    signed_off_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    signed_off_at TIMESTAMP WITH TIME ZONE,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_work_orders_status
+ON work_orders(status);
+
+CREATE INDEX idx_work_orders_assigned_to
+ON work_orders(assigned_to);
+
+CREATE TABLE work_order_parts (
+    id SERIAL PRIMARY KEY,
+    work_order_id INTEGER NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    part_id INTEGER NOT NULL REFERENCES plane_parts(id) ON DELETE CASCADE,
+    action VARCHAR(20) CHECK (action IN ('reset', 'replace')),
+    replacement_part_id INTEGER REFERENCES plane_parts(id) ON DELETE SET NULL,
+    UNIQUE (work_order_id, part_id)
+);
+
+CREATE INDEX idx_work_order_parts_part_id
+ON work_order_parts(part_id);
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS work_order_parts;
+DROP TABLE IF EXISTS work_orders;
@@ -0,0 +1,16 @@
+-- +goose Up
+SELECT 'up SQL query';
+CREATE TABLE user_invites (
+    id SERIAL PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    role VARCHAR(100) NOT NULL CHECK (role IN ('user', 'mechanic', 'admin')),
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    redeemed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    redeemed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS user_invites;
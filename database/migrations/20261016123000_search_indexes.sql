@@ -0,0 +1,30 @@
+-- +goose Up
+SELECT 'up SQL query';
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_planes_tail_number_trgm
+ON planes USING GIN (tail_number gin_trgm_ops);
+
+CREATE INDEX idx_planes_model_trgm
+ON planes USING GIN (model gin_trgm_ops);
+
+CREATE INDEX idx_plane_parts_serial_number_trgm
+ON plane_parts USING GIN (serial_number gin_trgm_ops);
+
+CREATE INDEX idx_plane_parts_part_name_trgm
+ON plane_parts USING GIN (part_name gin_trgm_ops);
+
+CREATE INDEX idx_plane_parts_category_trgm
+ON plane_parts USING GIN (category gin_trgm_ops);
+
+CREATE INDEX idx_plane_parts_search_fts
+ON plane_parts USING GIN (to_tsvector('simple', part_name || ' ' || category));
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_plane_parts_search_fts;
+DROP INDEX IF EXISTS idx_plane_parts_category_trgm;
+DROP INDEX IF EXISTS idx_plane_parts_part_name_trgm;
+DROP INDEX IF EXISTS idx_plane_parts_serial_number_trgm;
+DROP INDEX IF EXISTS idx_planes_model_trgm;
+DROP INDEX IF EXISTS idx_planes_tail_number_trgm;
@@ -0,0 +1,47 @@
+-- +goose Up
+SELECT 'up SQL query';
+-- before, after and changes use JSON rather than JSONB so the stored text is
+-- byte-for-byte what was hashed.
+CREATE TABLE audit_log (
+    id SERIAL PRIMARY KEY,
+    actor_id INTEGER,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    before JSON,
+    after JSON,
+    changes JSON,
+    request_id VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    prev_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL UNIQUE
+);
+
+CREATE INDEX idx_audit_log_entity
+ON audit_log(entity_type, entity_id);
+
+CREATE INDEX idx_audit_log_actor_id
+ON audit_log(actor_id);
+
+CREATE INDEX idx_audit_log_request_id
+ON audit_log(request_id);
+
+CREATE INDEX idx_audit_log_created_at
+ON audit_log(created_at);
+
+-- +goose StatementBegin
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_log
+FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
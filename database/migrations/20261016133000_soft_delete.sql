@@ -0,0 +1,28 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE planes
+ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE plane_parts
+ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE users
+ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_planes_deleted_at
+ON planes(deleted_at);
+
+CREATE INDEX idx_plane_parts_deleted_at
+ON plane_parts(deleted_at);
+
+CREATE INDEX idx_users_deleted_at
+ON users(deleted_at);
+
+-- +goose Down
+SELECT 'down SQL query';
+DELETE FROM plane_parts WHERE deleted_at IS NOT NULL;
+DELETE FROM planes WHERE deleted_at IS NOT NULL;
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+ALTER TABLE plane_parts DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE planes DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,26 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE planes
+ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('active', 'in_maintenance', 'grounded', 'retired'));
+
+CREATE INDEX idx_planes_status
+ON planes(status);
+
+CREATE TABLE plane_status_changes (
+    id SERIAL PRIMARY KEY,
+    plane_id INTEGER NOT NULL REFERENCES planes(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason VARCHAR(500),
+    changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_plane_status_changes_plane_id
+ON plane_status_changes(plane_id, changed_at);
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS plane_status_changes;
+ALTER TABLE planes DROP COLUMN IF EXISTS status;
@@ -0,0 +1,29 @@
+-- +goose Up
+SELECT 'up SQL query';
+ALTER TABLE plane_parts
+ADD COLUMN extension_hours NUMERIC(10,2) NOT NULL DEFAULT 0;
+
+CREATE TABLE part_limit_extensions (
+    id SERIAL PRIMARY KEY,
+    part_id INTEGER NOT NULL REFERENCES plane_parts(id) ON DELETE CASCADE,
+    requested_hours NUMERIC(10,2) NOT NULL CHECK (requested_hours > 0),
+    justification TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected')),
+    requested_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    review_note VARCHAR(500),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_part_limit_extensions_part_id
+ON part_limit_extensions(part_id, created_at);
+
+CREATE INDEX idx_part_limit_extensions_status
+ON part_limit_extensions(status);
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS part_limit_extensions;
+ALTER TABLE plane_parts DROP COLUMN IF EXISTS extension_hours;
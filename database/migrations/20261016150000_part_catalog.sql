@@ -0,0 +1,35 @@
+-- +goose Up
+SELECT 'up SQL query';
+CREATE TABLE catalog_parts (
+    id SERIAL PRIMARY KEY,
+    part_number VARCHAR(100) NOT NULL UNIQUE,
+    manufacturer VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    category VARCHAR(150) NOT NULL,
+    default_limit_hours NUMERIC(10,2) NOT NULL CHECK (default_limit_hours > 0),
+    default_limit_cycles INTEGER CHECK (default_limit_cycles > 0),
+    default_calendar_days INTEGER CHECK (default_calendar_days > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_catalog_parts_category
+ON catalog_parts(category);
+
+CREATE TABLE catalog_part_models (
+    catalog_part_id INTEGER NOT NULL REFERENCES catalog_parts(id) ON DELETE CASCADE,
+    model VARCHAR(100) NOT NULL,
+    PRIMARY KEY (catalog_part_id, model)
+);
+
+ALTER TABLE plane_parts
+ADD COLUMN catalog_part_id INTEGER REFERENCES catalog_parts(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_plane_parts_catalog_part_id
+ON plane_parts(catalog_part_id);
+
+-- +goose Down
+SELECT 'down SQL query';
+ALTER TABLE plane_parts DROP COLUMN IF EXISTS catalog_part_id;
+DROP TABLE IF EXISTS catalog_part_models;
+DROP TABLE IF EXISTS catalog_parts;
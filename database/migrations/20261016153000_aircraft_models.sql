@@ -0,0 +1,31 @@
+-- +goose Up
+SELECT 'up SQL query';
+CREATE TABLE aircraft_models (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    manufacturer VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Planes name their model as free text, matched case-insensitively.
+CREATE UNIQUE INDEX idx_aircraft_models_name
+ON aircraft_models(LOWER(name));
+
+CREATE TABLE aircraft_model_slots (
+    id SERIAL PRIMARY KEY,
+    aircraft_model_id INTEGER NOT NULL REFERENCES aircraft_models(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(150) NOT NULL,
+    catalog_part_id INTEGER REFERENCES catalog_parts(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    UNIQUE (aircraft_model_id, name)
+);
+
+CREATE INDEX idx_aircraft_model_slots_catalog_part_id
+ON aircraft_model_slots(catalog_part_id);
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS aircraft_model_slots;
+DROP TABLE IF EXISTS aircraft_models;
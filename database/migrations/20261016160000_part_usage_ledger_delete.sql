@@ -0,0 +1,17 @@
+-- +goose Up
+SELECT 'up SQL query';
+-- The ledger is append-only: deleting an entry rewrites a part's history as
+-- surely as updating one.
+DROP TRIGGER IF EXISTS trg_part_usage_entries_append_only ON part_usage_entries;
+
+CREATE TRIGGER trg_part_usage_entries_append_only
+BEFORE UPDATE OR DELETE ON part_usage_entries
+FOR EACH ROW EXECUTE FUNCTION reject_part_usage_entry_update();
+
+-- +goose Down
+SELECT 'down SQL query';
+DROP TRIGGER IF EXISTS trg_part_usage_entries_append_only ON part_usage_entries;
+
+CREATE TRIGGER trg_part_usage_entries_append_only
+BEFORE UPDATE ON part_usage_entries
+FOR EACH ROW EXECUTE FUNCTION reject_part_usage_entry_update();
@@ -0,0 +1,24 @@
+-- +goose Up
+SELECT 'up SQL query';
+-- Calendar limits run from a part's first entry into service. installed_at
+-- moves on every install, so it cannot be used for them.
+ALTER TABLE plane_parts
+ADD COLUMN in_service_at TIMESTAMP;
+
+UPDATE plane_parts p
+SET in_service_at = LEAST(
+    p.installed_at,
+    (SELECT MIN(i.installed_at) FROM part_installations i WHERE i.part_id = p.id)
+);
+
+UPDATE plane_parts
+SET in_service_at = CURRENT_TIMESTAMP
+WHERE in_service_at IS NULL;
+
+ALTER TABLE plane_parts
+ALTER COLUMN in_service_at SET DEFAULT CURRENT_TIMESTAMP,
+ALTER COLUMN in_service_at SET NOT NULL;
+
+-- +goose Down
+SELECT 'down SQL query';
+ALTER TABLE plane_parts DROP COLUMN IF EXISTS in_service_at;
@@ -0,0 +1,70 @@
+-- +goose Up
+SELECT 'up SQL query';
+-- Purging a plane or part used to cascade to its flights, usage ledger,
+-- installations, work order items, status changes and extensions. That
+-- history must outlive a purge, so the delete is refused instead.
+ALTER TABLE plane_parts
+DROP CONSTRAINT plane_parts_plane_id_fkey,
+ADD CONSTRAINT plane_parts_plane_id_fkey FOREIGN KEY (plane_id) REFERENCES planes(id) ON DELETE RESTRICT;
+
+ALTER TABLE part_usage_entries
+DROP CONSTRAINT part_usage_entries_part_id_fkey,
+ADD CONSTRAINT part_usage_entries_part_id_fkey FOREIGN KEY (part_id) REFERENCES plane_parts(id) ON DELETE RESTRICT;
+
+ALTER TABLE flights
+DROP CONSTRAINT flights_plane_id_fkey,
+ADD CONSTRAINT flights_plane_id_fkey FOREIGN KEY (plane_id) REFERENCES planes(id) ON DELETE RESTRICT;
+
+ALTER TABLE part_installations
+DROP CONSTRAINT part_installations_part_id_fkey,
+ADD CONSTRAINT part_installations_part_id_fkey FOREIGN KEY (part_id) REFERENCES plane_parts(id) ON DELETE RESTRICT;
+
+ALTER TABLE part_installations
+DROP CONSTRAINT part_installations_plane_id_fkey,
+ADD CONSTRAINT part_installations_plane_id_fkey FOREIGN KEY (plane_id) REFERENCES planes(id) ON DELETE RESTRICT;
+
+ALTER TABLE work_order_parts
+DROP CONSTRAINT work_order_parts_part_id_fkey,
+ADD CONSTRAINT work_order_parts_part_id_fkey FOREIGN KEY (part_id) REFERENCES plane_parts(id) ON DELETE RESTRICT;
+
+ALTER TABLE plane_status_changes
+DROP CONSTRAINT plane_status_changes_plane_id_fkey,
+ADD CONSTRAINT plane_status_changes_plane_id_fkey FOREIGN KEY (plane_id) REFERENCES planes(id) ON DELETE RESTRICT;
+
+ALTER TABLE part_limit_extensions
+DROP CONSTRAINT part_limit_extensions_part_id_fkey,
+ADD CONSTRAINT part_limit_extensions_part_id_fkey FOREIGN KEY (part_id) REFERENCES plane_parts(id) ON DELETE RESTRICT;
+
+-- +goose Down
+SELECT 'down SQL query';
+ALTER TABLE plane_parts
+DROP CONSTRAINT plane_parts_plane_id_fkey,
+ADD CONSTRAINT plane_parts_plane_id_fkey FOREIGN KEY (plane_id) REFERENCES planes(id) ON DELETE CASCADE;
+
+ALTER TABLE part_usage_entries
+DROP CONSTRAINT part_usage_entries_part_id_fkey,
+ADD CONSTRAINT part_usage_entries_part_id_fkey FOREIGN KEY (part_id) REFERENCES plane_parts(id) ON DELETE CASCADE;
+
+ALTER TABLE flights
+DROP CONSTRAINT flights_plane_id_fkey,
+ADD CONSTRAINT flights_plane_id_fkey FOREIGN KEY (plane_id) REFERENCES planes(id) ON DELETE CASCADE;
+
+ALTER TABLE part_installations
+DROP CONSTRAINT part_installations_part_id_fkey,
+ADD CONSTRAINT part_installations_part_id_fkey FOREIGN KEY (part_id) REFERENCES plane_parts(id) ON DELETE CASCADE;
+
+ALTER TABLE part_installations
+DROP CONSTRAINT part_installations_plane_id_fkey,
+ADD CONSTRAINT part_installations_plane_id_fkey FOREIGN KEY (plane_id) REFERENCES planes(id) ON DELETE CASCADE;
+
+ALTER TABLE work_order_parts
+DROP CONSTRAINT work_order_parts_part_id_fkey,
+ADD CONSTRAINT work_order_parts_part_id_fkey FOREIGN KEY (part_id) REFERENCES plane_parts(id) ON DELETE CASCADE;
+
+ALTER TABLE plane_status_changes
+DROP CONSTRAINT plane_status_changes_plane_id_fkey,
+ADD CONSTRAINT plane_status_changes_plane_id_fkey FOREIGN KEY (plane_id) REFERENCES planes(id) ON DELETE CASCADE;
+
+ALTER TABLE part_limit_extensions
+DROP CONSTRAINT part_limit_extensions_part_id_fkey,
+ADD CONSTRAINT part_limit_extensions_part_id_fkey FOREIGN KEY (part_id) REFERENCES plane_parts(id) ON DELETE CASCADE;
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PoolOptions tunes the connection pool behind a *gorm.DB. Zero values keep
+// the database/sql defaults: unlimited open connections, two idle ones, and
+// no maximum lifetime.
+type PoolOptions struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+func ConfigurePool(db *gorm.DB, opts PoolOptions) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get connection pool: %w", err)
+	}
+
+	sqlDB.SetMaxOpenConns(opts.MaxOpenConns)
+	if opts.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+	sqlDB.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+	return nil
+}
+
+// Close closes the connection pool, waiting for queries in progress.
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get connection pool: %w", err)
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,376 @@
+// Package config loads the server's settings once, at startup. Values come
+// from, lowest priority first: built-in defaults, the YAML file named by
+// CONFIG_FILE, a .env file, and the process environment. Everything is
+// validated before the server starts, so a bad value fails fast with a
+// message naming the setting instead of surfacing mid-request.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	CORS     CORSConfig     `yaml:"cors"`
+	Log      LogConfig      `yaml:"log"`
+}
+
+type ServerConfig struct {
+	Port              int           `yaml:"port"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	// MetricsAddr is the host:port of the listener that serves /metrics,
+	// kept apart from the API so it can stay off the public network.
+	MetricsAddr string `yaml:"metrics_addr"`
+}
+
+type DatabaseConfig struct {
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+type AuthConfig struct {
+	Secret             string        `yaml:"secret"`
+	AccessTokenExpiry  time.Duration `yaml:"access_token_expiry"`
+	RefreshTokenExpiry time.Duration `yaml:"refresh_token_expiry"`
+}
+
+// CORSConfig lists the browser origins allowed to call the API with
+// credentials, such as https://app.example.com.
+type CORSConfig struct {
+	Origins []string `yaml:"origins"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// Addr is the address the HTTP server listens on.
+func (c ServerConfig) Addr() string {
+	return ":" + strconv.Itoa(c.Port)
+}
+
+// Default returns the settings used when nothing overrides them. The
+// database DSN, the JWT secret and the CORS origins have no default.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:              8080,
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+			MetricsAddr:       "127.0.0.1:9090",
+		},
+		Database: DatabaseConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Auth: AuthConfig{
+			AccessTokenExpiry:  15 * time.Minute,
+			RefreshTokenExpiry: 720 * time.Hour,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: util.LogFormatText,
+		},
+	}
+}
+
+// envVar binds an environment variable to a setting. key is the setting's
+// YAML path, used in error messages.
+type envVar struct {
+	name  string
+	key   string
+	parse func(value string) error
+}
+
+func (c *Config) envVars() []envVar {
+	return []envVar{
+		{"PORT", "server.port", intValue(&c.Server.Port)},
+		{"METRICS_ADDR", "server.metrics_addr", stringValue(&c.Server.MetricsAddr)},
+		{"HTTP_READ_HEADER_TIMEOUT", "server.read_header_timeout", durationValue(&c.Server.ReadHeaderTimeout)},
+		{"HTTP_READ_TIMEOUT", "server.read_timeout", durationValue(&c.Server.ReadTimeout)},
+		{"HTTP_WRITE_TIMEOUT", "server.write_timeout", durationValue(&c.Server.WriteTimeout)},
+		{"HTTP_IDLE_TIMEOUT", "server.idle_timeout", durationValue(&c.Server.IdleTimeout)},
+		{"HTTP_SHUTDOWN_TIMEOUT", "server.shutdown_timeout", durationValue(&c.Server.ShutdownTimeout)},
+		{"GOOSE_DBSTRING", "database.dsn", stringValue(&c.Database.DSN)},
+		{"DB_MAX_OPEN_CONNS", "database.max_open_conns", intValue(&c.Database.MaxOpenConns)},
+		{"DB_MAX_IDLE_CONNS", "database.max_idle_conns", intValue(&c.Database.MaxIdleConns)},
+		{"DB_CONN_MAX_LIFETIME", "database.conn_max_lifetime", durationValue(&c.Database.ConnMaxLifetime)},
+		{"DB_CONN_MAX_IDLE_TIME", "database.conn_max_idle_time", durationValue(&c.Database.ConnMaxIdleTime)},
+		{"SECRET", "auth.secret", stringValue(&c.Auth.Secret)},
+		{"ACCESS_TOKEN_EXP", "auth.access_token_expiry", expiryValue(&c.Auth.AccessTokenExpiry, time.Minute)},
+		{"REFRESH_TOKEN_EXP", "auth.refresh_token_expiry", expiryValue(&c.Auth.RefreshTokenExpiry, time.Hour)},
+		{"ORIGIN", "cors.origins", listValue(&c.CORS.Origins)},
+		{"LOG_LEVEL", "log.level", stringValue(&c.Log.Level)},
+		{"LOG_FORMAT", "log.format", stringValue(&c.Log.Format)},
+	}
+}
+
+// Load builds the configuration from the defaults, the YAML file named by
+// CONFIG_FILE, envFile and the environment, then validates it. A missing
+// envFile is not an error. Empty variables count as unset, so a copy of
+// .env.sample changes nothing. All problems are returned together.
+func Load(envFile string) (*Config, error) {
+	dotenv, err := godotenv.Read(envFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", envFile, err)
+	}
+	lookup := func(name string) string {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+		return dotenv[name]
+	}
+
+	cfg := Default()
+	if path := lookup("CONFIG_FILE"); path != "" {
+		if err := cfg.loadYAML(path); err != nil {
+			return nil, err
+		}
+	}
+
+	var errs []error
+	for _, v := range cfg.envVars() {
+		value := strings.TrimSpace(lookup(v.name))
+		if value == "" {
+			continue
+		}
+		if err := v.parse(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w, got %q", v.name, err, value))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, invalid(errs)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadYAML overlays the file on cfg. Unknown keys are rejected so a typo
+// doesn't silently leave a default in place.
+func (c *Config) loadYAML(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate checks every setting and reports all problems at once, each
+// naming the YAML key and environment variable that set it. CORS origins
+// are normalized in place.
+func (c *Config) Validate() error {
+	names := map[string]string{}
+	for _, v := range c.envVars() {
+		names[v.key] = v.name
+	}
+	var errs []error
+	fail := func(key, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s (%s) %s", key, names[key], fmt.Sprintf(format, args...)))
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		fail("server.port", "must be between 1 and 65535")
+	}
+	if _, port, err := net.SplitHostPort(c.Server.MetricsAddr); err != nil {
+		fail("server.metrics_addr", "must be host:port, such as 127.0.0.1:9090")
+	} else if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		fail("server.metrics_addr", "must have a port between 1 and 65535")
+	} else if n == c.Server.Port {
+		fail("server.metrics_addr", "must not use server.port")
+	}
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"database.conn_max_lifetime", c.Database.ConnMaxLifetime},
+		{"database.conn_max_idle_time", c.Database.ConnMaxIdleTime},
+		{"auth.access_token_expiry", c.Auth.AccessTokenExpiry},
+		{"auth.refresh_token_expiry", c.Auth.RefreshTokenExpiry},
+	} {
+		if d.value <= 0 {
+			fail(d.key, "must be a positive duration")
+		}
+	}
+
+	if c.Database.DSN == "" {
+		fail("database.dsn", "is required")
+	}
+	if c.Database.MaxOpenConns < 1 {
+		fail("database.max_open_conns", "must be at least 1")
+	}
+	if c.Database.MaxIdleConns < 1 {
+		fail("database.max_idle_conns", "must be at least 1")
+	} else if c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("database.max_idle_conns", "must not exceed database.max_open_conns")
+	}
+
+	if c.Auth.Secret == "" {
+		fail("auth.secret", "is required")
+	}
+	if c.Auth.RefreshTokenExpiry <= c.Auth.AccessTokenExpiry {
+		fail("auth.refresh_token_expiry", "must be longer than auth.access_token_expiry")
+	}
+
+	if len(c.CORS.Origins) == 0 {
+		fail("cors.origins", "needs at least one origin")
+	}
+	for i, origin := range c.CORS.Origins {
+		normalized, err := normalizeOrigin(origin)
+		if err != nil {
+			fail("cors.origins", "has an invalid origin %q: %v", origin, err)
+			continue
+		}
+		c.CORS.Origins[i] = normalized
+	}
+
+	if _, err := util.ParseLogLevel(c.Log.Level); err != nil {
+		fail("log.level", "must be debug, info, warn or error")
+	}
+	switch strings.ToLower(c.Log.Format) {
+	case "", util.LogFormatText, util.LogFormatJSON:
+	default:
+		fail("log.format", "must be text or json")
+	}
+
+	if len(errs) > 0 {
+		return invalid(errs)
+	}
+	return nil
+}
+
+// normalizeOrigin checks that origin is a scheme and host, as browsers send
+// it in the Origin header, and drops a trailing slash. A wildcard is refused
+// because the API allows credentials.
+func normalizeOrigin(origin string) (string, error) {
+	origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+	if origin == "*" {
+		return "", errors.New("wildcard is not allowed with credentials")
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", errors.New("must be http(s)://host[:port]")
+	}
+	if u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", errors.New("must not have a path, query or credentials")
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
+
+func invalid(errs []error) error {
+	return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+}
+
+func stringValue(target *string) func(string) error {
+	return func(value string) error {
+		*target = value
+		return nil
+	}
+}
+
+func intValue(target *int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		*target = n
+		return nil
+	}
+}
+
+// durationValue reads Go duration syntax, such as 30s or 2m.
+func durationValue(target *time.Duration) func(string) error {
+	return func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("must be a duration such as 30s or 5m")
+		}
+		*target = d
+		return nil
+	}
+}
+
+// expiryValue reads a duration, or a bare number of unit as the token
+// expiry variables always accepted.
+func expiryValue(target *time.Duration, unit time.Duration) func(string) error {
+	return func(value string) error {
+		if n, err := strconv.Atoi(value); err == nil {
+			*target = time.Duration(n) * unit
+			return nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("must be a number of %s or a duration such as 15m", unitName(unit))
+		}
+		*target = d
+		return nil
+	}
+}
+
+func unitName(unit time.Duration) string {
+	if unit == time.Hour {
+		return "hours"
+	}
+	return "minutes"
+}
+
+// listValue reads a comma-separated list.
+func listValue(target *[]string) func(string) error {
+	return func(value string) error {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*target = items
+		return nil
+	}
+}
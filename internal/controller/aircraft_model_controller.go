@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type AircraftModelController struct {
+	service *service.AircraftModelService
+}
+
+func NewAircraftModelController(svc *service.AircraftModelService) *AircraftModelController {
+	return &AircraftModelController{service: svc}
+}
+
+func (c *AircraftModelController) CreateAircraftModel(ctx *gin.Context) {
+	var req models.CreateAircraftModelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.CreateAircraftModel(ctx.Request.Context(), &req)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *AircraftModelController) GetAircraftModel(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid aircraft model ID"})
+		return
+	}
+
+	resp, err := c.service.GetAircraftModel(ctx.Request.Context(), id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *AircraftModelController) ListAircraftModels(ctx *gin.Context) {
+	var query models.AircraftModelQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.ListAircraftModels(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *AircraftModelController) UpdateAircraftModel(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid aircraft model ID"})
+		return
+	}
+
+	var req models.UpdateAircraftModelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdateAircraftModel(ctx.Request.Context(), id, &req)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *AircraftModelController) DeleteAircraftModel(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid aircraft model ID"})
+		return
+	}
+
+	if err := c.service.DeleteAircraftModel(ctx.Request.Context(), id); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *AircraftModelController) ScaffoldPlane(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid aircraft model ID"})
+		return
+	}
+
+	var req models.ScaffoldPlaneRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.ScaffoldPlane(ctx.Request.Context(), id, &req, userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *AircraftModelController) handleError(ctx *gin.Context, err error) {
+	switch err.Error() {
+	case service.AircraftModelNotFoundErr, service.CatalogPartNotFoundErr:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.SlotNameErr, service.SlotNotFoundErr, service.SlotOverfilledErr, service.SlotLimitRequiredErr,
+		service.SerialNumberRepeatedErr, service.CatalogModelErr, service.CatalogLimitErr:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.AircraftModelExistsErr, service.AircraftModelInUseErr, service.PlaneExistsErr, service.PlanePartExistsErr:
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
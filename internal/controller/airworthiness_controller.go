@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type AirworthinessController struct {
+	service *service.AirworthinessService
+}
+
+func NewAirworthinessController(svc *service.AirworthinessService) *AirworthinessController {
+	return &AirworthinessController{service: svc}
+}
+
+func (c *AirworthinessController) GetPlaneAirworthiness(ctx *gin.Context) {
+	planeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
+		return
+	}
+
+	resp, err := c.service.GetReport(ctx.Request.Context(), planeID)
+	if err != nil {
+		if err.Error() == service.PlaneNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type AuditController struct {
+	service *service.AuditService
+}
+
+func NewAuditController(svc *service.AuditService) *AuditController {
+	return &AuditController{service: svc}
+}
+
+func (c *AuditController) GetAuditLog(ctx *gin.Context) {
+	var query models.AuditQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.List(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *AuditController) VerifyAuditLog(ctx *gin.Context) {
+	resp, err := c.service.Verify(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
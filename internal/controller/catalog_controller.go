@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type CatalogController struct {
+	service *service.CatalogService
+}
+
+func NewCatalogController(svc *service.CatalogService) *CatalogController {
+	return &CatalogController{service: svc}
+}
+
+func (c *CatalogController) CreateCatalogPart(ctx *gin.Context) {
+	var req models.CreateCatalogPartRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.CreateCatalogPart(ctx.Request.Context(), &req)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *CatalogController) GetCatalogPart(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid catalog part ID"})
+		return
+	}
+
+	resp, err := c.service.GetCatalogPart(ctx.Request.Context(), id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *CatalogController) ListCatalogParts(ctx *gin.Context) {
+	var query models.CatalogQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.ListCatalogParts(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *CatalogController) UpdateCatalogPart(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid catalog part ID"})
+		return
+	}
+
+	var req models.UpdateCatalogPartRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.UpdateCatalogPart(ctx.Request.Context(), id, &req)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *CatalogController) DeleteCatalogPart(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid catalog part ID"})
+		return
+	}
+
+	if err := c.service.DeleteCatalogPart(ctx.Request.Context(), id); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CatalogController) handleError(ctx *gin.Context, err error) {
+	switch err.Error() {
+	case service.CatalogPartNotFoundErr:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.CatalogPartExistsErr, service.CatalogPartInUseErr:
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type ExportController struct {
+	service *service.ExportService
+}
+
+func NewExportController(svc *service.ExportService) *ExportController {
+	return &ExportController{service: svc}
+}
+
+func (c *ExportController) ExportPlanes(ctx *gin.Context) {
+	var query models.PlaneQuery
+	format, ok := bindExportQuery(ctx, &query)
+	if !ok {
+		return
+	}
+
+	streamExport(ctx, "planes", format, func(reqCtx context.Context, w io.Writer) error {
+		return c.service.ExportPlanes(reqCtx, w, format, &query)
+	})
+}
+
+func (c *ExportController) ExportParts(ctx *gin.Context) {
+	var query models.PartQuery
+	format, ok := bindExportQuery(ctx, &query)
+	if !ok {
+		return
+	}
+
+	streamExport(ctx, "parts", format, func(reqCtx context.Context, w io.Writer) error {
+		return c.service.ExportParts(reqCtx, w, format, &query)
+	})
+}
+
+func (c *ExportController) ExportMaintenanceAlerts(ctx *gin.Context) {
+	var query models.MaintenanceAlertQuery
+	format, ok := bindExportQuery(ctx, &query)
+	if !ok {
+		return
+	}
+
+	streamExport(ctx, "maintenance-alerts", format, func(reqCtx context.Context, w io.Writer) error {
+		return c.service.ExportMaintenanceAlerts(reqCtx, w, format, &query)
+	})
+}
+
+// bindExportQuery binds the list filters into query and returns the export
+// format, writing a 400 response when either is invalid.
+func bindExportQuery(ctx *gin.Context, query any) (string, bool) {
+	var export models.ExportQuery
+	if err := ctx.ShouldBindQuery(&export); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	if !bindListQuery(ctx, query) {
+		return "", false
+	}
+	return export.FormatOrDefault(), true
+}
+
+// streamExport sends the export as a file download. Errors before the first
+// byte get a normal JSON error; once rows have been sent the status is
+// already committed, so the response is cut short instead.
+//
+// The server's write timeout is sized for ordinary responses, so the
+// deadline is pushed out to match the time the stream itself is allowed.
+func streamExport(ctx *gin.Context, name, format string, export func(context.Context, io.Writer) error) {
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Now().Add(repository.StreamTimeout)); err != nil {
+		_ = ctx.Error(fmt.Errorf("failed to extend write deadline: %w", err))
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == models.ExportFormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("2006-01-02"), format)
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Status(http.StatusOK)
+
+	if err := export(ctx.Request.Context(), ctx.Writer); err != nil {
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Type")
+			ctx.Writer.Header().Del("Content-Disposition")
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		_ = ctx.Error(err)
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type FlightController struct {
+	service *service.FlightService
+}
+
+func NewFlightController(svc *service.FlightService) *FlightController {
+	return &FlightController{service: svc}
+}
+
+func (c *FlightController) RecordFlight(ctx *gin.Context) {
+	planeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
+		return
+	}
+
+	var req models.CreateFlightRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.RecordFlight(ctx.Request.Context(), planeID, &req, userID)
+	if err != nil {
+		if err.Error() == service.FlightPlaneNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PlaneNotOperationalErr || err.Error() == service.PlanePartsOverLimitErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *FlightController) GetFlight(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("flightId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid flight ID"})
+		return
+	}
+
+	resp, err := c.service.GetFlight(ctx.Request.Context(), id)
+	if err != nil {
+		if err.Error() == service.FlightNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *FlightController) GetFlightsByPlane(ctx *gin.Context) {
+	planeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
+		return
+	}
+
+	var query models.PaginationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.GetFlightsByPlane(ctx.Request.Context(), planeID, &query)
+	if err != nil {
+		if err.Error() == service.FlightPlaneNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
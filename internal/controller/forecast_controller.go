@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type ForecastController struct {
+	service *service.ForecastService
+}
+
+func NewForecastController(svc *service.ForecastService) *ForecastController {
+	return &ForecastController{service: svc}
+}
+
+func (c *ForecastController) GetPlaneForecast(ctx *gin.Context) {
+	planeID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
+		return
+	}
+
+	var query models.ForecastQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.GetPlaneForecast(ctx.Request.Context(), planeID, &query)
+	if err != nil {
+		if err.Error() == service.ForecastPlaneNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *ForecastController) GetFleetForecast(ctx *gin.Context) {
+	var query models.FleetForecastQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.GetFleetForecast(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
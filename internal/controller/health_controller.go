@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type HealthController struct {
+	service *service.HealthService
+}
+
+func NewHealthController(svc *service.HealthService) *HealthController {
+	return &HealthController{service: svc}
+}
+
+// Healthz reports that the process is up and serving. It checks no
+// dependencies, so a database outage doesn't get healthy instances
+// restarted.
+func (c *HealthController) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": models.HealthStatusOK})
+}
+
+// Readyz reports whether the instance should receive traffic, answering
+// 503 while the database is down or behind on migrations.
+func (c *HealthController) Readyz(ctx *gin.Context) {
+	report := c.service.Ready(ctx.Request.Context())
+	if !report.Ready {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+// maxImportBytes caps the request body of a single import.
+const maxImportBytes = 10 << 20
+
+type ImportController struct {
+	service *service.ImportService
+}
+
+func NewImportController(svc *service.ImportService) *ImportController {
+	return &ImportController{service: svc}
+}
+
+// Import accepts either a JSON body with planes and parts arrays, or a
+// multipart form with optional CSV files named planes and parts.
+func (c *ImportController) Import(ctx *gin.Context) {
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid dry_run"})
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportBytes)
+	userID, _ := middleware.GetUserID(ctx)
+
+	var resp *models.ImportResponse
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		planesCSV, closePlanes, err := formFile(ctx, models.ImportSectionPlanes)
+		if err != nil {
+			importBodyError(ctx, err)
+			return
+		}
+		defer closePlanes()
+		partsCSV, closeParts, err := formFile(ctx, models.ImportSectionParts)
+		if err != nil {
+			importBodyError(ctx, err)
+			return
+		}
+		defer closeParts()
+
+		resp, err = c.service.ImportCSV(ctx.Request.Context(), planesCSV, partsCSV, dryRun, userID)
+	} else {
+		var req models.ImportRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			importBodyError(ctx, err)
+			return
+		}
+
+		resp, err = c.service.Import(ctx.Request.Context(), &req, dryRun, userID)
+	}
+	if err != nil {
+		if err.Error() == service.ImportEmptyErr || err.Error() == service.ImportTooLargeErr {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch {
+	case !resp.Valid:
+		ctx.JSON(http.StatusUnprocessableEntity, resp)
+	case resp.DryRun:
+		ctx.JSON(http.StatusOK, resp)
+	default:
+		ctx.JSON(http.StatusCreated, resp)
+	}
+}
+
+// formFile opens an optional upload, returning a nil reader when the form
+// has no file under name.
+func formFile(ctx *gin.Context, name string) (io.Reader, func(), error) {
+	header, err := ctx.FormFile(name)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, func() {}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, func() { file.Close() }, nil
+}
+
+func importBodyError(ctx *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "import body exceeds 10MB"})
+		return
+	}
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
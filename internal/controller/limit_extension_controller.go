@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type LimitExtensionController struct {
+	service *service.LimitExtensionService
+}
+
+func NewLimitExtensionController(svc *service.LimitExtensionService) *LimitExtensionController {
+	return &LimitExtensionController{service: svc}
+}
+
+func (c *LimitExtensionController) RequestExtension(ctx *gin.Context) {
+	partID, err := strconv.ParseInt(ctx.Param("partId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid part ID"})
+		return
+	}
+
+	var req models.CreateLimitExtensionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.RequestExtension(ctx.Request.Context(), partID, &req, userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *LimitExtensionController) GetPartExtensions(ctx *gin.Context) {
+	partID, err := strconv.ParseInt(ctx.Param("partId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid part ID"})
+		return
+	}
+
+	var query models.LimitExtensionQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query.PartID = &partID
+
+	resp, err := c.service.ListExtensions(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *LimitExtensionController) ListExtensions(ctx *gin.Context) {
+	var query models.LimitExtensionQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.ListExtensions(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *LimitExtensionController) GetExtension(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("extensionId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid extension ID"})
+		return
+	}
+
+	resp, err := c.service.GetExtension(ctx.Request.Context(), id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *LimitExtensionController) ApproveExtension(ctx *gin.Context) {
+	c.review(ctx, c.service.ApproveExtension)
+}
+
+func (c *LimitExtensionController) RejectExtension(ctx *gin.Context) {
+	c.review(ctx, c.service.RejectExtension)
+}
+
+type reviewFunc func(ctx context.Context, id int64, req *models.ReviewLimitExtensionRequest, actorID int64) (*models.PartLimitExtensionResponse, error)
+
+func (c *LimitExtensionController) review(ctx *gin.Context, review reviewFunc) {
+	id, err := strconv.ParseInt(ctx.Param("extensionId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid extension ID"})
+		return
+	}
+
+	// The note is optional, so an empty body is accepted.
+	var req models.ReviewLimitExtensionRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := review(ctx.Request.Context(), id, &req, userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *LimitExtensionController) handleError(ctx *gin.Context, err error) {
+	switch err.Error() {
+	case service.LimitExtensionNotFoundErr, service.PlanePartNotFoundErr:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.LimitExtensionSelfReviewErr:
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case service.LimitExtensionReviewedErr:
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+// bindListQuery binds a list's query string and writes the error response
+// when it fails. Only admins may ask for soft-deleted records.
+func bindListQuery(ctx *gin.Context, query any) bool {
+	if err := ctx.ShouldBindQuery(query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	if q, ok := query.(interface{ IncludesDeleted() bool }); ok && q.IncludesDeleted() {
+		if role, _ := middleware.GetUserRole(ctx); role != models.RoleAdmin {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "only admins can list deleted records"})
+			return false
+		}
+	}
+	return true
+}
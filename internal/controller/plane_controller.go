@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
 	"github.com/JasperRosales/aircraft-system-be/internal/models"
 	"github.com/JasperRosales/aircraft-system-be/internal/service"
 )
@@ -79,17 +80,18 @@ func (c *PlaneController) GetPlaneByTailNumber(ctx *gin.Context) {
 }
 
 func (c *PlaneController) GetAllPlanes(ctx *gin.Context) {
-	planes, err := c.service.GetAllPlanes(ctx.Request.Context())
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var query models.PlaneQuery
+	if !bindListQuery(ctx, &query) {
 		return
 	}
 
-	if planes == nil {
-		planes = []models.PlaneResponse{}
+	resp, err := c.service.GetAllPlanes(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, planes)
+	ctx.JSON(http.StatusOK, resp)
 }
 
 func (c *PlaneController) UpdatePlane(ctx *gin.Context) {
@@ -118,6 +120,69 @@ func (c *PlaneController) UpdatePlane(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// UpdatePlaneStatus moves a plane to a new operational status.
+func (c *PlaneController) UpdatePlaneStatus(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
+		return
+	}
+
+	var req models.UpdatePlaneStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	role, _ := middleware.GetUserRole(ctx)
+	resp, err := c.service.ChangeStatus(ctx.Request.Context(), id, &req, userID, role)
+	if err != nil {
+		if err.Error() == service.PlaneNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PermissionDenied {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PlaneStatusTransitionErr || err.Error() == service.PlanePartsOverLimitErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *PlaneController) GetPlaneStatusHistory(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
+		return
+	}
+
+	var query models.PaginationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.GetStatusHistory(ctx.Request.Context(), id, &query)
+	if err != nil {
+		if err.Error() == service.PlaneNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 func (c *PlaneController) DeletePlane(ctx *gin.Context) {
 	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	if err != nil {
@@ -138,29 +203,72 @@ func (c *PlaneController) DeletePlane(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
-func (c *PlaneController) GetPlaneWithParts(ctx *gin.Context) {
+// RestorePlane undeletes a plane and the parts deleted with it.
+func (c *PlaneController) RestorePlane(ctx *gin.Context) {
 	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
 		return
 	}
 
-	plane, parts, err := c.service.GetPlaneWithParts(ctx.Request.Context(), id)
+	resp, err := c.service.RestorePlane(ctx.Request.Context(), id)
 	if err != nil {
 		if err.Error() == service.PlaneNotFoundErr {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == service.PlaneNotDeletedErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	if parts == nil {
-		parts = []models.PlanePartResponse{}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// PurgePlane permanently removes a deleted plane.
+func (c *PlaneController) PurgePlane(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"plane": plane,
-		"parts": parts,
-	})
+	err = c.service.PurgePlane(ctx.Request.Context(), id)
+	if err != nil {
+		if err.Error() == service.PlaneNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PlaneNotDeletedErr || err.Error() == service.PlaneHasHistoryErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *PlaneController) GetPlaneWithParts(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
+		return
+	}
+
+	resp, err := c.service.GetPlaneWithParts(ctx.Request.Context(), id)
+	if err != nil {
+		if err.Error() == service.PlaneNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
 	"github.com/JasperRosales/aircraft-system-be/internal/models"
 	"github.com/JasperRosales/aircraft-system-be/internal/service"
 )
@@ -32,9 +33,10 @@ func (c *PlanePartController) AddPart(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.AddPart(ctx.Request.Context(), &req)
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.AddPart(ctx.Request.Context(), &req, userID)
 	if err != nil {
-		if err.Error() == service.PlaneNotFoundErrPart {
+		if err.Error() == service.PlaneNotFoundErrPart || err.Error() == service.CatalogPartNotFoundErr {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
@@ -42,6 +44,10 @@ func (c *PlanePartController) AddPart(ctx *gin.Context) {
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == service.CatalogModelErr || err.Error() == service.CatalogLimitErr {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -75,9 +81,13 @@ func (c *PlanePartController) GetPartsByPlane(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid plane ID"})
 		return
 	}
-	category := ctx.Query("category")
 
-	parts, err := c.service.GetPartsByPlane(ctx.Request.Context(), planeID, &category)
+	var query models.PartQuery
+	if !bindListQuery(ctx, &query) {
+		return
+	}
+
+	resp, err := c.service.GetPartsByPlane(ctx.Request.Context(), planeID, &query)
 	if err != nil {
 		if err.Error() == service.PlaneNotFoundErrPart {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -87,25 +97,22 @@ func (c *PlanePartController) GetPartsByPlane(ctx *gin.Context) {
 		return
 	}
 
-	if parts == nil {
-		parts = []models.PlanePartResponse{}
-	}
-
-	ctx.JSON(http.StatusOK, parts)
+	ctx.JSON(http.StatusOK, resp)
 }
 
 func (c *PlanePartController) GetAllParts(ctx *gin.Context) {
-	parts, err := c.service.GetAllParts(ctx.Request.Context())
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var query models.PartQuery
+	if !bindListQuery(ctx, &query) {
 		return
 	}
 
-	if parts == nil {
-		parts = []models.PlanePartResponse{}
+	resp, err := c.service.GetAllParts(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, parts)
+	ctx.JSON(http.StatusOK, resp)
 }
 
 func (c *PlanePartController) UpdatePart(ctx *gin.Context) {
@@ -121,12 +128,27 @@ func (c *PlanePartController) UpdatePart(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.UpdatePart(ctx.Request.Context(), id, &req)
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.UpdatePart(ctx.Request.Context(), id, &req, userID)
 	if err != nil {
 		if err.Error() == service.PlanePartNotFoundErr || err.Error() == service.PlanePartExistsErr {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == service.CatalogPartNotFoundErr || err.Error() == service.PlaneNotFoundErrPart {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.CatalogModelErr || err.Error() == service.CatalogLimitErr {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.LimitRaiseErr || err.Error() == service.CyclesLimitRaiseErr ||
+			err.Error() == service.CalendarRaiseErr || err.Error() == service.CatalogFieldErr ||
+			err.Error() == service.CatalogRelinkErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -147,16 +169,82 @@ func (c *PlanePartController) UpdatePartUsage(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.UpdatePartUsage(ctx.Request.Context(), id, &req)
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.UpdatePartUsage(ctx.Request.Context(), id, &req, userID)
+	if err != nil {
+		if err.Error() == service.PlanePartNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if isUsageValidationErr(err) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PlaneNotOperationalErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *PlanePartController) LogPartUsage(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("partId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid part ID"})
+		return
+	}
+
+	var req models.LogPartUsageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.LogPartUsage(ctx.Request.Context(), id, &req, userID)
 	if err != nil {
 		if err.Error() == service.PlanePartNotFoundErr {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		if err.Error() == service.InvalidUsageHoursErr {
+		if isUsageValidationErr(err) {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == service.PlaneNotOperationalErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *PlanePartController) GetPartUsageHistory(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("partId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid part ID"})
+		return
+	}
+
+	var query models.PaginationQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.GetPartUsageHistory(ctx.Request.Context(), id, &query)
+	if err != nil {
+		if err.Error() == service.PlanePartNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -184,23 +272,182 @@ func (c *PlanePartController) DeletePart(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
-func (c *PlanePartController) GetPartsNeedingMaintenance(ctx *gin.Context) {
-	thresholdStr := ctx.DefaultQuery("threshold", "80")
-	threshold, err := strconv.ParseFloat(thresholdStr, 64)
+// RestorePart undeletes a part. Parts on a deleted plane are restored with
+// the plane.
+func (c *PlanePartController) RestorePart(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("partId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid part ID"})
+		return
+	}
+
+	resp, err := c.service.RestorePart(ctx.Request.Context(), id)
+	if err != nil {
+		if err.Error() == service.PlanePartNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PartNotDeletedErr || err.Error() == service.PartPlaneDeletedErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// PurgePart permanently removes a deleted part.
+func (c *PlanePartController) PurgePart(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("partId"), 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid threshold value"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid part ID"})
+		return
+	}
+
+	err = c.service.PurgePart(ctx.Request.Context(), id)
+	if err != nil {
+		if err.Error() == service.PlanePartNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PartNotDeletedErr || err.Error() == service.PartHasHistoryErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *PlanePartController) InstallPart(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("partId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid part ID"})
+		return
+	}
+
+	var req models.InstallPartRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.InstallPart(ctx.Request.Context(), id, &req, userID)
+	if err != nil {
+		if err.Error() == service.PlanePartNotFoundErr || err.Error() == service.PlaneNotFoundErrPart || err.Error() == service.CatalogPartNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PartInstalledErr || err.Error() == service.PlaneNotOperationalErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.CatalogModelErr {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *PlanePartController) RemovePart(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("partId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid part ID"})
+		return
+	}
+
+	// The removal reason is optional, so an empty body is accepted.
+	var req models.RemovePartRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.RemovePart(ctx.Request.Context(), id, &req, userID)
+	if err != nil {
+		if err.Error() == service.PlanePartNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PartNotInstalledErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	parts, err := c.service.GetPartsNeedingMaintenance(ctx.Request.Context(), threshold)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *PlanePartController) GetSpareParts(ctx *gin.Context) {
+	var query models.PartQuery
+	if !bindListQuery(ctx, &query) {
+		return
+	}
+
+	resp, err := c.service.GetSpareParts(ctx.Request.Context(), &query)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	if parts == nil {
-		parts = []models.PlanePartResponse{}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *PlanePartController) GetPartHistoryBySerial(ctx *gin.Context) {
+	serialNumber := ctx.Param("serial")
+	if serialNumber == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "serial number is required"})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, parts)
+	resp, err := c.service.GetPartHistoryBySerial(ctx.Request.Context(), serialNumber)
+	if err != nil {
+		if err.Error() == service.PlanePartNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *PlanePartController) GetPartsNeedingMaintenance(ctx *gin.Context) {
+	var query models.MaintenanceAlertQuery
+	if !bindListQuery(ctx, &query) {
+		return
+	}
+
+	resp, err := c.service.GetPartsNeedingMaintenance(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func isUsageValidationErr(err error) bool {
+	switch err.Error() {
+	case service.NegativeUsageErr,
+		service.NegativeCyclesErr,
+		service.EmptyUsageEntryErr:
+		return true
+	}
+	return false
 }
@@ -6,19 +6,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
 	"github.com/JasperRosales/aircraft-system-be/internal/models"
 	"github.com/JasperRosales/aircraft-system-be/internal/service"
 )
 
 type UserController struct {
 	service    *service.UserService
-	jwtService *service.JWTService
+	sessionSvc *service.SessionService
 }
 
-func NewUserController(svc *service.UserService, jwtSvc *service.JWTService) *UserController {
+func NewUserController(svc *service.UserService, sessionSvc *service.SessionService) *UserController {
 	return &UserController{
 		service:    svc,
-		jwtService: jwtSvc,
+		sessionSvc: sessionSvc,
 	}
 }
 
@@ -36,6 +37,14 @@ func (c *UserController) Register(ctx *gin.Context) {
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == service.InviteRequiredErr {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.InvalidInviteErr {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -61,23 +70,113 @@ func (c *UserController) Login(ctx *gin.Context) {
 		return
 	}
 
-	// 🔥 PRODUCTION COOKIE (Render + HTTPS + Cross-Origin Safe)
+	c.setAuthCookies(ctx, resp.Token, resp.RefreshToken)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"user": resp.User,
+	})
+}
+
+// Refresh rotates the refresh token cookie and issues a new access token.
+func (c *UserController) Refresh(ctx *gin.Context) {
+	refreshToken, err := ctx.Cookie(service.RefreshCookieName)
+	if err != nil || refreshToken == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": service.InvalidRefreshTokenErr})
+		return
+	}
+
+	tokens, err := c.sessionSvc.Refresh(ctx.Request.Context(), refreshToken)
+	if err != nil {
+		if err.Error() == service.InvalidRefreshTokenErr || err.Error() == service.SessionRevokedErr {
+			c.clearAuthCookies(ctx)
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.setAuthCookies(ctx, tokens.AccessToken, tokens.RefreshToken)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "session refreshed",
+	})
+}
+
+// Logout revokes the current session server-side and clears the cookies.
+func (c *UserController) Logout(ctx *gin.Context) {
+	if refreshToken, err := ctx.Cookie(service.RefreshCookieName); err == nil && refreshToken != "" {
+		if err := c.sessionSvc.End(ctx.Request.Context(), refreshToken); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.clearAuthCookies(ctx)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "logged out successfully",
+	})
+}
+
+// RevokeSessions logs a user out of every session.
+func (c *UserController) RevokeSessions(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	role, _ := middleware.GetUserRole(ctx)
+	count, err := c.service.RevokeSessions(ctx.Request.Context(), id, userID, role)
+	if err != nil {
+		if err.Error() == service.UserNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.PermissionDenied {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if id == userID {
+		c.clearAuthCookies(ctx)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message":          "sessions revoked successfully",
+		"revoked_sessions": count,
+	})
+}
+
+// 🔥 PRODUCTION COOKIES (Render + HTTPS + Cross-Origin Safe). The refresh
+// token is only sent to the user routes that need it.
+func (c *UserController) setAuthCookies(ctx *gin.Context, accessToken, refreshToken string) {
 	http.SetCookie(ctx.Writer, &http.Cookie{
 		Name:     service.CookieName,
-		Value:    resp.Token,
+		Value:    accessToken,
 		Path:     "/",
-		MaxAge:   int(c.jwtService.GetExpiryDuration().Seconds()),
+		MaxAge:   int(c.sessionSvc.GetAccessExpiryDuration().Seconds()),
 		HttpOnly: true,
 		Secure:   true,                  // REQUIRED for HTTPS
 		SameSite: http.SameSiteNoneMode, // REQUIRED for cross-origin
 	})
-
-	ctx.JSON(http.StatusOK, gin.H{
-		"user": resp.User,
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     service.RefreshCookieName,
+		Value:    refreshToken,
+		Path:     "/api/users",
+		MaxAge:   int(c.sessionSvc.GetRefreshExpiryDuration().Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
 	})
 }
 
-func (c *UserController) Logout(ctx *gin.Context) {
+func (c *UserController) clearAuthCookies(ctx *gin.Context) {
 	http.SetCookie(ctx.Writer, &http.Cookie{
 		Name:     service.CookieName,
 		Value:    "",
@@ -87,9 +186,14 @@ func (c *UserController) Logout(ctx *gin.Context) {
 		Secure:   true,
 		SameSite: http.SameSiteNoneMode,
 	})
-
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "logged out successfully",
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     service.RefreshCookieName,
+		Value:    "",
+		Path:     "/api/users",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
 	})
 }
 
@@ -120,12 +224,18 @@ func (c *UserController) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.GetByID(ctx.Request.Context(), id)
+	userID, _ := middleware.GetUserID(ctx)
+	role, _ := middleware.GetUserRole(ctx)
+	resp, err := c.service.GetByID(ctx.Request.Context(), id, userID, role)
 	if err != nil {
 		if err.Error() == service.UserNotFoundErr {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == service.PermissionDenied {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -134,17 +244,18 @@ func (c *UserController) GetByID(ctx *gin.Context) {
 }
 
 func (c *UserController) GetAll(ctx *gin.Context) {
-	users, err := c.service.GetAll(ctx.Request.Context())
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var query models.UserQuery
+	if !bindListQuery(ctx, &query) {
 		return
 	}
 
-	if users == nil {
-		users = []models.UserResponse{}
+	resp, err := c.service.GetAll(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, users)
+	ctx.JSON(http.StatusOK, resp)
 }
 
 func (c *UserController) Update(ctx *gin.Context) {
@@ -160,12 +271,18 @@ func (c *UserController) Update(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := c.service.Update(ctx.Request.Context(), id, &req)
+	userID, _ := middleware.GetUserID(ctx)
+	role, _ := middleware.GetUserRole(ctx)
+	resp, err := c.service.Update(ctx.Request.Context(), id, &req, userID, role)
 	if err != nil {
 		if err.Error() == service.UserNotFoundErr {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == service.PermissionDenied {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -192,3 +309,100 @@ func (c *UserController) Delete(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// Restore undeletes a user account.
+func (c *UserController) Restore(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	resp, err := c.service.Restore(ctx.Request.Context(), id)
+	if err != nil {
+		if err.Error() == service.UserNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.UserNotDeletedErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Purge permanently removes a deleted user account.
+func (c *UserController) Purge(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	err = c.service.Purge(ctx.Request.Context(), id)
+	if err != nil {
+		if err.Error() == service.UserNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == service.UserNotDeletedErr {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *UserController) CreateInvite(ctx *gin.Context) {
+	var req models.CreateInviteRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.CreateInvite(ctx.Request.Context(), &req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *UserController) GetInvites(ctx *gin.Context) {
+	resp, err := c.service.GetInvites(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *UserController) RevokeInvite(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid invite ID"})
+		return
+	}
+
+	if err := c.service.RevokeInvite(ctx.Request.Context(), id); err != nil {
+		if err.Error() == service.InviteNotFoundErr {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "invite revoked successfully"})
+}
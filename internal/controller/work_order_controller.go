@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+type WorkOrderController struct {
+	service *service.WorkOrderService
+}
+
+func NewWorkOrderController(svc *service.WorkOrderService) *WorkOrderController {
+	return &WorkOrderController{service: svc}
+}
+
+func (c *WorkOrderController) CreateWorkOrder(ctx *gin.Context) {
+	var req models.CreateWorkOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	resp, err := c.service.CreateWorkOrder(ctx.Request.Context(), &req, userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+func (c *WorkOrderController) GetWorkOrder(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid work order ID"})
+		return
+	}
+
+	resp, err := c.service.GetWorkOrder(ctx.Request.Context(), id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *WorkOrderController) ListWorkOrders(ctx *gin.Context) {
+	var query models.WorkOrderQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.ListWorkOrders(ctx.Request.Context(), &query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *WorkOrderController) AssignWorkOrder(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid work order ID"})
+		return
+	}
+
+	var req models.AssignWorkOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := c.service.AssignWorkOrder(ctx.Request.Context(), id, &req)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *WorkOrderController) StartWorkOrder(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid work order ID"})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	role, _ := middleware.GetUserRole(ctx)
+	resp, err := c.service.StartWorkOrder(ctx.Request.Context(), id, userID, role)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *WorkOrderController) SignOffWorkOrder(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid work order ID"})
+		return
+	}
+
+	var req models.SignOffWorkOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(ctx)
+	role, _ := middleware.GetUserRole(ctx)
+	resp, err := c.service.SignOffWorkOrder(ctx.Request.Context(), id, &req, userID, role)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *WorkOrderController) CloseWorkOrder(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid work order ID"})
+		return
+	}
+
+	resp, err := c.service.CloseWorkOrder(ctx.Request.Context(), id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *WorkOrderController) handleError(ctx *gin.Context, err error) {
+	switch err.Error() {
+	case service.WorkOrderNotFoundErr, service.WorkOrderPartNotFoundErr, service.WorkOrderAssigneeNotFoundErr,
+		service.CatalogPartNotFoundErr:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.WorkOrderAssigneeRoleErr, service.WorkOrderItemsMismatchErr, service.CatalogLimitErr:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.WorkOrderNotAssigneeErr:
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case service.WorkOrderTransitionErr, service.WorkOrderUnassignedErr, service.WorkOrderReplacementExistsErr,
+		service.LimitRaiseErr, service.CyclesLimitRaiseErr, service.CalendarRaiseErr, service.CatalogFieldErr:
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
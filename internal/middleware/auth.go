@@ -1,45 +1,63 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
 	"github.com/JasperRosales/aircraft-system-be/internal/service"
 	"github.com/JasperRosales/aircraft-system-be/internal/util"
 )
 
-func AuthMiddleware(logger *util.Logger, jwtSvc *service.JWTService) gin.HandlerFunc {
+// TokenValidator resolves an access token to its claims. SessionService is
+// the production implementation and also rejects revoked sessions.
+type TokenValidator interface {
+	ValidateAccessToken(ctx context.Context, token string) (*service.JWTClaims, error)
+}
+
+func AuthMiddleware(logger *util.Logger, validator TokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token, err := c.Cookie(service.CookieName)
-		logger.Info("Auth: Checking cookie",
-			"cookie_name", service.CookieName,
-			"token", token,
-			"error", err,
-		)
+		ctx := c.Request.Context()
 
+		// Only where the token came from is logged, never the token itself.
+		source := "cookie"
+		token, err := c.Cookie(service.CookieName)
 		if err != nil || token == "" {
+			source = "header"
 			authHeader := c.GetHeader("Authorization")
-			logger.Info("Auth: Checking header",
-				"header", authHeader,
-			)
 			if authHeader != "" && strings.HasPrefix(authHeader, "Bearer ") {
 				token = strings.TrimPrefix(authHeader, "Bearer ")
 			}
 		}
 
 		if token == "" {
-			logger.Warn("Auth: No token found, rejecting request")
+			logger.WarnContext(ctx, "Auth: No token found, rejecting request")
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error": "authentication required",
 			})
 			return
 		}
+		logger.DebugContext(ctx, "Auth: Token found",
+			"source", source,
+		)
 
-		claims, err := jwtSvc.ValidateToken(token)
+		claims, err := validator.ValidateAccessToken(ctx, token)
 		if err != nil {
-			logger.Warn("Auth: Token validation failed",
+			if !errors.Is(err, service.InvalidTokenErr) && !errors.Is(err, service.ExpiredTokenErr) &&
+				err.Error() != service.SessionRevokedErr {
+				logger.ErrorContext(ctx, "Auth: Failed to verify session",
+					"error", err,
+				)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"error": "failed to verify session",
+				})
+				return
+			}
+			logger.WarnContext(ctx, "Auth: Token validation failed",
 				"error", err,
 			)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
@@ -51,8 +69,10 @@ func AuthMiddleware(logger *util.Logger, jwtSvc *service.JWTService) gin.Handler
 		c.Set("user_id", claims.UserID)
 		c.Set("user_name", claims.Name)
 		c.Set("user_role", claims.Role)
+		c.Set("session_id", claims.SessionID)
+		c.Request = c.Request.WithContext(util.WithActorID(ctx, claims.UserID))
 
-		logger.Info("Auth: User authenticated",
+		logger.DebugContext(ctx, "Auth: User authenticated",
 			"user_id", claims.UserID,
 			"name", claims.Name,
 			"role", claims.Role,
@@ -61,19 +81,22 @@ func AuthMiddleware(logger *util.Logger, jwtSvc *service.JWTService) gin.Handler
 	}
 }
 
+// RoleMiddleware only lets through users with requiredRole. Admins pass every
+// role check.
 func RoleMiddleware(logger *util.Logger, requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		role, exists := c.Get("user_role")
 		if !exists {
-			logger.Warn("Role: User not authenticated")
+			logger.WarnContext(ctx, "Role: User not authenticated")
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error": "authentication required",
 			})
 			return
 		}
 
-		if role.(string) != requiredRole && role.(string) != "admin" {
-			logger.Warn("Role: Insufficient permissions",
+		if role.(string) != requiredRole && role.(string) != models.RoleAdmin {
+			logger.WarnContext(ctx, "Role: Insufficient permissions",
 				"user_role", role.(string),
 				"required_role", requiredRole,
 			)
@@ -83,7 +106,7 @@ func RoleMiddleware(logger *util.Logger, requiredRole string) gin.HandlerFunc {
 			return
 		}
 
-		logger.Info("Role: Access granted",
+		logger.DebugContext(ctx, "Role: Access granted",
 			"role", role.(string),
 		)
 		c.Next()
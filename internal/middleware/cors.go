@@ -1,23 +1,28 @@
 package middleware
 
 import (
-	"log"
-	"os"
-
 	"github.com/gin-gonic/gin"
 )
 
-func CORSMiddleware() gin.HandlerFunc {
+// CORSMiddleware allows credentialed requests from the configured origins.
+// A request from any other origin gets no CORS headers, so the browser
+// blocks it. Origins must already be normalized by config.Validate.
+func CORSMiddleware(origins []string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		allowed[origin] = true
+	}
+
 	return func(c *gin.Context) {
-		origin := os.Getenv("ORIGIN")
-		if origin == "" {
-			log.Fatal("ORIGIN environment variable not set")
-		}
+		c.Writer.Header().Add("Vary", "Origin")
 
-		c.Header("Access-Control-Allow-Origin", origin)
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Requested-With")
-		c.Header("Access-Control-Allow-Credentials", "true")
+		if origin := c.GetHeader("Origin"); allowed[origin] {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Requested-With, "+RequestIDHeader)
+			c.Header("Access-Control-Expose-Headers", RequestIDHeader)
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
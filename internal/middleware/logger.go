@@ -15,7 +15,7 @@ func LoggerMiddleware(logger *util.Logger) gin.HandlerFunc {
 		c.Next()
 
 		duration := time.Since(start)
-		logger.Info("Incoming request",
+		logger.InfoContext(c.Request.Context(), "Incoming request",
 			"method", c.Request.Method,
 			"path", c.Request.URL.Path,
 			"status", c.Writer.Status(),
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits which client-supplied IDs are trusted, so the value
+// is safe to log and store.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware tags every request with an ID, reusing the caller's
+// X-Request-ID when it is well-formed. The ID is echoed in the response
+// header and stored on the request context.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			token, err := util.GenerateToken()
+			if err != nil {
+				c.Next()
+				return
+			}
+			id = token[:32]
+		}
+
+		c.Set("request_id", id)
+		c.Request = c.Request.WithContext(util.WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+func GetRequestID(c *gin.Context) string {
+	return c.GetString("request_id")
+}
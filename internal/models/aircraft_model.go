@@ -0,0 +1,224 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// AircraftModel is a registered aircraft type and the component slots every
+// plane of that type must fill. Planes link to it by Model, compared
+// case-insensitively with Name.
+type AircraftModel struct {
+	ID           int64               `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name         string              `json:"name" gorm:"type:varchar(100);not null"`
+	Manufacturer string              `json:"manufacturer" gorm:"type:varchar(255);not null"`
+	CreatedAt    time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time           `json:"updated_at" gorm:"autoUpdateTime"`
+	Slots        []AircraftModelSlot `json:"slots" gorm:"foreignKey:AircraftModelID"`
+}
+
+// AircraftModelSlot is one required component position, such as "Engine"
+// with quantity 2. A slot with a catalog part only accepts that part number;
+// otherwise any part of the slot's category fills it.
+type AircraftModelSlot struct {
+	ID              int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	AircraftModelID int64  `json:"-" gorm:"not null;index"`
+	Name            string `json:"name" gorm:"type:varchar(100);not null"`
+	Category        string `json:"category" gorm:"type:varchar(150);not null"`
+	CatalogPartID   *int64 `json:"catalog_part_id" gorm:"index"`
+	Quantity        int    `json:"quantity" gorm:"not null"`
+}
+
+type AircraftModelSlotRequest struct {
+	Name          string `json:"name" binding:"required,min=2,max=100"`
+	Category      string `json:"category" binding:"required_without=CatalogPartID,excluded_with=CatalogPartID,omitempty,min=2,max=150"`
+	CatalogPartID *int64 `json:"catalog_part_id" binding:"omitempty,gt=0"`
+	Quantity      int    `json:"quantity" binding:"required,gt=0,max=100"`
+}
+
+type CreateAircraftModelRequest struct {
+	Name         string                     `json:"name" binding:"required,min=2,max=100"`
+	Manufacturer string                     `json:"manufacturer" binding:"required,min=2,max=255"`
+	Slots        []AircraftModelSlotRequest `json:"slots" binding:"omitempty,dive"`
+}
+
+// UpdateAircraftModelRequest changes a model. The name cannot change because
+// planes refer to it. A nil Slots leaves the template alone; an empty one
+// clears it.
+type UpdateAircraftModelRequest struct {
+	Manufacturer *string                    `json:"manufacturer" binding:"omitempty,min=2,max=255"`
+	Slots        []AircraftModelSlotRequest `json:"slots" binding:"omitempty,dive"`
+}
+
+// AircraftModelQuery filters and sorts GET /api/aircraft-models. Name and
+// manufacturer match case-insensitive substrings.
+type AircraftModelQuery struct {
+	PaginationQuery
+	Name         string `form:"name"`
+	Manufacturer string `form:"manufacturer"`
+	Sort         string `form:"sort" binding:"omitempty,oneof=id name manufacturer created_at"`
+	Order        string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// ScaffoldPlaneRequest creates a plane of a registered model together with
+// the parts filling its slots. Slots left out are reported as missing.
+type ScaffoldPlaneRequest struct {
+	TailNumber string                `json:"tail_number" binding:"required,min=2,max=50"`
+	Parts      []ScaffoldPartRequest `json:"parts" binding:"omitempty,max=500,dive"`
+}
+
+// ScaffoldPartRequest is one serialized part for a named slot. Name and
+// category come from the slot, or from its catalog part, which also supplies
+// any limits left unset.
+type ScaffoldPartRequest struct {
+	Slot              string  `json:"slot" binding:"required"`
+	SerialNumber      string  `json:"serial_number" binding:"required,min=2,max=100"`
+	UsageHours        float64 `json:"usage_hours" binding:"omitempty,gte=0"`
+	UsageLimitHours   float64 `json:"usage_limit_hours" binding:"omitempty,gt=0"`
+	UsageCycles       int     `json:"usage_cycles" binding:"omitempty,gte=0"`
+	UsageLimitCycles  *int    `json:"usage_limit_cycles" binding:"omitempty,gt=0"`
+	CalendarLimitDays *int    `json:"calendar_limit_days" binding:"omitempty,gt=0"`
+}
+
+type AircraftModelSlotResponse struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Category      string `json:"category"`
+	CatalogPartID *int64 `json:"catalog_part_id"`
+	Quantity      int    `json:"quantity"`
+}
+
+type AircraftModelResponse struct {
+	ID           int64                       `json:"id"`
+	Name         string                      `json:"name"`
+	Manufacturer string                      `json:"manufacturer"`
+	Slots        []AircraftModelSlotResponse `json:"slots"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
+
+// SlotStatus compares one slot with the parts installed in it.
+type SlotStatus struct {
+	SlotID        int64   `json:"slot_id"`
+	Name          string  `json:"name"`
+	Category      string  `json:"category"`
+	CatalogPartID *int64  `json:"catalog_part_id"`
+	Required      int     `json:"required"`
+	Installed     int     `json:"installed"`
+	Missing       int     `json:"missing"`
+	Surplus       int     `json:"surplus"`
+	PartIDs       []int64 `json:"part_ids"`
+}
+
+// SlotReport compares a plane's parts with its model's template. Complete
+// means no slot is missing parts; surplus and unslotted parts do not affect
+// it.
+type SlotReport struct {
+	AircraftModelID  int64        `json:"aircraft_model_id"`
+	Complete         bool         `json:"complete"`
+	Slots            []SlotStatus `json:"slots"`
+	UnslottedPartIDs []int64      `json:"unslotted_part_ids"`
+}
+
+func (m *AircraftModel) ToResponse() AircraftModelResponse {
+	resp := AircraftModelResponse{
+		ID:           m.ID,
+		Name:         m.Name,
+		Manufacturer: m.Manufacturer,
+		Slots:        make([]AircraftModelSlotResponse, len(m.Slots)),
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+	for i, slot := range m.Slots {
+		resp.Slots[i] = AircraftModelSlotResponse{
+			ID:            slot.ID,
+			Name:          slot.Name,
+			Category:      slot.Category,
+			CatalogPartID: slot.CatalogPartID,
+			Quantity:      slot.Quantity,
+		}
+	}
+	return resp
+}
+
+// Slot finds a slot by name, ignoring case.
+func (m *AircraftModel) Slot(name string) *AircraftModelSlot {
+	for i := range m.Slots {
+		if strings.EqualFold(m.Slots[i].Name, name) {
+			return &m.Slots[i]
+		}
+	}
+	return nil
+}
+
+// Matches reports whether part can fill the slot: by part number when the
+// slot names one, otherwise by category.
+func (s *AircraftModelSlot) Matches(part *PlanePart) bool {
+	if s.CatalogPartID != nil {
+		return part.CatalogPartID != nil && *part.CatalogPartID == *s.CatalogPartID
+	}
+	return strings.EqualFold(s.Category, part.Category)
+}
+
+// CheckSlots assigns each part to the first matching slot with room left,
+// preferring slots that name the part's part number over category slots.
+// A part whose matching slots are all full counts as surplus in the first
+// of them; a part no slot matches is unslotted.
+func (m *AircraftModel) CheckSlots(parts []PlanePart) SlotReport {
+	report := SlotReport{
+		AircraftModelID:  m.ID,
+		Complete:         true,
+		Slots:            make([]SlotStatus, len(m.Slots)),
+		UnslottedPartIDs: []int64{},
+	}
+	for i, slot := range m.Slots {
+		report.Slots[i] = SlotStatus{
+			SlotID:        slot.ID,
+			Name:          slot.Name,
+			Category:      slot.Category,
+			CatalogPartID: slot.CatalogPartID,
+			Required:      slot.Quantity,
+			PartIDs:       []int64{},
+		}
+	}
+
+	for i := range parts {
+		var candidates []int
+		for j := range m.Slots {
+			if m.Slots[j].CatalogPartID != nil && m.Slots[j].Matches(&parts[i]) {
+				candidates = append(candidates, j)
+			}
+		}
+		for j := range m.Slots {
+			if m.Slots[j].CatalogPartID == nil && m.Slots[j].Matches(&parts[i]) {
+				candidates = append(candidates, j)
+			}
+		}
+		if len(candidates) == 0 {
+			report.UnslottedPartIDs = append(report.UnslottedPartIDs, parts[i].ID)
+			continue
+		}
+
+		target := candidates[0]
+		for _, j := range candidates {
+			if report.Slots[j].Installed < report.Slots[j].Required {
+				target = j
+				break
+			}
+		}
+		report.Slots[target].Installed++
+		report.Slots[target].PartIDs = append(report.Slots[target].PartIDs, parts[i].ID)
+	}
+
+	for i := range report.Slots {
+		status := &report.Slots[i]
+		if status.Installed < status.Required {
+			status.Missing = status.Required - status.Installed
+			report.Complete = false
+		} else {
+			status.Surplus = status.Installed - status.Required
+		}
+	}
+
+	return report
+}
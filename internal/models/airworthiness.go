@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// LifeLimitPercent is the share of a life limit a part may use. A part past
+// it has overrun the limit and grounds its plane.
+const LifeLimitPercent = 100.0
+
+// OverLimit reports whether the part has used more than its life on any
+// configured limit.
+func (pp *PlanePart) OverLimit(now time.Time) bool {
+	percent, _ := pp.LifeUsed(now)
+	return percent > LifeLimitPercent
+}
+
+// AirworthinessReport says whether a plane may fly. A plane is airworthy
+// when it is active and no installed part has overrun a life limit.
+type AirworthinessReport struct {
+	PlaneID          int64                      `json:"plane_id"`
+	TailNumber       string                     `json:"tail_number"`
+	Status           string                     `json:"status"`
+	Airworthy        bool                       `json:"airworthy"`
+	OverLimitParts   []PlanePartResponse        `json:"over_limit_parts"`
+	LastStatusChange *PlaneStatusChangeResponse `json:"last_status_change,omitempty"`
+	CheckedAt        time.Time                  `json:"checked_at"`
+}
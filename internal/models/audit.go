@@ -0,0 +1,184 @@
+package models
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	AuditEntityPlane         = "plane"
+	AuditEntityPlanePart     = "plane_part"
+	AuditEntityFlight        = "flight"
+	AuditEntityWorkOrder     = "work_order"
+	AuditEntityUser          = "user"
+	AuditEntityUserInvite    = "user_invite"
+	AuditEntityExtension     = "part_limit_extension"
+	AuditEntityCatalogPart   = "catalog_part"
+	AuditEntityAircraftModel = "aircraft_model"
+)
+
+const (
+	AuditActionCreate         = "create"
+	AuditActionUpdate         = "update"
+	AuditActionDelete         = "delete"
+	AuditActionRestore        = "restore"
+	AuditActionPurge          = "purge"
+	AuditActionStatus         = "status"
+	AuditActionImport         = "import"
+	AuditActionUsage          = "usage"
+	AuditActionInstall        = "install"
+	AuditActionRemove         = "remove"
+	AuditActionAssign         = "assign"
+	AuditActionStart          = "start"
+	AuditActionSignOff        = "sign_off"
+	AuditActionClose          = "close"
+	AuditActionApprove        = "approve"
+	AuditActionReject         = "reject"
+	AuditActionRevokeSessions = "revoke_sessions"
+)
+
+// AuditGenesisHash is the PrevHash of the first entry in the chain.
+var AuditGenesisHash = strings.Repeat("0", 64)
+
+// AuditEntry records one mutation. Entries form a hash chain: Hash covers
+// every field plus PrevHash, the previous entry's Hash, so editing or
+// deleting any row breaks every hash after it. Before, After and Changes
+// hold JSON text exactly as it was hashed.
+type AuditEntry struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement"`
+	ActorID    *int64    `gorm:"index"`
+	EntityType string    `gorm:"type:varchar(50);not null"`
+	EntityID   int64     `gorm:"not null"`
+	Action     string    `gorm:"type:varchar(50);not null"`
+	Before     *string   `gorm:"type:json"`
+	After      *string   `gorm:"type:json"`
+	Changes    *string   `gorm:"type:json"`
+	RequestID  *string   `gorm:"type:varchar(64)"`
+	CreatedAt  time.Time `gorm:"not null"`
+	PrevHash   string    `gorm:"type:char(64);not null"`
+	Hash       string    `gorm:"type:char(64);not null;uniqueIndex"`
+}
+
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
+
+// ComputeHash returns the entry's hash given its PrevHash. CreatedAt is
+// hashed at microsecond precision, which is what PostgreSQL stores.
+func (e *AuditEntry) ComputeHash() string {
+	fields, _ := json.Marshal([]any{
+		e.PrevHash,
+		e.ActorID,
+		e.EntityType,
+		e.EntityID,
+		e.Action,
+		e.Before,
+		e.After,
+		e.Changes,
+		e.RequestID,
+		e.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(fields)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditFieldChange is one changed top-level field in AuditEntry.Changes.
+type AuditFieldChange struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// AuditDiff compares two JSON objects and returns the top-level fields whose
+// values differ, keyed by field name. Fields only present on one side are
+// reported with null on the other.
+func AuditDiff(before, after []byte) (map[string]AuditFieldChange, error) {
+	var from, to map[string]json.RawMessage
+	if err := json.Unmarshal(before, &from); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &to); err != nil {
+		return nil, err
+	}
+
+	null := json.RawMessage("null")
+	changes := make(map[string]AuditFieldChange)
+	for key, old := range from {
+		next, ok := to[key]
+		if !ok {
+			next = null
+		}
+		if !bytes.Equal(old, next) {
+			changes[key] = AuditFieldChange{From: old, To: next}
+		}
+	}
+	for key, next := range to {
+		if _, ok := from[key]; !ok {
+			changes[key] = AuditFieldChange{From: null, To: next}
+		}
+	}
+	return changes, nil
+}
+
+// AuditQuery filters GET /api/audit. Entries are listed newest first.
+type AuditQuery struct {
+	PaginationQuery
+	EntityType string     `form:"entity_type" binding:"omitempty,oneof=plane plane_part flight work_order user user_invite part_limit_extension catalog_part aircraft_model"`
+	EntityID   *int64     `form:"entity_id" binding:"omitempty,gt=0"`
+	ActorID    *int64     `form:"actor_id" binding:"omitempty,gt=0"`
+	Action     string     `form:"action" binding:"omitempty,max=50"`
+	RequestID  string     `form:"request_id" binding:"omitempty,max=64"`
+	From       *time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To         *time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+}
+
+type AuditEntryResponse struct {
+	ID         int64           `json:"id"`
+	ActorID    *int64          `json:"actor_id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   int64           `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Changes    json.RawMessage `json:"changes"`
+	RequestID  *string         `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	PrevHash   string          `json:"prev_hash"`
+	Hash       string          `json:"hash"`
+}
+
+func (e *AuditEntry) ToResponse() AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:         e.ID,
+		ActorID:    e.ActorID,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Action:     e.Action,
+		Before:     rawJSON(e.Before),
+		After:      rawJSON(e.After),
+		Changes:    rawJSON(e.Changes),
+		RequestID:  e.RequestID,
+		CreatedAt:  e.CreatedAt,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+	}
+}
+
+func rawJSON(s *string) json.RawMessage {
+	if s == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(*s)
+}
+
+// AuditVerifyResponse reports the result of walking the hash chain.
+// FirstInvalidID is the first entry whose hash or link does not match.
+type AuditVerifyResponse struct {
+	Valid          bool   `json:"valid"`
+	Checked        int64  `json:"checked"`
+	FirstInvalidID *int64 `json:"first_invalid_id"`
+	Reason         string `json:"reason,omitempty"`
+}
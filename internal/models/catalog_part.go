@@ -0,0 +1,163 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// CatalogPart is a part number: one kind of component and the defaults every
+// serialized PlanePart of that kind starts from. A part number with no
+// applicable models fits any aircraft.
+type CatalogPart struct {
+	ID                  int64              `json:"id" gorm:"primaryKey;autoIncrement"`
+	PartNumber          string             `json:"part_number" gorm:"type:varchar(100);uniqueIndex;not null"`
+	Manufacturer        string             `json:"manufacturer" gorm:"type:varchar(255);not null"`
+	Description         string             `json:"description" gorm:"type:varchar(255);not null"`
+	Category            string             `json:"category" gorm:"type:varchar(150);not null;index"`
+	DefaultLimitHours   float64            `json:"default_limit_hours" gorm:"type:numeric(10,2);not null"`
+	DefaultLimitCycles  *int               `json:"default_limit_cycles"`
+	DefaultCalendarDays *int               `json:"default_calendar_days"`
+	CreatedAt           time.Time          `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time          `json:"updated_at" gorm:"autoUpdateTime"`
+	ApplicableModels    []CatalogPartModel `json:"applicable_models,omitempty" gorm:"foreignKey:CatalogPartID"`
+}
+
+// CatalogPartModel is one aircraft model a part number is approved for.
+type CatalogPartModel struct {
+	CatalogPartID int64  `json:"catalog_part_id" gorm:"primaryKey"`
+	Model         string `json:"model" gorm:"type:varchar(100);primaryKey"`
+}
+
+type CreateCatalogPartRequest struct {
+	PartNumber          string   `json:"part_number" binding:"required,min=2,max=100"`
+	Manufacturer        string   `json:"manufacturer" binding:"required,min=2,max=255"`
+	Description         string   `json:"description" binding:"required,min=2,max=255"`
+	Category            string   `json:"category" binding:"required,min=2,max=150"`
+	DefaultLimitHours   float64  `json:"default_limit_hours" binding:"required,gt=0"`
+	DefaultLimitCycles  *int     `json:"default_limit_cycles" binding:"omitempty,gt=0"`
+	DefaultCalendarDays *int     `json:"default_calendar_days" binding:"omitempty,gt=0"`
+	ApplicableModels    []string `json:"applicable_models" binding:"omitempty,dive,min=2,max=100"`
+}
+
+// UpdateCatalogPartRequest changes a catalog entry. A nil ApplicableModels
+// leaves the list alone; an empty one opens the part number to every model.
+type UpdateCatalogPartRequest struct {
+	PartNumber          *string  `json:"part_number" binding:"omitempty,min=2,max=100"`
+	Manufacturer        *string  `json:"manufacturer" binding:"omitempty,min=2,max=255"`
+	Description         *string  `json:"description" binding:"omitempty,min=2,max=255"`
+	Category            *string  `json:"category" binding:"omitempty,min=2,max=150"`
+	DefaultLimitHours   *float64 `json:"default_limit_hours" binding:"omitempty,gt=0"`
+	DefaultLimitCycles  *int     `json:"default_limit_cycles" binding:"omitempty,gt=0"`
+	DefaultCalendarDays *int     `json:"default_calendar_days" binding:"omitempty,gt=0"`
+	ApplicableModels    []string `json:"applicable_models" binding:"omitempty,dive,min=2,max=100"`
+}
+
+// CatalogQuery filters and sorts GET /api/catalog. Manufacturer and part
+// number match case-insensitive substrings; Model keeps the part numbers
+// that fit that aircraft model.
+type CatalogQuery struct {
+	PaginationQuery
+	PartNumber   string `form:"part_number"`
+	Manufacturer string `form:"manufacturer"`
+	Category     string `form:"category"`
+	Model        string `form:"model"`
+	Sort         string `form:"sort" binding:"omitempty,oneof=id part_number manufacturer category created_at"`
+	Order        string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type CatalogPartResponse struct {
+	ID                  int64     `json:"id"`
+	PartNumber          string    `json:"part_number"`
+	Manufacturer        string    `json:"manufacturer"`
+	Description         string    `json:"description"`
+	Category            string    `json:"category"`
+	DefaultLimitHours   float64   `json:"default_limit_hours"`
+	DefaultLimitCycles  *int      `json:"default_limit_cycles"`
+	DefaultCalendarDays *int      `json:"default_calendar_days"`
+	ApplicableModels    []string  `json:"applicable_models"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// AppliesTo reports whether the part number may be fitted to an aircraft of
+// the given model. Model names compare case-insensitively.
+func (c *CatalogPart) AppliesTo(model string) bool {
+	if len(c.ApplicableModels) == 0 {
+		return true
+	}
+	for _, m := range c.ApplicableModels {
+		if strings.EqualFold(m.Model, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// Inherit makes part a serialized instance of this part number. Name and
+// category always come from the catalog; limits the part leaves unset take
+// the catalog defaults.
+func (c *CatalogPart) Inherit(part *PlanePart) {
+	part.CatalogPartID = &c.ID
+	part.PartName = c.Description
+	part.Category = c.Category
+	if part.UsageLimitHours == 0 {
+		part.UsageLimitHours = c.DefaultLimitHours
+	}
+	if part.UsageLimitCycles == nil {
+		part.UsageLimitCycles = c.DefaultLimitCycles
+	}
+	if part.CalendarLimitDays == nil {
+		part.CalendarLimitDays = c.DefaultCalendarDays
+	}
+}
+
+// WithinDefaults reports whether the part's limits are no looser than the
+// catalog defaults. A serial may be held to a tighter limit, never a looser
+// one; extra life needs an approved extension.
+func (c *CatalogPart) WithinDefaults(part *PlanePart) bool {
+	if part.UsageLimitHours > c.DefaultLimitHours {
+		return false
+	}
+	if c.DefaultLimitCycles != nil && (part.UsageLimitCycles == nil || *part.UsageLimitCycles > *c.DefaultLimitCycles) {
+		return false
+	}
+	if c.DefaultCalendarDays != nil && (part.CalendarLimitDays == nil || *part.CalendarLimitDays > *c.DefaultCalendarDays) {
+		return false
+	}
+	return true
+}
+
+// SetApplicableModels replaces the model list, dropping blanks and repeats.
+func (c *CatalogPart) SetApplicableModels(names []string) {
+	c.ApplicableModels = make([]CatalogPartModel, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		c.ApplicableModels = append(c.ApplicableModels, CatalogPartModel{CatalogPartID: c.ID, Model: name})
+	}
+}
+
+func (c *CatalogPart) ToResponse() CatalogPartResponse {
+	resp := CatalogPartResponse{
+		ID:                  c.ID,
+		PartNumber:          c.PartNumber,
+		Manufacturer:        c.Manufacturer,
+		Description:         c.Description,
+		Category:            c.Category,
+		DefaultLimitHours:   c.DefaultLimitHours,
+		DefaultLimitCycles:  c.DefaultLimitCycles,
+		DefaultCalendarDays: c.DefaultCalendarDays,
+		ApplicableModels:    make([]string, len(c.ApplicableModels)),
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
+	}
+	for i, m := range c.ApplicableModels {
+		resp.ApplicableModels[i] = m.Model
+	}
+	return resp
+}
@@ -0,0 +1,20 @@
+package models
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// ExportQuery selects the export format. Exports otherwise take the same
+// filters and sort as the matching list endpoint; pagination is ignored.
+type ExportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+}
+
+// FormatOrDefault returns the requested format, defaulting to CSV.
+func (q ExportQuery) FormatOrDefault() string {
+	if q.Format == "" {
+		return ExportFormatCSV
+	}
+	return q.Format
+}
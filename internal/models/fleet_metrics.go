@@ -0,0 +1,11 @@
+package models
+
+// PlanePartUsage counts the parts installed on a live plane by how much of
+// their life they have used: at or above 80% (the default maintenance alert
+// threshold), at or above 90%, and over the limit.
+type PlanePartUsage struct {
+	PlaneID   int64
+	Above80   int64
+	Above90   int64
+	OverLimit int64
+}
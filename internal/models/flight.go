@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+type Flight struct {
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	PlaneID     int64     `json:"plane_id" gorm:"not null;index"`
+	DepartureAt time.Time `json:"departure_at" gorm:"not null"`
+	ArrivalAt   time.Time `json:"arrival_at" gorm:"not null"`
+	BlockHours  float64   `json:"block_hours" gorm:"type:numeric(10,2);not null"`
+	Cycles      int       `json:"cycles" gorm:"not null;default:1"`
+	RecordedBy  *int64    `json:"recorded_by"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Plane       *Plane    `json:"plane,omitempty" gorm:"foreignKey:PlaneID"`
+}
+
+type CreateFlightRequest struct {
+	DepartureAt time.Time `json:"departure_at" binding:"required"`
+	ArrivalAt   time.Time `json:"arrival_at" binding:"required,gtfield=DepartureAt"`
+	BlockHours  *float64  `json:"block_hours" binding:"omitempty,gt=0"`
+	Cycles      int       `json:"cycles" binding:"omitempty,gte=1"`
+}
+
+type FlightResponse struct {
+	ID           int64     `json:"id"`
+	PlaneID      int64     `json:"plane_id"`
+	DepartureAt  time.Time `json:"departure_at"`
+	ArrivalAt    time.Time `json:"arrival_at"`
+	BlockHours   float64   `json:"block_hours"`
+	Cycles       int       `json:"cycles"`
+	RecordedBy   *int64    `json:"recorded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	PartsUpdated *int      `json:"parts_updated,omitempty"`
+}
+
+func (f *Flight) ToResponse() FlightResponse {
+	return FlightResponse{
+		ID:          f.ID,
+		PlaneID:     f.PlaneID,
+		DepartureAt: f.DepartureAt,
+		ArrivalAt:   f.ArrivalAt,
+		BlockHours:  f.BlockHours,
+		Cycles:      f.Cycles,
+		RecordedBy:  f.RecordedBy,
+		CreatedAt:   f.CreatedAt,
+	}
+}
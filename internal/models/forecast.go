@@ -0,0 +1,147 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	DefaultForecastLookbackDays = 90
+	DefaultForecastHorizonDays  = 30
+
+	// maxForecastDays caps projections; anything further out is reported
+	// as having no due date.
+	maxForecastDays = 36500
+)
+
+// PlaneUtilization is the flying a plane did since a point in time.
+// WindowStart is the later of that point and the plane's creation, so new
+// aircraft are not averaged over days they did not exist.
+type PlaneUtilization struct {
+	PlaneID     int64     `json:"plane_id"`
+	TailNumber  string    `json:"tail_number"`
+	WindowStart time.Time `json:"window_start"`
+	Hours       float64   `json:"hours"`
+	Cycles      int       `json:"cycles"`
+}
+
+// DailyRates returns average hours and cycles flown per day up to now.
+func (u *PlaneUtilization) DailyRates(now time.Time) (float64, float64) {
+	days := now.Sub(u.WindowStart).Hours() / 24
+	if days < 1 {
+		days = 1
+	}
+	return u.Hours / days, float64(u.Cycles) / days
+}
+
+type ForecastQuery struct {
+	LookbackDays int `form:"lookback_days" binding:"omitempty,min=1,max=365"`
+}
+
+type FleetForecastQuery struct {
+	ForecastQuery
+	Days int `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+// PartForecast projects when a part reaches its first life limit at the
+// plane's current utilization. DueAt is nil when no limit will be reached:
+// the part has only usage limits and the plane has not flown recently.
+type PartForecast struct {
+	PartID          int64      `json:"part_id"`
+	PlaneID         int64      `json:"plane_id"`
+	TailNumber      string     `json:"tail_number,omitempty"`
+	SerialNumber    string     `json:"serial_number"`
+	PartName        string     `json:"part_name"`
+	Category        string     `json:"category"`
+	UsagePercent    float64    `json:"usage_percent"`
+	RemainingHours  float64    `json:"remaining_hours"`
+	RemainingCycles *int       `json:"remaining_cycles"`
+	DueAt           *time.Time `json:"due_at"`
+	DaysRemaining   *float64   `json:"days_remaining"`
+	LimitDriver     string     `json:"limit_driver,omitempty"`
+	Overdue         bool       `json:"overdue"`
+}
+
+// Forecast projects the part's due date from daily hours and cycles flown.
+// A limit that is already used up is due now.
+func (pp *PlanePart) Forecast(now time.Time, dailyHours, dailyCycles float64) PartForecast {
+	f := PartForecast{
+		PartID:         pp.ID,
+		SerialNumber:   pp.SerialNumber,
+		PartName:       pp.PartName,
+		Category:       pp.Category,
+		RemainingHours: pp.EffectiveLimitHours() - pp.UsageHours,
+	}
+	if pp.PlaneID != nil {
+		f.PlaneID = *pp.PlaneID
+	}
+	f.UsagePercent, _ = pp.LifeUsed(now)
+
+	consider := func(due time.Time, driver string) {
+		if f.DueAt == nil || due.Before(*f.DueAt) {
+			f.DueAt, f.LimitDriver = &due, driver
+		}
+	}
+	project := func(remaining, rate float64, driver string) {
+		switch {
+		case remaining <= 0:
+			consider(now, driver)
+		case rate > 0 && remaining/rate <= maxForecastDays:
+			consider(now.Add(time.Duration(remaining/rate*24*float64(time.Hour))), driver)
+		}
+	}
+
+	if pp.EffectiveLimitHours() > 0 {
+		project(f.RemainingHours, dailyHours, LimitDriverHours)
+	}
+	if pp.UsageLimitCycles != nil && *pp.UsageLimitCycles > 0 {
+		remaining := *pp.UsageLimitCycles - pp.UsageCycles
+		f.RemainingCycles = &remaining
+		project(float64(remaining), dailyCycles, LimitDriverCycles)
+	}
+	if due := pp.CalendarDueAt(); due != nil {
+		consider(*due, LimitDriverCalendar)
+	}
+
+	if f.DueAt != nil {
+		days := f.DueAt.Sub(now).Hours() / 24
+		f.DaysRemaining = &days
+		f.Overdue = !f.DueAt.After(now)
+	}
+	return f
+}
+
+// SortForecasts orders forecasts by due date, soonest first, with parts that
+// have no projected date last.
+func SortForecasts(forecasts []PartForecast) {
+	sort.SliceStable(forecasts, func(i, j int) bool {
+		a, b := forecasts[i].DueAt, forecasts[j].DueAt
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+}
+
+type PlaneForecastResponse struct {
+	PlaneID        int64          `json:"plane_id"`
+	TailNumber     string         `json:"tail_number"`
+	LookbackDays   int            `json:"lookback_days"`
+	AvgDailyHours  float64        `json:"avg_daily_hours"`
+	AvgDailyCycles float64        `json:"avg_daily_cycles"`
+	Parts          []PartForecast `json:"parts"`
+}
+
+// ForecastDay groups the items falling due on one UTC calendar day.
+type ForecastDay struct {
+	Date  string         `json:"date"`
+	Items []PartForecast `json:"items"`
+}
+
+type FleetForecastResponse struct {
+	From         time.Time      `json:"from"`
+	To           time.Time      `json:"to"`
+	LookbackDays int            `json:"lookback_days"`
+	Overdue      []PartForecast `json:"overdue"`
+	Days         []ForecastDay  `json:"days"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// HealthCheck is the result of checking one dependency.
+type HealthCheck struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+// MigrationCheck compares the schema version applied to the database with
+// the newest migration the binary was built with. A database ahead of the
+// binary, as during a rolling deploy, still passes.
+type MigrationCheck struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Current  int64  `json:"current"`
+	Expected int64  `json:"expected"`
+}
+
+// ReadinessReport says whether the instance can serve traffic: the database
+// answers and its schema is up to date.
+type ReadinessReport struct {
+	Ready      bool           `json:"ready"`
+	Database   HealthCheck    `json:"database"`
+	Migrations MigrationCheck `json:"migrations"`
+	CheckedAt  time.Time      `json:"checked_at"`
+}
@@ -0,0 +1,56 @@
+package models
+
+const (
+	ImportSectionPlanes = "planes"
+	ImportSectionParts  = "parts"
+
+	// MaxImportRows bounds each section of a single import.
+	MaxImportRows = 5000
+)
+
+type ImportPlaneRow struct {
+	TailNumber string `json:"tail_number"`
+	Model      string `json:"model"`
+}
+
+// ImportPartRow describes one serialized part. An empty TailNumber imports
+// the part as a spare; otherwise it must name a plane that already exists
+// or is created by the same import. A PartNumber links the part to that
+// catalog entry, which then supplies the name, category and default limits.
+type ImportPartRow struct {
+	TailNumber        string  `json:"tail_number"`
+	PartNumber        string  `json:"part_number"`
+	PartName          string  `json:"part_name"`
+	SerialNumber      string  `json:"serial_number"`
+	Category          string  `json:"category"`
+	UsageHours        float64 `json:"usage_hours"`
+	UsageLimitHours   float64 `json:"usage_limit_hours"`
+	UsageCycles       int     `json:"usage_cycles"`
+	UsageLimitCycles  *int    `json:"usage_limit_cycles"`
+	CalendarLimitDays *int    `json:"calendar_limit_days"`
+}
+
+type ImportRequest struct {
+	Planes []ImportPlaneRow `json:"planes"`
+	Parts  []ImportPartRow  `json:"parts"`
+}
+
+// ImportRowError points at a rejected row. Row is 1-based within its
+// section (for CSV, the data row after the header); row 0 means the whole
+// section, such as a missing CSV column.
+type ImportRowError struct {
+	Section string `json:"section"`
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportResponse reports what an import did, or would do on a dry run.
+// Nothing is written unless Valid is true and DryRun is false.
+type ImportResponse struct {
+	DryRun bool             `json:"dry_run"`
+	Valid  bool             `json:"valid"`
+	Planes int              `json:"planes"`
+	Parts  int              `json:"parts"`
+	Errors []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,37 @@
+package models
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+type PaginationQuery struct {
+	Page     int `form:"page" binding:"omitempty,gte=1"`
+	PageSize int `form:"page_size" binding:"omitempty,gte=1,lte=100"`
+}
+
+func (q *PaginationQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize < 1 {
+		q.PageSize = DefaultPageSize
+	}
+	if q.PageSize > MaxPageSize {
+		q.PageSize = MaxPageSize
+	}
+}
+
+func (q *PaginationQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+type PaginatedResponse[T any] struct {
+	Data     []T   `json:"data"`
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+}
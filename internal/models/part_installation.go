@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+)
+
+// PartInstallation records one period during which a serialized part was
+// fitted to a plane. RemovedAt is nil while the part is still installed.
+type PartInstallation struct {
+	ID              int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	PartID          int64      `json:"part_id" gorm:"not null;index"`
+	PlaneID         int64      `json:"plane_id" gorm:"not null;index"`
+	InstalledAt     time.Time  `json:"installed_at" gorm:"not null"`
+	RemovedAt       *time.Time `json:"removed_at"`
+	InstalledBy     *int64     `json:"installed_by"`
+	RemovedBy       *int64     `json:"removed_by"`
+	RemovalReason   *string    `json:"removal_reason" gorm:"type:varchar(255)"`
+	HoursAtInstall  float64    `json:"hours_at_install" gorm:"type:numeric(10,2);not null;default:0"`
+	CyclesAtInstall int        `json:"cycles_at_install" gorm:"not null;default:0"`
+	HoursAtRemoval  *float64   `json:"hours_at_removal" gorm:"type:numeric(10,2)"`
+	CyclesAtRemoval *int       `json:"cycles_at_removal"`
+	Plane           *Plane     `json:"plane,omitempty" gorm:"foreignKey:PlaneID"`
+}
+
+type InstallPartRequest struct {
+	PlaneID int64 `json:"plane_id" binding:"required"`
+}
+
+type RemovePartRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=255"`
+}
+
+type PartInstallationResponse struct {
+	ID            int64      `json:"id"`
+	PlaneID       int64      `json:"plane_id"`
+	TailNumber    string     `json:"tail_number,omitempty"`
+	InstalledAt   time.Time  `json:"installed_at"`
+	RemovedAt     *time.Time `json:"removed_at"`
+	InstalledBy   *int64     `json:"installed_by"`
+	RemovedBy     *int64     `json:"removed_by"`
+	RemovalReason *string    `json:"removal_reason"`
+	DurationDays  float64    `json:"duration_days"`
+	HoursAccrued  float64    `json:"hours_accrued"`
+	CyclesAccrued int        `json:"cycles_accrued"`
+}
+
+// ToResponse reports the installation period; for an open installation the
+// part's current totals and now are used as the end of the period.
+func (pi *PartInstallation) ToResponse(part *PlanePart, now time.Time) PartInstallationResponse {
+	resp := PartInstallationResponse{
+		ID:            pi.ID,
+		PlaneID:       pi.PlaneID,
+		InstalledAt:   pi.InstalledAt,
+		RemovedAt:     pi.RemovedAt,
+		InstalledBy:   pi.InstalledBy,
+		RemovedBy:     pi.RemovedBy,
+		RemovalReason: pi.RemovalReason,
+	}
+	if pi.Plane != nil {
+		resp.TailNumber = pi.Plane.TailNumber
+	}
+
+	end, endHours, endCycles := now, part.UsageHours, part.UsageCycles
+	if pi.RemovedAt != nil {
+		end = *pi.RemovedAt
+	}
+	if pi.HoursAtRemoval != nil {
+		endHours = *pi.HoursAtRemoval
+	}
+	if pi.CyclesAtRemoval != nil {
+		endCycles = *pi.CyclesAtRemoval
+	}
+
+	resp.DurationDays = end.Sub(pi.InstalledAt).Hours() / 24
+	resp.HoursAccrued = endHours - pi.HoursAtInstall
+	resp.CyclesAccrued = endCycles - pi.CyclesAtInstall
+	return resp
+}
+
+// PartHistoryResponse is the back-to-birth record of a serialized part.
+type PartHistoryResponse struct {
+	Part          PlanePartResponse          `json:"part"`
+	Installations []PartInstallationResponse `json:"installations"`
+}
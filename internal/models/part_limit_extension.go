@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ExtensionStatusPending  = "pending"
+	ExtensionStatusApproved = "approved"
+	ExtensionStatusRejected = "rejected"
+)
+
+// PartLimitExtension asks for extra hours on a part's life limit, usually
+// after an inspection. Approved extensions add to the part's ExtensionHours;
+// the original UsageLimitHours is never changed.
+type PartLimitExtension struct {
+	ID             int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	PartID         int64      `json:"part_id" gorm:"not null;index"`
+	RequestedHours float64    `json:"requested_hours" gorm:"type:numeric(10,2);not null"`
+	Justification  string     `json:"justification" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	RequestedBy    *int64     `json:"requested_by"`
+	ReviewedBy     *int64     `json:"reviewed_by"`
+	ReviewNote     *string    `json:"review_note" gorm:"type:varchar(500)"`
+	ReviewedAt     *time.Time `json:"reviewed_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type CreateLimitExtensionRequest struct {
+	RequestedHours float64 `json:"requested_hours" binding:"required,gt=0"`
+	Justification  string  `json:"justification" binding:"required,min=10,max=2000"`
+}
+
+type ReviewLimitExtensionRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+type LimitExtensionQuery struct {
+	PaginationQuery
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	PartID *int64 `form:"part_id" binding:"omitempty,gt=0"`
+}
+
+type PartLimitExtensionResponse struct {
+	ID             int64      `json:"id"`
+	PartID         int64      `json:"part_id"`
+	RequestedHours float64    `json:"requested_hours"`
+	Justification  string     `json:"justification"`
+	Status         string     `json:"status"`
+	RequestedBy    *int64     `json:"requested_by"`
+	ReviewedBy     *int64     `json:"reviewed_by"`
+	ReviewNote     *string    `json:"review_note,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (e *PartLimitExtension) ToResponse() PartLimitExtensionResponse {
+	return PartLimitExtensionResponse{
+		ID:             e.ID,
+		PartID:         e.PartID,
+		RequestedHours: e.RequestedHours,
+		Justification:  e.Justification,
+		Status:         e.Status,
+		RequestedBy:    e.RequestedBy,
+		ReviewedBy:     e.ReviewedBy,
+		ReviewNote:     e.ReviewNote,
+		ReviewedAt:     e.ReviewedAt,
+		CreatedAt:      e.CreatedAt,
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+)
+
+// Users may log manual and correction entries. The other sources are
+// written only by the system, so an entry's source can be trusted.
+const (
+	UsageSourceInitial    = "initial"
+	UsageSourceManual     = "manual"
+	UsageSourceCorrection = "correction"
+	UsageSourceFlight     = "flight"
+	UsageSourceWorkOrder  = "work_order"
+)
+
+// PartUsageEntry is a single append-only reading in a part's usage ledger.
+// The part's usage_hours and usage_cycles columns are always the sums of its
+// entries.
+type PartUsageEntry struct {
+	ID          int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	PartID      int64     `json:"part_id" gorm:"not null;index"`
+	DeltaHours  float64   `json:"delta_hours" gorm:"type:numeric(10,2);not null"`
+	DeltaCycles int       `json:"delta_cycles" gorm:"not null;default:0"`
+	RecordedBy  *int64    `json:"recorded_by"`
+	Source      string    `json:"source" gorm:"type:varchar(50);not null"`
+	FlightID    *int64    `json:"flight_id" gorm:"index"`
+	RecordedAt  time.Time `json:"recorded_at" gorm:"autoCreateTime"`
+}
+
+type LogPartUsageRequest struct {
+	DeltaHours  float64 `json:"delta_hours"`
+	DeltaCycles int     `json:"delta_cycles"`
+	Source      string  `json:"source" binding:"omitempty,oneof=manual correction"`
+}
+
+type PartUsageEntryResponse struct {
+	ID          int64     `json:"id"`
+	PartID      int64     `json:"part_id"`
+	DeltaHours  float64   `json:"delta_hours"`
+	DeltaCycles int       `json:"delta_cycles"`
+	RecordedBy  *int64    `json:"recorded_by"`
+	Source      string    `json:"source"`
+	FlightID    *int64    `json:"flight_id,omitempty"`
+	RecordedAt  time.Time `json:"recorded_at"`
+}
+
+func (e *PartUsageEntry) ToResponse() PartUsageEntryResponse {
+	return PartUsageEntryResponse{
+		ID:          e.ID,
+		PartID:      e.PartID,
+		DeltaHours:  e.DeltaHours,
+		DeltaCycles: e.DeltaCycles,
+		RecordedBy:  e.RecordedBy,
+		Source:      e.Source,
+		FlightID:    e.FlightID,
+		RecordedAt:  e.RecordedAt,
+	}
+}
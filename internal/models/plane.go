@@ -2,13 +2,17 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Plane struct {
-	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
-	TailNumber string    `json:"tail_number" gorm:"type:varchar(50);uniqueIndex;not null"`
-	Model      string    `json:"model" gorm:"type:varchar(100);not null"`
-	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID         int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	TailNumber string         `json:"tail_number" gorm:"type:varchar(50);uniqueIndex;not null"`
+	Model      string         `json:"model" gorm:"type:varchar(100);not null"`
+	Status     string         `json:"status" gorm:"type:varchar(20);not null;default:active"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 type CreatePlaneRequest struct {
@@ -21,11 +25,33 @@ type UpdatePlaneRequest struct {
 	Model      *string `json:"model" binding:"omitempty,min=2,max=100"`
 }
 
+// PlaneQuery filters and sorts GET /api/planes. Model and tail number match
+// case-insensitive substrings.
+type PlaneQuery struct {
+	PaginationQuery
+	DeletedFilter
+	Model      string `form:"model"`
+	TailNumber string `form:"tail_number"`
+	Status     string `form:"status" binding:"omitempty,oneof=active in_maintenance grounded retired"`
+	Sort       string `form:"sort" binding:"omitempty,oneof=id tail_number model status created_at"`
+	Order      string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
 type PlaneResponse struct {
-	ID         int64     `json:"id"`
-	TailNumber string    `json:"tail_number"`
-	Model      string    `json:"model"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID         int64      `json:"id"`
+	TailNumber string     `json:"tail_number"`
+	Model      string     `json:"model"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+}
+
+// PlaneWithPartsResponse is a plane with its installed parts. Slots is set
+// when the plane's model is registered.
+type PlaneWithPartsResponse struct {
+	Plane PlaneResponse       `json:"plane"`
+	Parts []PlanePartResponse `json:"parts"`
+	Slots *SlotReport         `json:"slots,omitempty"`
 }
 
 func (p *Plane) ToResponse() PlaneResponse {
@@ -33,6 +59,8 @@ func (p *Plane) ToResponse() PlaneResponse {
 		ID:         p.ID,
 		TailNumber: p.TailNumber,
 		Model:      p.Model,
+		Status:     p.Status,
 		CreatedAt:  p.CreatedAt,
+		DeletedAt:  deletedAt(p.DeletedAt),
 	}
 }
@@ -2,84 +2,198 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	LimitDriverHours    = "hours"
+	LimitDriverCycles   = "cycles"
+	LimitDriverCalendar = "calendar"
 )
 
+// PlanePart is a serialized component. PlaneID is nil while the part is
+// uninstalled and held as a spare. CatalogPartID names its part number; parts
+// created before the catalog have none. ExtensionHours is the sum of approved
+// limit extensions and is only changed by approving one. InstalledAt is when
+// the part was fitted to its current plane; InServiceAt is when it first
+// entered service, never changes, and is what calendar limits run from.
 type PlanePart struct {
-	ID              int64     `json:"id" gorm:"primaryKey;autoIncrement"`
-	PlaneID         int64     `json:"plane_id" gorm:"not null;index"`
-	PartName        string    `json:"part_name" gorm:"type:varchar(255);not null"`
-	SerialNumber    string    `json:"serial_number" gorm:"type:varchar(100);uniqueIndex;not null"`
-	Category        string    `json:"category" gorm:"type:varchar(150);not null;index"`
-	UsageHours      float64   `json:"usage_hours" gorm:"type:numeric(10,2);default:0"`
-	UsageLimitHours float64   `json:"usage_limit_hours" gorm:"type:numeric(10,2);not null"`
-	UsagePercent    *float64  `json:"usage_percent" gorm:"-"`
-	InstalledAt     time.Time `json:"installed_at" gorm:"autoCreateTime"`
-	Plane           *Plane    `json:"plane,omitempty" gorm:"foreignKey:PlaneID"`
+	ID                int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	PlaneID           *int64         `json:"plane_id" gorm:"index"`
+	CatalogPartID     *int64         `json:"catalog_part_id" gorm:"index"`
+	PartName          string         `json:"part_name" gorm:"type:varchar(255);not null"`
+	SerialNumber      string         `json:"serial_number" gorm:"type:varchar(100);uniqueIndex;not null"`
+	Category          string         `json:"category" gorm:"type:varchar(150);not null;index"`
+	UsageHours        float64        `json:"usage_hours" gorm:"type:numeric(10,2);default:0"`
+	UsageLimitHours   float64        `json:"usage_limit_hours" gorm:"type:numeric(10,2);not null"`
+	ExtensionHours    float64        `json:"extension_hours" gorm:"type:numeric(10,2);not null;default:0"`
+	UsageCycles       int            `json:"usage_cycles" gorm:"not null;default:0"`
+	UsageLimitCycles  *int           `json:"usage_limit_cycles"`
+	CalendarLimitDays *int           `json:"calendar_limit_days"`
+	InstalledAt       time.Time      `json:"installed_at" gorm:"autoCreateTime"`
+	InServiceAt       time.Time      `json:"in_service_at" gorm:"autoCreateTime"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	Plane             *Plane         `json:"plane,omitempty" gorm:"foreignKey:PlaneID"`
 }
 
+// CreatePlanePartRequest adds a serialized part. With CatalogPartID the name
+// and category come from the catalog and the limits default to its own;
+// without it they must all be given.
 type CreatePlanePartRequest struct {
-	PlaneID         int64   `json:"plane_id" binding:"required"`
-	PartName        string  `json:"part_name" binding:"required,min=2,max=255"`
-	SerialNumber    string  `json:"serial_number" binding:"required,min=2,max=100"`
-	Category        string  `json:"category" binding:"required,min=2,max=150"`
-	UsageHours      float64 `json:"usage_hours"`
-	UsageLimitHours float64 `json:"usage_limit_hours" binding:"required,gt=0"`
+	PlaneID           int64   `json:"plane_id" binding:"required"`
+	CatalogPartID     *int64  `json:"catalog_part_id" binding:"omitempty,gt=0"`
+	PartName          string  `json:"part_name" binding:"required_without=CatalogPartID,excluded_with=CatalogPartID,omitempty,min=2,max=255"`
+	SerialNumber      string  `json:"serial_number" binding:"required,min=2,max=100"`
+	Category          string  `json:"category" binding:"required_without=CatalogPartID,excluded_with=CatalogPartID,omitempty,min=2,max=150"`
+	UsageHours        float64 `json:"usage_hours"`
+	UsageLimitHours   float64 `json:"usage_limit_hours" binding:"required_without=CatalogPartID,omitempty,gt=0"`
+	UsageCycles       int     `json:"usage_cycles" binding:"omitempty,gte=0"`
+	UsageLimitCycles  *int    `json:"usage_limit_cycles" binding:"omitempty,gt=0"`
+	CalendarLimitDays *int    `json:"calendar_limit_days" binding:"omitempty,gt=0"`
 }
 
+// UpdatePlanePartRequest changes a part. CatalogPartID links a part that has
+// no catalog entry to one; a linked part can't be moved to another entry.
 type UpdatePlanePartRequest struct {
-	PartName        *string  `json:"part_name" binding:"omitempty,min=2,max=255"`
-	SerialNumber    *string  `json:"serial_number" binding:"omitempty,min=2,max=100"`
-	Category        *string  `json:"category" binding:"omitempty,min=2,max=150"`
-	UsageLimitHours *float64 `json:"usage_limit_hours" binding:"omitempty,gt=0"`
+	CatalogPartID     *int64   `json:"catalog_part_id" binding:"omitempty,gt=0"`
+	PartName          *string  `json:"part_name" binding:"omitempty,min=2,max=255"`
+	SerialNumber      *string  `json:"serial_number" binding:"omitempty,min=2,max=100"`
+	Category          *string  `json:"category" binding:"omitempty,min=2,max=150"`
+	UsageLimitHours   *float64 `json:"usage_limit_hours" binding:"omitempty,gt=0"`
+	UsageLimitCycles  *int     `json:"usage_limit_cycles" binding:"omitempty,gt=0"`
+	CalendarLimitDays *int     `json:"calendar_limit_days" binding:"omitempty,gt=0"`
 }
 
 type UpdatePartUsageRequest struct {
-	UsageHours float64 `json:"usage_hours" binding:"required,gte=0"`
+	UsageHours  float64 `json:"usage_hours" binding:"required,gte=0"`
+	UsageCycles *int    `json:"usage_cycles" binding:"omitempty,gte=0"`
 }
 
 type PlanePartResponse struct {
-	ID              int64     `json:"id"`
-	PlaneID         int64     `json:"plane_id"`
-	PartName        string    `json:"part_name"`
-	SerialNumber    string    `json:"serial_number"`
-	Category        string    `json:"category"`
-	UsageHours      float64   `json:"usage_hours"`
-	UsageLimitHours float64   `json:"usage_limit_hours"`
-	UsagePercent    float64   `json:"usage_percent"`
-	InstalledAt     time.Time `json:"installed_at"`
+	ID                  int64      `json:"id"`
+	PlaneID             *int64     `json:"plane_id"`
+	CatalogPartID       *int64     `json:"catalog_part_id"`
+	PartName            string     `json:"part_name"`
+	SerialNumber        string     `json:"serial_number"`
+	Category            string     `json:"category"`
+	UsageHours          float64    `json:"usage_hours"`
+	UsageLimitHours     float64    `json:"usage_limit_hours"`
+	ExtensionHours      float64    `json:"extension_hours"`
+	EffectiveLimitHours float64    `json:"effective_limit_hours"`
+	UsageCycles         int        `json:"usage_cycles"`
+	UsageLimitCycles    *int       `json:"usage_limit_cycles"`
+	CalendarLimitDays   *int       `json:"calendar_limit_days"`
+	CalendarDueAt       *time.Time `json:"calendar_due_at,omitempty"`
+	UsagePercent        float64    `json:"usage_percent"`
+	LimitDriver         string     `json:"limit_driver"`
+	InstalledAt         time.Time  `json:"installed_at"`
+	InServiceAt         time.Time  `json:"in_service_at"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
+}
+
+// EffectiveLimitHours is the hour limit the part is held to: the original
+// limit plus every approved extension.
+func (pp *PlanePart) EffectiveLimitHours() float64 {
+	return pp.UsageLimitHours + pp.ExtensionHours
+}
+
+// CalendarDueAt returns when the part's calendar limit expires, or nil when
+// the part has no calendar limit. The limit runs from first entry into
+// service, so removing and reinstalling the part does not reset it.
+func (pp *PlanePart) CalendarDueAt() *time.Time {
+	if pp.CalendarLimitDays == nil {
+		return nil
+	}
+	due := pp.InServiceAt.AddDate(0, 0, *pp.CalendarLimitDays)
+	return &due
+}
+
+// LifeUsed returns the percentage of life consumed against whichever
+// configured limit is closest to expiry, and the name of that limit.
+func (pp *PlanePart) LifeUsed(now time.Time) (float64, string) {
+	percent, driver := 0.0, LimitDriverHours
+	if limit := pp.EffectiveLimitHours(); limit > 0 {
+		percent = (pp.UsageHours / limit) * 100
+	}
+
+	if pp.UsageLimitCycles != nil && *pp.UsageLimitCycles > 0 {
+		cyclesPercent := float64(pp.UsageCycles) / float64(*pp.UsageLimitCycles) * 100
+		if cyclesPercent > percent {
+			percent, driver = cyclesPercent, LimitDriverCycles
+		}
+	}
+
+	if pp.CalendarLimitDays != nil && *pp.CalendarLimitDays > 0 {
+		elapsedDays := now.Sub(pp.InServiceAt).Hours() / 24
+		calendarPercent := elapsedDays / float64(*pp.CalendarLimitDays) * 100
+		if calendarPercent > percent {
+			percent, driver = calendarPercent, LimitDriverCalendar
+		}
+	}
+
+	return percent, driver
 }
 
 func (pp *PlanePart) ToResponse() PlanePartResponse {
 	resp := PlanePartResponse{
-		ID:              pp.ID,
-		PlaneID:         pp.PlaneID,
-		PartName:        pp.PartName,
-		SerialNumber:    pp.SerialNumber,
-		Category:        pp.Category,
-		UsageHours:      pp.UsageHours,
-		UsageLimitHours: pp.UsageLimitHours,
-		InstalledAt:     pp.InstalledAt,
-	}
-	if pp.UsagePercent != nil {
-		resp.UsagePercent = *pp.UsagePercent
-	} else if pp.UsageLimitHours > 0 {
-		resp.UsagePercent = (pp.UsageHours / pp.UsageLimitHours) * 100
+		ID:                  pp.ID,
+		PlaneID:             pp.PlaneID,
+		CatalogPartID:       pp.CatalogPartID,
+		PartName:            pp.PartName,
+		SerialNumber:        pp.SerialNumber,
+		Category:            pp.Category,
+		UsageHours:          pp.UsageHours,
+		UsageLimitHours:     pp.UsageLimitHours,
+		ExtensionHours:      pp.ExtensionHours,
+		EffectiveLimitHours: pp.EffectiveLimitHours(),
+		UsageCycles:         pp.UsageCycles,
+		UsageLimitCycles:    pp.UsageLimitCycles,
+		CalendarLimitDays:   pp.CalendarLimitDays,
+		CalendarDueAt:       pp.CalendarDueAt(),
+		InstalledAt:         pp.InstalledAt,
+		InServiceAt:         pp.InServiceAt,
+		DeletedAt:           deletedAt(pp.DeletedAt),
 	}
+	resp.UsagePercent, resp.LimitDriver = pp.LifeUsed(time.Now())
 	return resp
 }
 
 func (pp *PlanePart) ToResponseWithPlane() PlanePartResponse {
 	resp := pp.ToResponse()
 	if pp.Plane != nil {
-		resp.PlaneID = pp.Plane.ID
+		resp.PlaneID = &pp.Plane.ID
 	}
 	return resp
 }
 
-type PlanePartsByPlaneQuery struct {
-	Category *string `form:"category"`
+// PartQuery filters and sorts part lists. Installed dates are inclusive
+// calendar days (YYYY-MM-DD); usage_percent is the same figure reported in
+// PlanePartResponse.
+type PartQuery struct {
+	PaginationQuery
+	DeletedFilter
+	PlaneID         *int64     `form:"plane_id" binding:"omitempty,gt=0"`
+	CatalogPartID   *int64     `form:"catalog_part_id" binding:"omitempty,gt=0"`
+	Category        string     `form:"category"`
+	PartName        string     `form:"part_name"`
+	MinUsageHours   *float64   `form:"min_usage_hours" binding:"omitempty,gte=0"`
+	MaxUsageHours   *float64   `form:"max_usage_hours" binding:"omitempty,gte=0"`
+	MinUsagePercent *float64   `form:"min_usage_percent" binding:"omitempty,gte=0"`
+	MaxUsagePercent *float64   `form:"max_usage_percent" binding:"omitempty,gte=0"`
+	InstalledFrom   *time.Time `form:"installed_from" time_format:"2006-01-02" time_utc:"1"`
+	InstalledTo     *time.Time `form:"installed_to" time_format:"2006-01-02" time_utc:"1"`
+	Sort            string     `form:"sort" binding:"omitempty,oneof=id serial_number part_name category usage_hours usage_cycles usage_percent installed_at"`
+	Order           string     `form:"order" binding:"omitempty,oneof=asc desc"`
+
+	// Installed is set by the endpoint rather than the query string:
+	// false lists spares only.
+	Installed *bool `form:"-"`
 }
 
+// MaintenanceAlertQuery lists parts at or above Threshold percent of life
+// used (default 80), most used first unless Sort says otherwise.
 type MaintenanceAlertQuery struct {
-	Threshold float64 `form:"threshold" binding:"omitempty,gte=0,lte=100"`
+	PartQuery
+	Threshold *float64 `form:"threshold" binding:"omitempty,gte=0"`
 }
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	PlaneStatusActive        = "active"
+	PlaneStatusInMaintenance = "in_maintenance"
+	PlaneStatusGrounded      = "grounded"
+	PlaneStatusRetired       = "retired"
+)
+
+// planeStatusTransitions lists the statuses each status may move to. A
+// grounded plane goes back into service through maintenance; retirement is
+// final.
+var planeStatusTransitions = map[string][]string{
+	PlaneStatusActive:        {PlaneStatusInMaintenance, PlaneStatusGrounded, PlaneStatusRetired},
+	PlaneStatusInMaintenance: {PlaneStatusActive, PlaneStatusGrounded, PlaneStatusRetired},
+	PlaneStatusGrounded:      {PlaneStatusInMaintenance, PlaneStatusRetired},
+	PlaneStatusRetired:       {},
+}
+
+// CanTransitionPlaneStatus reports whether a plane may move from one status
+// to another.
+func CanTransitionPlaneStatus(from, to string) bool {
+	for _, next := range planeStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Operational reports whether the plane may fly and accrue usage. Grounded
+// and retired planes may not.
+func (p *Plane) Operational() bool {
+	return p.Status != PlaneStatusGrounded && p.Status != PlaneStatusRetired
+}
+
+// PlaneStatusChange is one entry in a plane's status history.
+type PlaneStatusChange struct {
+	ID         int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	PlaneID    int64     `json:"plane_id" gorm:"not null;index"`
+	FromStatus string    `json:"from_status" gorm:"type:varchar(20);not null"`
+	ToStatus   string    `json:"to_status" gorm:"type:varchar(20);not null"`
+	Reason     *string   `json:"reason" gorm:"type:varchar(500)"`
+	ChangedBy  *int64    `json:"changed_by"`
+	ChangedAt  time.Time `json:"changed_at" gorm:"autoCreateTime"`
+}
+
+type UpdatePlaneStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active in_maintenance grounded retired"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type PlaneStatusChangeResponse struct {
+	ID         int64     `json:"id"`
+	PlaneID    int64     `json:"plane_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     *string   `json:"reason,omitempty"`
+	ChangedBy  *int64    `json:"changed_by"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+func (c *PlaneStatusChange) ToResponse() PlaneStatusChangeResponse {
+	return PlaneStatusChangeResponse{
+		ID:         c.ID,
+		PlaneID:    c.PlaneID,
+		FromStatus: c.FromStatus,
+		ToStatus:   c.ToStatus,
+		Reason:     c.Reason,
+		ChangedBy:  c.ChangedBy,
+		ChangedAt:  c.ChangedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+const (
+	SearchTypePlane = "plane"
+	SearchTypePart  = "part"
+)
+
+// SearchQuery is a fleet-wide lookup by partial tail number, model, serial,
+// part name or category. Type restricts results to planes or parts.
+type SearchQuery struct {
+	PaginationQuery
+	Q    string `form:"q" binding:"required,min=2,max=100"`
+	Type string `form:"type" binding:"omitempty,oneof=plane part"`
+}
+
+// SearchResult is one ranked hit. For planes Title is the tail number and
+// Subtitle the model; for parts they are the serial number and part name.
+type SearchResult struct {
+	Type     string  `json:"type"`
+	ID       int64   `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle"`
+	Category *string `json:"category,omitempty"`
+	PlaneID  *int64  `json:"plane_id"`
+	Score    float64 `json:"score"`
+}
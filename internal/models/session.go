@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// Reasons recorded when a session is revoked.
+const (
+	SessionRevokedLogout          = "logout"
+	SessionRevokedLogoutAll       = "logout_all"
+	SessionRevokedTokenReuse      = "token_reuse"
+	SessionRevokedPasswordChanged = "password_changed"
+	SessionRevokedRoleChanged     = "role_changed"
+	SessionRevokedUserDeleted     = "user_deleted"
+)
+
+// Session is one login. Access tokens carry its ID and stop working as soon
+// as it is revoked or expires.
+type Session struct {
+	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID        int64      `json:"user_id" gorm:"not null;index"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	RevokedReason *string    `json:"revoked_reason" gorm:"type:varchar(50)"`
+	LastUsedAt    time.Time  `json:"last_used_at" gorm:"autoCreateTime"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// RefreshToken is a single-use token that is exchanged for a new access and
+// refresh token pair. Only its SHA-256 hash is stored.
+type RefreshToken struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	SessionID int64      `json:"session_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	Session   Session    `json:"-" gorm:"foreignKey:SessionID"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DeletedInclude = "include"
+	DeletedOnly    = "only"
+)
+
+// DeletedFilter lets admins see soft-deleted records in a list. By default
+// they are left out; "include" lists them alongside live records and "only"
+// lists nothing else.
+type DeletedFilter struct {
+	Deleted string `form:"deleted" binding:"omitempty,oneof=include only"`
+}
+
+// deletedAt converts gorm's soft delete marker for responses: nil while the
+// record is live.
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Time
+}
+
+// IncludesDeleted reports whether the query asks for soft-deleted records.
+func (f DeletedFilter) IncludesDeleted() bool {
+	return f.Deleted != ""
+}
@@ -2,20 +2,32 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RoleUser     = "user"
+	RoleMechanic = "mechanic"
+	RoleAdmin    = "admin"
 )
 
 type User struct {
-	ID        int64     `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name      string    `json:"name" gorm:"type:varchar(255);not null"`
-	Password  string    `json:"-" gorm:"type:varchar(255);not null"`
-	Role      string    `json:"role" gorm:"type:varchar(100);default:'user'"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID        int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string         `json:"name" gorm:"type:varchar(255);not null"`
+	Password  string         `json:"-" gorm:"type:varchar(255);not null"`
+	Role      string         `json:"role" gorm:"type:varchar(100);default:'user'"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
+// RegisterRequest signs up a new user. Without an invite token the role must
+// be empty or "user"; with one, the invite decides the role.
 type RegisterRequest struct {
-	Name     string `json:"name" binding:"required,min=2,max=255"`
-	Password string `json:"password" binding:"required,min=6"`
-	Role     string `json:"role" binding:"omitempty,oneof=user mechanic admin"`
+	Name        string `json:"name" binding:"required,min=2,max=255"`
+	Password    string `json:"password" binding:"required,min=6"`
+	Role        string `json:"role" binding:"omitempty,oneof=user mechanic admin"`
+	InviteToken string `json:"invite_token"`
 }
 
 type LoginRequest struct {
@@ -26,14 +38,26 @@ type LoginRequest struct {
 type UpdateRequest struct {
 	Name     string `json:"name" binding:"omitempty,min=2,max=255"`
 	Password string `json:"password" binding:"omitempty,min=6"`
-	Role     string `json:"role" binding:"omitempty,oneof=user admin"`
+	Role     string `json:"role" binding:"omitempty,oneof=user mechanic admin"`
+}
+
+// UserQuery filters and sorts GET /api/users. Name matches a
+// case-insensitive substring.
+type UserQuery struct {
+	PaginationQuery
+	DeletedFilter
+	Name  string `form:"name"`
+	Role  string `form:"role" binding:"omitempty,oneof=user mechanic admin"`
+	Sort  string `form:"sort" binding:"omitempty,oneof=id name role created_at"`
+	Order string `form:"order" binding:"omitempty,oneof=asc desc"`
 }
 
 type UserResponse struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 func (u *User) ToResponse() UserResponse {
@@ -42,5 +66,6 @@ func (u *User) ToResponse() UserResponse {
 		Name:      u.Name,
 		Role:      u.Role,
 		CreatedAt: u.CreatedAt,
+		DeletedAt: deletedAt(u.DeletedAt),
 	}
 }
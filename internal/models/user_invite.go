@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// UserInvite lets an admin pre-approve a role for someone who has not
+// registered yet. Only the SHA-256 hash of the token is stored.
+type UserInvite struct {
+	ID         int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	TokenHash  string     `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	Role       string     `json:"role" gorm:"type:varchar(100);not null"`
+	CreatedBy  *int64     `json:"created_by"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	RedeemedBy *int64     `json:"redeemed_by"`
+	RedeemedAt *time.Time `json:"redeemed_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type CreateInviteRequest struct {
+	Role           string `json:"role" binding:"required,oneof=user mechanic admin"`
+	ExpiresInHours int    `json:"expires_in_hours" binding:"omitempty,min=1,max=720"`
+}
+
+type InviteResponse struct {
+	ID         int64      `json:"id"`
+	Role       string     `json:"role"`
+	Token      string     `json:"token,omitempty"`
+	CreatedBy  *int64     `json:"created_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RedeemedBy *int64     `json:"redeemed_by"`
+	RedeemedAt *time.Time `json:"redeemed_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (i *UserInvite) ToResponse() InviteResponse {
+	return InviteResponse{
+		ID:         i.ID,
+		Role:       i.Role,
+		CreatedBy:  i.CreatedBy,
+		ExpiresAt:  i.ExpiresAt,
+		RedeemedBy: i.RedeemedBy,
+		RedeemedAt: i.RedeemedAt,
+		CreatedAt:  i.CreatedAt,
+	}
+}
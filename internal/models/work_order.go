@@ -0,0 +1,151 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	WorkOrderStatusOpen       = "open"
+	WorkOrderStatusInProgress = "in_progress"
+	WorkOrderStatusSignedOff  = "signed_off"
+	WorkOrderStatusClosed     = "closed"
+
+	WorkOrderActionReset   = "reset"
+	WorkOrderActionReplace = "replace"
+)
+
+// workOrderStatusTransitions lists the statuses each status may move to.
+// A work order only closes once its parts are signed off.
+var workOrderStatusTransitions = map[string][]string{
+	WorkOrderStatusOpen:       {WorkOrderStatusInProgress},
+	WorkOrderStatusInProgress: {WorkOrderStatusSignedOff},
+	WorkOrderStatusSignedOff:  {WorkOrderStatusClosed},
+	WorkOrderStatusClosed:     {},
+}
+
+// CanTransitionWorkOrderStatus reports whether a work order may move from
+// one status to another.
+func CanTransitionWorkOrderStatus(from, to string) bool {
+	for _, next := range workOrderStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type WorkOrder struct {
+	ID          int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Title       string          `json:"title" gorm:"type:varchar(255);not null"`
+	Description string          `json:"description" gorm:"type:text"`
+	Status      string          `json:"status" gorm:"type:varchar(20);not null;default:'open';index"`
+	AssignedTo  *int64          `json:"assigned_to" gorm:"index"`
+	CreatedBy   *int64          `json:"created_by"`
+	SignedOffBy *int64          `json:"signed_off_by"`
+	SignedOffAt *time.Time      `json:"signed_off_at"`
+	ClosedAt    *time.Time      `json:"closed_at"`
+	CreatedAt   time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	Parts       []WorkOrderPart `json:"parts,omitempty" gorm:"foreignKey:WorkOrderID"`
+}
+
+// WorkOrderPart links a work order to one of the parts it covers. Action and
+// ReplacementPartID are filled in when the work order is signed off.
+type WorkOrderPart struct {
+	ID                int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	WorkOrderID       int64      `json:"work_order_id" gorm:"not null;index"`
+	PartID            int64      `json:"part_id" gorm:"not null;index"`
+	Action            *string    `json:"action" gorm:"type:varchar(20)"`
+	ReplacementPartID *int64     `json:"replacement_part_id"`
+	Part              *PlanePart `json:"part,omitempty" gorm:"foreignKey:PartID"`
+}
+
+type CreateWorkOrderRequest struct {
+	Title       string  `json:"title" binding:"required,min=2,max=255"`
+	Description string  `json:"description"`
+	PartIDs     []int64 `json:"part_ids" binding:"required,min=1,dive,gt=0"`
+	AssignedTo  *int64  `json:"assigned_to" binding:"omitempty,gt=0"`
+}
+
+type AssignWorkOrderRequest struct {
+	UserID int64 `json:"user_id" binding:"required,gt=0"`
+}
+
+type SignOffWorkOrderRequest struct {
+	Items []SignOffItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type SignOffItemRequest struct {
+	PartID      int64                   `json:"part_id" binding:"required"`
+	Action      string                  `json:"action" binding:"required,oneof=reset replace"`
+	Replacement *ReplacementPartRequest `json:"replacement" binding:"required_if=Action replace,omitempty"`
+}
+
+// ReplacementPartRequest describes the serial fitted in place of a removed
+// part. Name, category and limits default to those of the removed part.
+type ReplacementPartRequest struct {
+	SerialNumber      string   `json:"serial_number" binding:"required,min=2,max=100"`
+	PartName          *string  `json:"part_name" binding:"omitempty,min=2,max=255"`
+	UsageHours        float64  `json:"usage_hours" binding:"omitempty,gte=0"`
+	UsageCycles       int      `json:"usage_cycles" binding:"omitempty,gte=0"`
+	UsageLimitHours   *float64 `json:"usage_limit_hours" binding:"omitempty,gt=0"`
+	UsageLimitCycles  *int     `json:"usage_limit_cycles" binding:"omitempty,gt=0"`
+	CalendarLimitDays *int     `json:"calendar_limit_days" binding:"omitempty,gt=0"`
+}
+
+type WorkOrderQuery struct {
+	PaginationQuery
+	Status     string `form:"status" binding:"omitempty,oneof=open in_progress signed_off closed"`
+	AssignedTo *int64 `form:"assigned_to" binding:"omitempty,gt=0"`
+}
+
+type WorkOrderPartResponse struct {
+	PartID            int64              `json:"part_id"`
+	Action            *string            `json:"action"`
+	ReplacementPartID *int64             `json:"replacement_part_id"`
+	Part              *PlanePartResponse `json:"part,omitempty"`
+}
+
+type WorkOrderResponse struct {
+	ID          int64                   `json:"id"`
+	Title       string                  `json:"title"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	AssignedTo  *int64                  `json:"assigned_to"`
+	CreatedBy   *int64                  `json:"created_by"`
+	SignedOffBy *int64                  `json:"signed_off_by"`
+	SignedOffAt *time.Time              `json:"signed_off_at"`
+	ClosedAt    *time.Time              `json:"closed_at"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+	Parts       []WorkOrderPartResponse `json:"parts"`
+}
+
+func (wo *WorkOrder) ToResponse() WorkOrderResponse {
+	resp := WorkOrderResponse{
+		ID:          wo.ID,
+		Title:       wo.Title,
+		Description: wo.Description,
+		Status:      wo.Status,
+		AssignedTo:  wo.AssignedTo,
+		CreatedBy:   wo.CreatedBy,
+		SignedOffBy: wo.SignedOffBy,
+		SignedOffAt: wo.SignedOffAt,
+		ClosedAt:    wo.ClosedAt,
+		CreatedAt:   wo.CreatedAt,
+		UpdatedAt:   wo.UpdatedAt,
+		Parts:       make([]WorkOrderPartResponse, len(wo.Parts)),
+	}
+	for i, item := range wo.Parts {
+		resp.Parts[i] = WorkOrderPartResponse{
+			PartID:            item.PartID,
+			Action:            item.Action,
+			ReplacementPartID: item.ReplacementPartID,
+		}
+		if item.Part != nil {
+			partResp := item.Part.ToResponse()
+			resp.Parts[i].Part = &partResp
+		}
+	}
+	return resp
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type AircraftModelRepository struct {
+	db *gorm.DB
+}
+
+func NewAircraftModelRepository(db *gorm.DB) *AircraftModelRepository {
+	return &AircraftModelRepository{db: db}
+}
+
+func preloadSlots(db *gorm.DB) *gorm.DB {
+	return db.Preload("Slots", func(db *gorm.DB) *gorm.DB { return db.Order("id") })
+}
+
+func (r *AircraftModelRepository) Create(ctx context.Context, aircraftModel *models.AircraftModel) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Create(aircraftModel)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create aircraft model: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *AircraftModelRepository) GetByID(ctx context.Context, id int64) (*models.AircraftModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var aircraftModel models.AircraftModel
+	result := preloadSlots(conn(ctx, r.db)).First(&aircraftModel, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get aircraft model by id: %w", result.Error)
+	}
+
+	return &aircraftModel, nil
+}
+
+// GetByName looks a model up the way planes refer to it, ignoring case.
+func (r *AircraftModelRepository) GetByName(ctx context.Context, name string) (*models.AircraftModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var aircraftModel models.AircraftModel
+	result := preloadSlots(conn(ctx, r.db)).
+		Where("LOWER(name) = LOWER(?)", name).
+		First(&aircraftModel)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get aircraft model by name: %w", result.Error)
+	}
+
+	return &aircraftModel, nil
+}
+
+var aircraftModelSortColumns = map[string]string{
+	"id":           "id",
+	"name":         "name",
+	"manufacturer": "manufacturer",
+	"created_at":   "created_at",
+}
+
+func (r *AircraftModelRepository) List(ctx context.Context, query *models.AircraftModelQuery) ([]models.AircraftModel, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := conn(ctx, r.db).Model(&models.AircraftModel{})
+	if query.Name != "" {
+		db = db.Where("name ILIKE ?", "%"+query.Name+"%")
+	}
+	if query.Manufacturer != "" {
+		db = db.Where("manufacturer ILIKE ?", "%"+query.Manufacturer+"%")
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count aircraft models: %w", err)
+	}
+
+	var aircraftModels []models.AircraftModel
+	result := preloadSlots(db).
+		Order(orderBy(aircraftModelSortColumns, query.Sort, query.Order, "name", models.SortAsc)).
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&aircraftModels)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get aircraft models: %w", result.Error)
+	}
+
+	return aircraftModels, total, nil
+}
+
+// Update saves the model and, when replaceSlots is set, replaces its slots in
+// the same transaction.
+func (r *AircraftModelRepository) Update(ctx context.Context, aircraftModel *models.AircraftModel, replaceSlots bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Slots").Save(aircraftModel).Error; err != nil {
+			return err
+		}
+		if !replaceSlots {
+			return nil
+		}
+		if err := tx.Where("aircraft_model_id = ?", aircraftModel.ID).Delete(&models.AircraftModelSlot{}).Error; err != nil {
+			return err
+		}
+		if len(aircraftModel.Slots) == 0 {
+			return nil
+		}
+		for i := range aircraftModel.Slots {
+			aircraftModel.Slots[i].ID = 0
+			aircraftModel.Slots[i].AircraftModelID = aircraftModel.ID
+		}
+		return tx.Create(&aircraftModel.Slots).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update aircraft model: %w", err)
+	}
+
+	return nil
+}
+
+// CountPlanes counts the planes of this model, deleted ones included, since
+// they can be restored.
+func (r *AircraftModelRepository) CountPlanes(ctx context.Context, name string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	result := conn(ctx, r.db).Unscoped().Model(&models.Plane{}).
+		Where("LOWER(model) = LOWER(?)", name).
+		Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count aircraft model usage: %w", result.Error)
+	}
+
+	return count, nil
+}
+
+func (r *AircraftModelRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Delete(&models.AircraftModel{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete aircraft model: %w", result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+// auditChainLockKey is the transaction-scoped advisory lock that serializes
+// appends, so two requests cannot chain onto the same previous hash.
+const auditChainLockKey = 0x61756469740001
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Append links entries onto the end of the chain in order, setting each
+// entry's PrevHash and Hash, and stores them. Inside a transaction from
+// Transactor.InTx the entries are stored just before it commits: the chain
+// lock is then held only for the commit, and a failure rolls back the change
+// being audited. Otherwise they are stored in a transaction of their own,
+// with the import timeout because an import appends an entry per created row.
+func (r *AuditRepository) Append(ctx context.Context, entries []*models.AuditEntry) error {
+	if beforeCommit(ctx, func(tx *gorm.DB) error { return appendEntries(tx, entries) }) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return appendEntries(tx, entries)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append audit entries: %w", err)
+	}
+
+	return nil
+}
+
+// appendEntries chains and inserts entries. The advisory lock serializes
+// appends until tx commits, so two transactions cannot chain onto the same
+// previous hash.
+func appendEntries(tx *gorm.DB, entries []*models.AuditEntry) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockKey).Error; err != nil {
+		return fmt.Errorf("failed to lock audit chain: %w", err)
+	}
+
+	var last models.AuditEntry
+	prevHash := models.AuditGenesisHash
+	result := tx.Select("hash").Order("id DESC").Limit(1).Find(&last)
+	if result.Error != nil {
+		return fmt.Errorf("failed to get audit chain head: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		prevHash = last.Hash
+	}
+
+	for _, entry := range entries {
+		entry.PrevHash = prevHash
+		entry.Hash = entry.ComputeHash()
+		prevHash = entry.Hash
+	}
+	if err := tx.CreateInBatches(entries, 500).Error; err != nil {
+		return fmt.Errorf("failed to append audit entries: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, query *models.AuditQuery) ([]models.AuditEntry, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := conn(ctx, r.db).Model(&models.AuditEntry{})
+	if query.EntityType != "" {
+		db = db.Where("entity_type = ?", query.EntityType)
+	}
+	if query.EntityID != nil {
+		db = db.Where("entity_id = ?", *query.EntityID)
+	}
+	if query.ActorID != nil {
+		db = db.Where("actor_id = ?", *query.ActorID)
+	}
+	if query.Action != "" {
+		db = db.Where("action = ?", query.Action)
+	}
+	if query.RequestID != "" {
+		db = db.Where("request_id = ?", query.RequestID)
+	}
+	if query.From != nil {
+		db = db.Where("created_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("created_at < ?", query.To.AddDate(0, 0, 1))
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	var entries []models.AuditEntry
+	result := db.Order("id DESC").Offset(query.Offset()).Limit(query.PageSize).Find(&entries)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get audit entries: %w", result.Error)
+	}
+
+	return entries, total, nil
+}
+
+// Stream passes every entry to fn in chain order.
+func (r *AuditRepository) Stream(ctx context.Context, fn func(*models.AuditEntry) error) error {
+	ctx, cancel := context.WithTimeout(ctx, StreamTimeout)
+	defer cancel()
+
+	db := conn(ctx, r.db).Model(&models.AuditEntry{}).Order("id")
+	if err := streamRows(db, fn); err != nil {
+		return fmt.Errorf("failed to stream audit entries: %w", err)
+	}
+
+	return nil
+}
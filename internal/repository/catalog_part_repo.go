@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type CatalogPartRepository struct {
+	db *gorm.DB
+}
+
+func NewCatalogPartRepository(db *gorm.DB) *CatalogPartRepository {
+	return &CatalogPartRepository{db: db}
+}
+
+func (r *CatalogPartRepository) Create(ctx context.Context, catalogPart *models.CatalogPart) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Create(catalogPart)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create catalog part: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *CatalogPartRepository) GetByID(ctx context.Context, id int64) (*models.CatalogPart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var catalogPart models.CatalogPart
+	result := conn(ctx, r.db).
+		Preload("ApplicableModels", func(db *gorm.DB) *gorm.DB { return db.Order("model") }).
+		First(&catalogPart, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get catalog part by id: %w", result.Error)
+	}
+
+	return &catalogPart, nil
+}
+
+func (r *CatalogPartRepository) GetByPartNumber(ctx context.Context, partNumber string) (*models.CatalogPart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var catalogPart models.CatalogPart
+	result := conn(ctx, r.db).Where("part_number = ?", partNumber).First(&catalogPart)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get catalog part by part number: %w", result.Error)
+	}
+
+	return &catalogPart, nil
+}
+
+// GetByPartNumbers returns the catalog entries with the given part numbers
+// and their applicable models.
+func (r *CatalogPartRepository) GetByPartNumbers(ctx context.Context, partNumbers []string) ([]models.CatalogPart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var catalogParts []models.CatalogPart
+	result := conn(ctx, r.db).
+		Preload("ApplicableModels").
+		Where("part_number IN ?", partNumbers).
+		Find(&catalogParts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get catalog parts by part numbers: %w", result.Error)
+	}
+
+	return catalogParts, nil
+}
+
+var catalogSortColumns = map[string]string{
+	"id":           "id",
+	"part_number":  "part_number",
+	"manufacturer": "manufacturer",
+	"category":     "category",
+	"created_at":   "created_at",
+}
+
+func (r *CatalogPartRepository) List(ctx context.Context, query *models.CatalogQuery) ([]models.CatalogPart, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := conn(ctx, r.db).Model(&models.CatalogPart{})
+	if query.PartNumber != "" {
+		db = db.Where("part_number ILIKE ?", "%"+query.PartNumber+"%")
+	}
+	if query.Manufacturer != "" {
+		db = db.Where("manufacturer ILIKE ?", "%"+query.Manufacturer+"%")
+	}
+	if query.Category != "" {
+		db = db.Where("category = ?", query.Category)
+	}
+	if query.Model != "" {
+		// Part numbers without applicable models fit every aircraft.
+		db = db.Where(`NOT EXISTS (SELECT 1 FROM catalog_part_models m WHERE m.catalog_part_id = catalog_parts.id)
+			OR EXISTS (SELECT 1 FROM catalog_part_models m WHERE m.catalog_part_id = catalog_parts.id AND LOWER(m.model) = LOWER(?))`, query.Model)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count catalog parts: %w", err)
+	}
+
+	var catalogParts []models.CatalogPart
+	result := db.
+		Preload("ApplicableModels", func(db *gorm.DB) *gorm.DB { return db.Order("model") }).
+		Order(orderBy(catalogSortColumns, query.Sort, query.Order, "part_number", models.SortAsc)).
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&catalogParts)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get catalog parts: %w", result.Error)
+	}
+
+	return catalogParts, total, nil
+}
+
+// Update saves the catalog entry and replaces its applicable models in one
+// transaction.
+func (r *CatalogPartRepository) Update(ctx context.Context, catalogPart *models.CatalogPart) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("ApplicableModels").Save(catalogPart).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("catalog_part_id = ?", catalogPart.ID).Delete(&models.CatalogPartModel{}).Error; err != nil {
+			return err
+		}
+		if len(catalogPart.ApplicableModels) == 0 {
+			return nil
+		}
+		return tx.Create(&catalogPart.ApplicableModels).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update catalog part: %w", err)
+	}
+
+	return nil
+}
+
+// CountParts counts the serialized parts of this part number, deleted ones
+// included, since they still reference it.
+func (r *CatalogPartRepository) CountParts(ctx context.Context, id int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	result := conn(ctx, r.db).Unscoped().Model(&models.PlanePart{}).
+		Where("catalog_part_id = ?", id).
+		Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count catalog part usage: %w", result.Error)
+	}
+
+	return count, nil
+}
+
+// CountSlots counts the aircraft model slots that require this part number.
+func (r *CatalogPartRepository) CountSlots(ctx context.Context, id int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	result := conn(ctx, r.db).Model(&models.AircraftModelSlot{}).
+		Where("catalog_part_id = ?", id).
+		Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count catalog part slots: %w", result.Error)
+	}
+
+	return count, nil
+}
+
+func (r *CatalogPartRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Delete(&models.CatalogPart{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete catalog part: %w", result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type FlightRepository struct {
+	db *gorm.DB
+}
+
+func NewFlightRepository(db *gorm.DB) *FlightRepository {
+	return &FlightRepository{db: db}
+}
+
+// CreateWithAccrual inserts the flight and, in the same transaction, appends a
+// ledger entry for every part installed on the plane and adds the flight's
+// block hours and cycles to their totals. It returns the number of parts
+// updated.
+func (r *FlightRepository) CreateWithAccrual(ctx context.Context, flight *models.Flight) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var updated int
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Plane").Create(flight).Error; err != nil {
+			return err
+		}
+
+		var partIDs []int64
+		if err := tx.Model(&models.PlanePart{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("plane_id = ?", flight.PlaneID).
+			Order("id").
+			Pluck("id", &partIDs).Error; err != nil {
+			return err
+		}
+		if len(partIDs) == 0 {
+			return nil
+		}
+
+		entries := make([]models.PartUsageEntry, len(partIDs))
+		for i, partID := range partIDs {
+			entries[i] = models.PartUsageEntry{
+				PartID:      partID,
+				DeltaHours:  flight.BlockHours,
+				DeltaCycles: flight.Cycles,
+				RecordedBy:  flight.RecordedBy,
+				Source:      models.UsageSourceFlight,
+				FlightID:    &flight.ID,
+			}
+		}
+		if err := tx.Create(&entries).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.PlanePart{}).
+			Where("id IN ?", partIDs).
+			Updates(map[string]interface{}{
+				"usage_hours":  gorm.Expr("usage_hours + ?", flight.BlockHours),
+				"usage_cycles": gorm.Expr("usage_cycles + ?", flight.Cycles),
+			}).Error; err != nil {
+			return err
+		}
+
+		updated = len(partIDs)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to record flight: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *FlightRepository) GetByID(ctx context.Context, id int64) (*models.Flight, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var flight models.Flight
+	result := conn(ctx, r.db).First(&flight, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get flight by id: %w", result.Error)
+	}
+
+	return &flight, nil
+}
+
+func (r *FlightRepository) GetByPlaneID(ctx context.Context, planeID int64, offset, limit int) ([]models.Flight, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int64
+	query := conn(ctx, r.db).Model(&models.Flight{}).Where("plane_id = ?", planeID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count flights: %w", err)
+	}
+
+	var flights []models.Flight
+	result := query.Order("departure_at DESC, id DESC").Offset(offset).Limit(limit).Find(&flights)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get flights by plane id: %w", result.Error)
+	}
+
+	return flights, total, nil
+}
+
+// GetUtilization sums the hours and cycles flown since the given time, per
+// plane. Planes without flights are included with zero totals. A nil
+// planeID covers the whole fleet.
+func (r *FlightRepository) GetUtilization(ctx context.Context, since time.Time, planeID *int64) ([]models.PlaneUtilization, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := conn(ctx, r.db).
+		Table("planes").
+		Select(`planes.id AS plane_id, planes.tail_number,
+			GREATEST(planes.created_at, ?) AS window_start,
+			COALESCE(SUM(flights.block_hours), 0) AS hours,
+			COALESCE(SUM(flights.cycles), 0) AS cycles`, since).
+		Joins("LEFT JOIN flights ON flights.plane_id = planes.id AND flights.departure_at >= ?", since).
+		Where("planes.deleted_at IS NULL").
+		Group("planes.id")
+	if planeID != nil {
+		query = query.Where("planes.id = ?", *planeID)
+	}
+
+	var utilization []models.PlaneUtilization
+	if err := query.Scan(&utilization).Error; err != nil {
+		return nil, fmt.Errorf("failed to get plane utilization: %w", err)
+	}
+
+	return utilization, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type HealthRepository struct {
+	db *gorm.DB
+}
+
+func NewHealthRepository(db *gorm.DB) *HealthRepository {
+	return &HealthRepository{db: db}
+}
+
+// Ping checks that the database accepts connections.
+func (r *HealthRepository) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database handle: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return nil
+}
+
+// MigrationVersion returns the newest migration goose has applied, or 0 when
+// none has been.
+func (r *HealthRepository) MigrationVersion(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	var version *int64
+	result := conn(ctx, r.db).
+		Raw("SELECT MAX(version_id) FROM goose_db_version WHERE is_applied").
+		Scan(&version)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to get migration version: %w", result.Error)
+	}
+	if version == nil {
+		return 0, nil
+	}
+
+	return *version, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+// importTimeout is longer than the usual query timeout because an import
+// writes thousands of rows in one transaction.
+const importTimeout = 60 * time.Second
+
+type ImportRepository struct {
+	db *gorm.DB
+}
+
+func NewImportRepository(db *gorm.DB) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// Import creates planes and parts in a single transaction. partTails[i] is
+// the tail number parts[i] is installed on, or "" for a spare; tails are
+// resolved after the new planes are created so parts can reference them.
+func (r *ImportRepository) Import(ctx context.Context, planes []models.Plane, parts []models.PlanePart, partTails []string, createdBy *int64) error {
+	ctx, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if len(planes) > 0 {
+			if err := tx.CreateInBatches(&planes, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		var tails []string
+		for _, tail := range partTails {
+			if tail != "" {
+				tails = append(tails, tail)
+			}
+		}
+		planeIDs := make(map[string]int64)
+		if len(tails) > 0 {
+			var targets []models.Plane
+			if err := tx.Where("tail_number IN ?", tails).Find(&targets).Error; err != nil {
+				return err
+			}
+			for _, plane := range targets {
+				planeIDs[plane.TailNumber] = plane.ID
+			}
+		}
+
+		for i := range parts {
+			if tail := partTails[i]; tail != "" {
+				planeID, ok := planeIDs[tail]
+				if !ok {
+					return fmt.Errorf("plane %s not found", tail)
+				}
+				parts[i].PlaneID = &planeID
+			}
+			if err := createPart(tx, &parts[i], createdBy); err != nil {
+				return fmt.Errorf("part %s: %w", parts[i].SerialNumber, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import planes and parts: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type LimitExtensionRepository struct {
+	db *gorm.DB
+}
+
+func NewLimitExtensionRepository(db *gorm.DB) *LimitExtensionRepository {
+	return &LimitExtensionRepository{db: db}
+}
+
+func (r *LimitExtensionRepository) Create(ctx context.Context, extension *models.PartLimitExtension) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Create(extension)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create limit extension: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *LimitExtensionRepository) GetByID(ctx context.Context, id int64) (*models.PartLimitExtension, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var extension models.PartLimitExtension
+	result := conn(ctx, r.db).First(&extension, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get limit extension by id: %w", result.Error)
+	}
+
+	return &extension, nil
+}
+
+func (r *LimitExtensionRepository) List(ctx context.Context, status string, partID *int64, offset, limit int) ([]models.PartLimitExtension, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := conn(ctx, r.db).Model(&models.PartLimitExtension{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if partID != nil {
+		query = query.Where("part_id = ?", *partID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count limit extensions: %w", err)
+	}
+
+	var extensions []models.PartLimitExtension
+	result := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&extensions)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get limit extensions: %w", result.Error)
+	}
+
+	return extensions, total, nil
+}
+
+// Review moves a pending extension to its reviewed status and, when it is
+// approved, adds its hours to the part's extension total, in one
+// transaction. It reports false without changing anything when the
+// extension is no longer pending.
+func (r *LimitExtensionRepository) Review(ctx context.Context, extension *models.PartLimitExtension) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	reviewed := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PartLimitExtension{}).
+			Where("id = ? AND status = ?", extension.ID, models.ExtensionStatusPending).
+			Updates(map[string]interface{}{
+				"status":      extension.Status,
+				"reviewed_by": extension.ReviewedBy,
+				"review_note": extension.ReviewNote,
+				"reviewed_at": extension.ReviewedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		reviewed = true
+		if extension.Status != models.ExtensionStatusApproved {
+			return nil
+		}
+		return tx.Model(&models.PlanePart{}).
+			Where("id = ?", extension.PartID).
+			Update("extension_hours", gorm.Expr("extension_hours + ?", extension.RequestedHours)).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to review limit extension: %w", err)
+	}
+
+	return reviewed, nil
+}
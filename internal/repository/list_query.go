@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+// StreamTimeout bounds an export. It is far longer than the usual query
+// timeout because the whole result is written to the client while the
+// cursor is open.
+const StreamTimeout = 10 * time.Minute
+
+// orderBy builds an ORDER BY clause from a whitelisted sort key. Unknown or
+// empty keys fall back to defaultSort/defaultOrder. id breaks ties so pages
+// stay stable.
+func orderBy(columns map[string]string, sort, order, defaultSort, defaultOrder string) string {
+	column, ok := columns[sort]
+	if !ok {
+		column = columns[defaultSort]
+		if order == "" {
+			order = defaultOrder
+		}
+	}
+
+	direction := "ASC"
+	if order == models.SortDesc {
+		direction = "DESC"
+	}
+	return column + " " + direction + " NULLS LAST, id " + direction
+}
+
+// scopeDeleted widens a query to soft-deleted rows when the filter asks for
+// them. Queries leave deleted rows out by default.
+func scopeDeleted(db *gorm.DB, filter models.DeletedFilter) *gorm.DB {
+	switch filter.Deleted {
+	case models.DeletedInclude:
+		return db.Unscoped()
+	case models.DeletedOnly:
+		return db.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+	return db
+}
+
+// streamRows scans the query one row at a time and hands each row to fn, so
+// exports never hold the whole result in memory. An error from fn stops the
+// scan and is returned as is.
+func streamRows[T any](db *gorm.DB, fn func(*T) error) error {
+	rows, err := db.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item T
+		if err := db.ScanRows(rows, &item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type PartInstallationRepository struct {
+	db *gorm.DB
+}
+
+func NewPartInstallationRepository(db *gorm.DB) *PartInstallationRepository {
+	return &PartInstallationRepository{db: db}
+}
+
+// Install fits an uninstalled part to the plane and opens a new installation
+// record carrying the part's current totals. It returns nil without writing
+// if the part is already installed.
+func (r *PartInstallationRepository) Install(ctx context.Context, partID, planeID int64, installedBy *int64) (*models.PartInstallation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var installation *models.PartInstallation
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var part models.PlanePart
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&part, partID).Error; err != nil {
+			return err
+		}
+		if part.PlaneID != nil {
+			return nil
+		}
+
+		now := time.Now()
+		if err := tx.Model(&part).Updates(map[string]interface{}{
+			"plane_id":     planeID,
+			"installed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		installation = &models.PartInstallation{
+			PartID:          partID,
+			PlaneID:         planeID,
+			InstalledAt:     now,
+			InstalledBy:     installedBy,
+			HoursAtInstall:  part.UsageHours,
+			CyclesAtInstall: part.UsageCycles,
+		}
+		return tx.Omit("Plane").Create(installation).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to install plane part: %w", err)
+	}
+
+	return installation, nil
+}
+
+// Remove closes the part's open installation record and leaves the part
+// uninstalled.
+func (r *PartInstallationRepository) Remove(ctx context.Context, partID int64, removedBy *int64, reason *string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var part models.PlanePart
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&part, partID).Error; err != nil {
+			return err
+		}
+		return removePart(tx, &part, removedBy, reason)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove plane part: %w", err)
+	}
+
+	return nil
+}
+
+// removePart closes the open installation of a part that the caller has
+// already locked and leaves the part uninstalled.
+func removePart(tx *gorm.DB, part *models.PlanePart, removedBy *int64, reason *string) error {
+	if part.PlaneID == nil {
+		return fmt.Errorf("plane part is not installed")
+	}
+
+	if err := tx.Model(&models.PartInstallation{}).
+		Where("part_id = ? AND removed_at IS NULL", part.ID).
+		Updates(map[string]interface{}{
+			"removed_at":        time.Now(),
+			"removed_by":        removedBy,
+			"removal_reason":    reason,
+			"hours_at_removal":  part.UsageHours,
+			"cycles_at_removal": part.UsageCycles,
+		}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(part).Update("plane_id", nil).Error; err != nil {
+		return err
+	}
+	part.PlaneID = nil
+	return nil
+}
+
+func (r *PartInstallationRepository) GetByPartID(ctx context.Context, partID int64) ([]models.PartInstallation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var installations []models.PartInstallation
+	result := conn(ctx, r.db).
+		Preload("Plane", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("part_id = ?", partID).
+		Order("installed_at, id").
+		Find(&installations)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get part installations: %w", result.Error)
+	}
+
+	return installations, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type PartUsageRepository struct {
+	db *gorm.DB
+}
+
+func NewPartUsageRepository(db *gorm.DB) *PartUsageRepository {
+	return &PartUsageRepository{db: db}
+}
+
+// Create appends an entry to the part's ledger and re-derives the part's
+// usage totals from the ledger in the same transaction. It returns the new
+// hour and cycle totals.
+func (r *PartUsageRepository) Create(ctx context.Context, entry *models.PartUsageEntry) (float64, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var totals usageTotals
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var part models.PlanePart
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&part, entry.PartID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+
+		var err error
+		totals, err = syncUsage(tx, entry.PartID)
+		return err
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to record part usage: %w", err)
+	}
+
+	return totals.Hours, totals.Cycles, nil
+}
+
+func (r *PartUsageRepository) GetByPartID(ctx context.Context, partID int64, offset, limit int) ([]models.PartUsageEntry, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int64
+	query := conn(ctx, r.db).Model(&models.PartUsageEntry{}).Where("part_id = ?", partID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count part usage entries: %w", err)
+	}
+
+	var entries []models.PartUsageEntry
+	result := query.Order("recorded_at DESC, id DESC").Offset(offset).Limit(limit).Find(&entries)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get part usage entries: %w", result.Error)
+	}
+
+	return entries, total, nil
+}
+
+type usageTotals struct {
+	Hours  float64
+	Cycles int
+}
+
+// syncUsage recomputes plane_parts.usage_hours and usage_cycles from the
+// ledger. It must run inside the transaction that modified the ledger.
+func syncUsage(tx *gorm.DB, partID int64) (usageTotals, error) {
+	var totals usageTotals
+	if err := tx.Model(&models.PartUsageEntry{}).
+		Where("part_id = ?", partID).
+		Select("COALESCE(SUM(delta_hours), 0) AS hours, COALESCE(SUM(delta_cycles), 0) AS cycles").
+		Scan(&totals).Error; err != nil {
+		return usageTotals{}, err
+	}
+
+	if err := tx.Model(&models.PlanePart{}).
+		Where("id = ?", partID).
+		Updates(map[string]interface{}{
+			"usage_hours":  totals.Hours,
+			"usage_cycles": totals.Cycles,
+		}).Error; err != nil {
+		return usageTotals{}, err
+	}
+
+	return totals, nil
+}
@@ -2,14 +2,26 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/JasperRosales/aircraft-system-be/internal/models"
 )
 
+// lifeUsedPercentSQL mirrors models.PlanePart.LifeUsed: the highest share of
+// life consumed across the hour (including approved extensions), cycle and
+// calendar limits. GREATEST skips the NULLs produced by limits that are not
+// set.
+const lifeUsedPercentSQL = `GREATEST(
+	usage_hours / NULLIF(usage_limit_hours + extension_hours, 0) * 100,
+	usage_cycles::numeric / NULLIF(usage_limit_cycles, 0) * 100,
+	EXTRACT(EPOCH FROM (LOCALTIMESTAMP - in_service_at)) / 86400 / NULLIF(calendar_limit_days, 0) * 100
+)`
+
 type PlanePartRepository struct {
 	db *gorm.DB
 }
@@ -18,24 +30,64 @@ func NewPlanePartRepository(db *gorm.DB) *PlanePartRepository {
 	return &PlanePartRepository{db: db}
 }
 
-func (r *PlanePartRepository) Create(ctx context.Context, part *models.PlanePart) error {
+// Create inserts the part together with its opening ledger entry (when it
+// starts with non-zero usage) and, for an installed part, its first
+// installation record, all in one transaction.
+func (r *PlanePartRepository) Create(ctx context.Context, part *models.PlanePart, createdBy *int64) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Select(
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return createPart(tx, part, createdBy)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create plane part: %w", err)
+	}
+
+	return nil
+}
+
+func createPart(tx *gorm.DB, part *models.PlanePart, createdBy *int64) error {
+	if err := tx.Select(
 		"plane_id",
+		"catalog_part_id",
 		"part_name",
 		"serial_number",
 		"category",
 		"usage_hours",
 		"usage_limit_hours",
+		"usage_cycles",
+		"usage_limit_cycles",
+		"calendar_limit_days",
 		"installed_at",
-	).Create(part)
-	if result.Error != nil {
-		return fmt.Errorf("failed to create plane part: %w", result.Error)
+		"in_service_at",
+	).Create(part).Error; err != nil {
+		return err
 	}
 
-	return nil
+	if part.UsageHours != 0 || part.UsageCycles != 0 {
+		if err := tx.Create(&models.PartUsageEntry{
+			PartID:      part.ID,
+			DeltaHours:  part.UsageHours,
+			DeltaCycles: part.UsageCycles,
+			RecordedBy:  createdBy,
+			Source:      models.UsageSourceInitial,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	if part.PlaneID == nil {
+		return nil
+	}
+	return tx.Omit("Plane").Create(&models.PartInstallation{
+		PartID:          part.ID,
+		PlaneID:         *part.PlaneID,
+		InstalledAt:     part.InstalledAt,
+		InstalledBy:     createdBy,
+		HoursAtInstall:  part.UsageHours,
+		CyclesAtInstall: part.UsageCycles,
+	}).Error
 }
 
 func (r *PlanePartRepository) GetByID(ctx context.Context, id int64) (*models.PlanePart, error) {
@@ -43,7 +95,26 @@ func (r *PlanePartRepository) GetByID(ctx context.Context, id int64) (*models.Pl
 	defer cancel()
 
 	var part models.PlanePart
-	result := r.db.WithContext(ctx).First(&part, id)
+	result := conn(ctx, r.db).First(&part, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get plane part by id: %w", result.Error)
+	}
+
+	return &part, nil
+}
+
+// GetByIDForUpdate is GetByID that also locks the part's row until the
+// transaction in ctx ends, so its usage totals cannot change underneath the
+// caller.
+func (r *PlanePartRepository) GetByIDForUpdate(ctx context.Context, id int64) (*models.PlanePart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var part models.PlanePart
+	result := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&part, id)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -54,12 +125,60 @@ func (r *PlanePartRepository) GetByID(ctx context.Context, id int64) (*models.Pl
 	return &part, nil
 }
 
+// GetByIDWithDeleted is GetByID including soft-deleted parts.
+func (r *PlanePartRepository) GetByIDWithDeleted(ctx context.Context, id int64) (*models.PlanePart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var part models.PlanePart
+	result := conn(ctx, r.db).Unscoped().First(&part, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get plane part by id: %w", result.Error)
+	}
+
+	return &part, nil
+}
+
+func (r *PlanePartRepository) GetByIDs(ctx context.Context, ids []int64) ([]models.PlanePart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var parts []models.PlanePart
+	result := conn(ctx, r.db).Where("id IN ?", ids).Order("id").Find(&parts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get plane parts by ids: %w", result.Error)
+	}
+
+	return parts, nil
+}
+
 func (r *PlanePartRepository) GetBySerialNumber(ctx context.Context, serialNumber string) (*models.PlanePart, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var part models.PlanePart
-	result := r.db.WithContext(ctx).Where("serial_number = ?", serialNumber).First(&part)
+	result := conn(ctx, r.db).Where("serial_number = ?", serialNumber).First(&part)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get plane part by serial number: %w", result.Error)
+	}
+
+	return &part, nil
+}
+
+// GetBySerialNumberWithDeleted also finds soft-deleted parts, which still
+// hold their serial number.
+func (r *PlanePartRepository) GetBySerialNumberWithDeleted(ctx context.Context, serialNumber string) (*models.PlanePart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var part models.PlanePart
+	result := conn(ctx, r.db).Unscoped().Where("serial_number = ?", serialNumber).First(&part)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -70,12 +189,27 @@ func (r *PlanePartRepository) GetBySerialNumber(ctx context.Context, serialNumbe
 	return &part, nil
 }
 
+// GetBySerialNumbers includes soft-deleted parts, whose serial numbers are
+// still taken.
+func (r *PlanePartRepository) GetBySerialNumbers(ctx context.Context, serialNumbers []string) ([]models.PlanePart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var parts []models.PlanePart
+	result := conn(ctx, r.db).Unscoped().Where("serial_number IN ?", serialNumbers).Find(&parts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get plane parts by serial numbers: %w", result.Error)
+	}
+
+	return parts, nil
+}
+
 func (r *PlanePartRepository) GetByPlaneID(ctx context.Context, planeID int64) ([]models.PlanePart, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var parts []models.PlanePart
-	result := r.db.WithContext(ctx).Where("plane_id = ?", planeID).Order("id").Find(&parts)
+	result := conn(ctx, r.db).Where("plane_id = ?", planeID).Order("id").Find(&parts)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get plane parts by plane id: %w", result.Error)
 	}
@@ -88,7 +222,7 @@ func (r *PlanePartRepository) GetByCategory(ctx context.Context, category string
 	defer cancel()
 
 	var parts []models.PlanePart
-	result := r.db.WithContext(ctx).Where("category = ?", category).Order("id").Find(&parts)
+	result := conn(ctx, r.db).Where("category = ?", category).Order("id").Find(&parts)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get plane parts by category: %w", result.Error)
 	}
@@ -96,83 +230,239 @@ func (r *PlanePartRepository) GetByCategory(ctx context.Context, category string
 	return parts, nil
 }
 
-func (r *PlanePartRepository) GetByPlaneIDAndCategory(ctx context.Context, planeID int64, category string) ([]models.PlanePart, error) {
+// GetInstalled returns every installed part, optionally only on one plane.
+func (r *PlanePartRepository) GetInstalled(ctx context.Context, planeID *int64) ([]models.PlanePart, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	query := conn(ctx, r.db).Where("plane_id IS NOT NULL")
+	if planeID != nil {
+		query = query.Where("plane_id = ?", *planeID)
+	}
+
 	var parts []models.PlanePart
-	result := r.db.WithContext(ctx).
-		Where("plane_id = ? AND category = ?", planeID, category).
-		Order("id").
-		Find(&parts)
+	result := query.Order("id").Find(&parts)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get plane parts: %w", result.Error)
+		return nil, fmt.Errorf("failed to get installed plane parts: %w", result.Error)
 	}
 
 	return parts, nil
 }
 
-func (r *PlanePartRepository) GetNeedingMaintenance(ctx context.Context, thresholdPercent float64) ([]models.PlanePart, error) {
+// GetOverLimit returns the parts installed on the plane that have used more
+// than 100% of any life limit, most overrun first.
+func (r *PlanePartRepository) GetOverLimit(ctx context.Context, planeID int64) ([]models.PlanePart, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var parts []models.PlanePart
-	result := r.db.WithContext(ctx).
-		Where("usage_percent >= ?", thresholdPercent).
-		Order("usage_percent DESC").
+	result := conn(ctx, r.db).
+		Where("plane_id = ?", planeID).
+		Where(lifeUsedPercentSQL+" > ?", models.LifeLimitPercent).
+		Order(lifeUsedPercentSQL + " DESC, id").
 		Find(&parts)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get parts needing maintenance: %w", result.Error)
+		return nil, fmt.Errorf("failed to get parts over limit: %w", result.Error)
 	}
 
 	return parts, nil
 }
 
-func (r *PlanePartRepository) GetAll(ctx context.Context) ([]models.PlanePart, error) {
+// CountUsage buckets the live parts installed on each live plane by life
+// used. Planes with no installed parts are left out.
+func (r *PlanePartRepository) CountUsage(ctx context.Context) ([]models.PlanePartUsage, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	var usage []models.PlanePartUsage
+	result := conn(ctx, r.db).Model(&models.PlanePart{}).
+		Select(`plane_parts.plane_id,
+			COUNT(*) FILTER (WHERE `+lifeUsedPercentSQL+` >= 80) AS above80,
+			COUNT(*) FILTER (WHERE `+lifeUsedPercentSQL+` >= 90) AS above90,
+			COUNT(*) FILTER (WHERE `+lifeUsedPercentSQL+` > ?) AS over_limit`, models.LifeLimitPercent).
+		Joins("JOIN planes ON planes.id = plane_parts.plane_id AND planes.deleted_at IS NULL").
+		Group("plane_parts.plane_id").
+		Order("plane_parts.plane_id").
+		Scan(&usage)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to count part usage: %w", result.Error)
+	}
+
+	return usage, nil
+}
+
+var partSortColumns = map[string]string{
+	"id":            "id",
+	"serial_number": "serial_number",
+	"part_name":     "part_name",
+	"category":      "category",
+	"usage_hours":   "usage_hours",
+	"usage_cycles":  "usage_cycles",
+	"usage_percent": lifeUsedPercentSQL,
+	"installed_at":  "installed_at",
+}
+
+// filterParts applies the PartQuery filters shared by every part list.
+func filterParts(db *gorm.DB, query *models.PartQuery) *gorm.DB {
+	db = scopeDeleted(db, query.DeletedFilter)
+	if query.PlaneID != nil {
+		db = db.Where("plane_id = ?", *query.PlaneID)
+	}
+	if query.CatalogPartID != nil {
+		db = db.Where("catalog_part_id = ?", *query.CatalogPartID)
+	}
+	if query.Installed != nil {
+		if *query.Installed {
+			db = db.Where("plane_id IS NOT NULL")
+		} else {
+			db = db.Where("plane_id IS NULL")
+		}
+	}
+	if query.Category != "" {
+		db = db.Where("category = ?", query.Category)
+	}
+	if query.PartName != "" {
+		db = db.Where("part_name ILIKE ?", "%"+query.PartName+"%")
+	}
+	if query.MinUsageHours != nil {
+		db = db.Where("usage_hours >= ?", *query.MinUsageHours)
+	}
+	if query.MaxUsageHours != nil {
+		db = db.Where("usage_hours <= ?", *query.MaxUsageHours)
+	}
+	if query.MinUsagePercent != nil {
+		db = db.Where(lifeUsedPercentSQL+" >= ?", *query.MinUsagePercent)
+	}
+	if query.MaxUsagePercent != nil {
+		db = db.Where(lifeUsedPercentSQL+" <= ?", *query.MaxUsagePercent)
+	}
+	if query.InstalledFrom != nil {
+		db = db.Where("installed_at >= ?", *query.InstalledFrom)
+	}
+	if query.InstalledTo != nil {
+		db = db.Where("installed_at < ?", query.InstalledTo.AddDate(0, 0, 1))
+	}
+	return db
+}
+
+func (r *PlanePartRepository) List(ctx context.Context, query *models.PartQuery) ([]models.PlanePart, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := filterParts(conn(ctx, r.db).Model(&models.PlanePart{}), query)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count plane parts: %w", err)
+	}
+
 	var parts []models.PlanePart
-	result := r.db.WithContext(ctx).Order("id").Find(&parts)
+	result := db.
+		Order(orderBy(partSortColumns, query.Sort, query.Order, "id", models.SortAsc)).
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&parts)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get all plane parts: %w", result.Error)
+		return nil, 0, fmt.Errorf("failed to get plane parts: %w", result.Error)
 	}
 
-	return parts, nil
+	return parts, total, nil
 }
 
-func (r *PlanePartRepository) Update(ctx context.Context, part *models.PlanePart) error {
+func (r *PlanePartRepository) ListNeedingMaintenance(ctx context.Context, thresholdPercent float64, query *models.PartQuery) ([]models.PlanePart, int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Save(part)
+	db := needingMaintenance(conn(ctx, r.db).Model(&models.PlanePart{}), thresholdPercent, query)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count parts needing maintenance: %w", err)
+	}
+
+	var parts []models.PlanePart
+	result := db.
+		Order(orderBy(partSortColumns, query.Sort, query.Order, "usage_percent", models.SortDesc)).
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&parts)
 	if result.Error != nil {
-		return fmt.Errorf("failed to update plane part: %w", result.Error)
+		return nil, 0, fmt.Errorf("failed to get parts needing maintenance: %w", result.Error)
+	}
+
+	return parts, total, nil
+}
+
+// Stream passes every part matching query's filters and sort to fn,
+// ignoring pagination.
+func (r *PlanePartRepository) Stream(ctx context.Context, query *models.PartQuery, fn func(*models.PlanePart) error) error {
+	ctx, cancel := context.WithTimeout(ctx, StreamTimeout)
+	defer cancel()
+
+	db := filterParts(conn(ctx, r.db).Model(&models.PlanePart{}), query).
+		Order(orderBy(partSortColumns, query.Sort, query.Order, "id", models.SortAsc))
+	if err := streamRows(db, fn); err != nil {
+		return fmt.Errorf("failed to stream plane parts: %w", err)
+	}
+
+	return nil
+}
+
+// StreamNeedingMaintenance is the streaming form of ListNeedingMaintenance.
+func (r *PlanePartRepository) StreamNeedingMaintenance(ctx context.Context, thresholdPercent float64, query *models.PartQuery, fn func(*models.PlanePart) error) error {
+	ctx, cancel := context.WithTimeout(ctx, StreamTimeout)
+	defer cancel()
+
+	db := needingMaintenance(conn(ctx, r.db).Model(&models.PlanePart{}), thresholdPercent, query).
+		Order(orderBy(partSortColumns, query.Sort, query.Order, "usage_percent", models.SortDesc))
+	if err := streamRows(db, fn); err != nil {
+		return fmt.Errorf("failed to stream parts needing maintenance: %w", err)
 	}
 
 	return nil
 }
 
-func (r *PlanePartRepository) UpdateUsage(ctx context.Context, part *models.PlanePart) error {
+func needingMaintenance(db *gorm.DB, thresholdPercent float64, query *models.PartQuery) *gorm.DB {
+	return filterParts(db, query).Where(lifeUsedPercentSQL+" >= ?", thresholdPercent)
+}
+
+// Update writes the details an edit can change. Usage totals follow the
+// ledger, the plane and installed_at follow installations, extension hours
+// follow approved extensions and the in-service date never changes, so none
+// of them are written here. Deleted parts are left alone.
+func (r *PlanePartRepository) Update(ctx context.Context, part *models.PlanePart) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Model(part).
-		Updates(map[string]interface{}{
-			"usage_hours": part.UsageHours,
+	result := conn(ctx, r.db).Model(&models.PlanePart{}).
+		Where("id = ?", part.ID).
+		Updates(map[string]any{
+			"catalog_part_id":     part.CatalogPartID,
+			"part_name":           part.PartName,
+			"serial_number":       part.SerialNumber,
+			"category":            part.Category,
+			"usage_limit_hours":   part.UsageLimitHours,
+			"usage_limit_cycles":  part.UsageLimitCycles,
+			"calendar_limit_days": part.CalendarLimitDays,
 		})
 	if result.Error != nil {
-		return fmt.Errorf("failed to update usage hours: %w", result.Error)
+		return fmt.Errorf("failed to update plane part: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("plane part not found")
 	}
 
 	return nil
 }
 
+// Delete soft-deletes the part. It keeps its plane, so a restore puts it
+// back where it was.
 func (r *PlanePartRepository) Delete(ctx context.Context, id int64) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Delete(&models.PlanePart{}, id)
+	result := conn(ctx, r.db).Delete(&models.PlanePart{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete plane part: %w", result.Error)
 	}
@@ -184,12 +474,69 @@ func (r *PlanePartRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (r *PlanePartRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Unscoped().Model(&models.PlanePart{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore plane part: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("plane part not found")
+	}
+
+	return nil
+}
+
+// HasHistory reports whether anything has been recorded against the part:
+// usage entries, installations, work order items or limit extensions. The
+// foreign keys refuse to delete a part that has any.
+func (r *PlanePartRepository) HasHistory(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var exists bool
+	err := conn(ctx, r.db).Raw(`SELECT
+		EXISTS (SELECT 1 FROM part_usage_entries WHERE part_id = @id) OR
+		EXISTS (SELECT 1 FROM part_installations WHERE part_id = @id) OR
+		EXISTS (SELECT 1 FROM work_order_parts WHERE part_id = @id OR replacement_part_id = @id) OR
+		EXISTS (SELECT 1 FROM part_limit_extensions WHERE part_id = @id)`,
+		sql.Named("id", id)).Scan(&exists).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check plane part history: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Purge permanently removes a soft-deleted part. Callers check HasHistory
+// first; the foreign keys refuse the delete otherwise.
+func (r *PlanePartRepository) Purge(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.PlanePart{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge plane part: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("plane part not found")
+	}
+
+	return nil
+}
+
 func (r *PlanePartRepository) GetByPlaneIDWithDetails(ctx context.Context, planeID int64) ([]models.PlanePart, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var parts []models.PlanePart
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Plane").
 		Where("plane_id = ?", planeID).
 		Order("id").
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/JasperRosales/aircraft-system-be/internal/models"
 )
@@ -22,7 +24,7 @@ func (r *PlaneRepository) Create(ctx context.Context, plane *models.Plane) error
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Create(plane)
+	result := conn(ctx, r.db).Create(plane)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create plane: %w", result.Error)
 	}
@@ -35,7 +37,42 @@ func (r *PlaneRepository) GetByID(ctx context.Context, id int64) (*models.Plane,
 	defer cancel()
 
 	var plane models.Plane
-	result := r.db.WithContext(ctx).First(&plane, id)
+	result := conn(ctx, r.db).First(&plane, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get plane by id: %w", result.Error)
+	}
+
+	return &plane, nil
+}
+
+// GetByIDForUpdate is GetByID that also locks the plane's row until the
+// transaction in ctx ends, so its status cannot change underneath the caller.
+func (r *PlaneRepository) GetByIDForUpdate(ctx context.Context, id int64) (*models.Plane, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var plane models.Plane
+	result := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&plane, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get plane by id: %w", result.Error)
+	}
+
+	return &plane, nil
+}
+
+// GetByIDWithDeleted is GetByID including soft-deleted planes.
+func (r *PlaneRepository) GetByIDWithDeleted(ctx context.Context, id int64) (*models.Plane, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var plane models.Plane
+	result := conn(ctx, r.db).Unscoped().First(&plane, id)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -51,7 +88,25 @@ func (r *PlaneRepository) GetByTailNumber(ctx context.Context, tailNumber string
 	defer cancel()
 
 	var plane models.Plane
-	result := r.db.WithContext(ctx).Where("tail_number = ?", tailNumber).First(&plane)
+	result := conn(ctx, r.db).Where("tail_number = ?", tailNumber).First(&plane)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get plane by tail number: %w", result.Error)
+	}
+
+	return &plane, nil
+}
+
+// GetByTailNumberWithDeleted also finds soft-deleted planes, which still
+// hold their tail number.
+func (r *PlaneRepository) GetByTailNumberWithDeleted(ctx context.Context, tailNumber string) (*models.Plane, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var plane models.Plane
+	result := conn(ctx, r.db).Unscoped().Where("tail_number = ?", tailNumber).First(&plane)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -62,38 +117,291 @@ func (r *PlaneRepository) GetByTailNumber(ctx context.Context, tailNumber string
 	return &plane, nil
 }
 
-func (r *PlaneRepository) GetAll(ctx context.Context) ([]models.Plane, error) {
+// GetByTailNumbers includes soft-deleted planes, so callers can tell a taken
+// tail number from a usable one.
+func (r *PlaneRepository) GetByTailNumbers(ctx context.Context, tailNumbers []string) ([]models.Plane, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	var planes []models.Plane
-	result := r.db.WithContext(ctx).Order("id").Find(&planes)
+	result := conn(ctx, r.db).Unscoped().Where("tail_number IN ?", tailNumbers).Find(&planes)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get all planes: %w", result.Error)
+		return nil, fmt.Errorf("failed to get planes by tail numbers: %w", result.Error)
 	}
 
 	return planes, nil
 }
 
+var planeSortColumns = map[string]string{
+	"id":          "id",
+	"tail_number": "tail_number",
+	"model":       "model",
+	"status":      "status",
+	"created_at":  "created_at",
+}
+
+func (r *PlaneRepository) List(ctx context.Context, query *models.PlaneQuery) ([]models.Plane, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := filterPlanes(conn(ctx, r.db).Model(&models.Plane{}), query)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count planes: %w", err)
+	}
+
+	var planes []models.Plane
+	result := db.
+		Order(orderBy(planeSortColumns, query.Sort, query.Order, "id", models.SortAsc)).
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&planes)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get planes: %w", result.Error)
+	}
+
+	return planes, total, nil
+}
+
+// Stream passes every plane matching query's filters and sort to fn,
+// ignoring pagination.
+func (r *PlaneRepository) Stream(ctx context.Context, query *models.PlaneQuery, fn func(*models.Plane) error) error {
+	ctx, cancel := context.WithTimeout(ctx, StreamTimeout)
+	defer cancel()
+
+	db := filterPlanes(conn(ctx, r.db).Model(&models.Plane{}), query).
+		Order(orderBy(planeSortColumns, query.Sort, query.Order, "id", models.SortAsc))
+	if err := streamRows(db, fn); err != nil {
+		return fmt.Errorf("failed to stream planes: %w", err)
+	}
+
+	return nil
+}
+
+// filterPlanes applies the PlaneQuery filters shared by the list and export.
+func filterPlanes(db *gorm.DB, query *models.PlaneQuery) *gorm.DB {
+	db = scopeDeleted(db, query.DeletedFilter)
+	if query.Model != "" {
+		db = db.Where("model ILIKE ?", "%"+query.Model+"%")
+	}
+	if query.TailNumber != "" {
+		db = db.Where("tail_number ILIKE ?", "%"+query.TailNumber+"%")
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	return db
+}
+
+// Update saves the plane's details. Status only changes through
+// ChangeStatus, so it is left alone here, as are deleted planes.
 func (r *PlaneRepository) Update(ctx context.Context, plane *models.Plane) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Save(plane)
+	result := conn(ctx, r.db).Model(&models.Plane{}).
+		Where("id = ?", plane.ID).
+		Updates(map[string]any{
+			"tail_number": plane.TailNumber,
+			"model":       plane.Model,
+		})
 	if result.Error != nil {
 		return fmt.Errorf("failed to update plane: %w", result.Error)
 	}
 
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("plane not found")
+	}
+
 	return nil
 }
 
-func (r *PlaneRepository) Delete(ctx context.Context, id int64) error {
+// ChangeStatus moves the plane from change.FromStatus to change.ToStatus and
+// records the change in its history, in one transaction. It returns false
+// without changing anything if the plane is no longer in FromStatus.
+func (r *PlaneRepository) ChangeStatus(ctx context.Context, change *models.PlaneStatusChange) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	changed := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Plane{}).
+			Where("id = ? AND status = ?", change.PlaneID, change.FromStatus).
+			Update("status", change.ToStatus)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		changed = true
+		return tx.Create(change).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to change plane status: %w", err)
+	}
+
+	return changed, nil
+}
+
+func (r *PlaneRepository) GetStatusChanges(ctx context.Context, planeID int64, offset, limit int) ([]models.PlaneStatusChange, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var total int64
+	query := conn(ctx, r.db).Model(&models.PlaneStatusChange{}).Where("plane_id = ?", planeID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count plane status changes: %w", err)
+	}
+
+	var changes []models.PlaneStatusChange
+	result := query.Order("changed_at DESC, id DESC").Offset(offset).Limit(limit).Find(&changes)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get plane status changes: %w", result.Error)
+	}
+
+	return changes, total, nil
+}
+
+// CountPartsOverLimit counts the parts installed on the plane that have used
+// more than 100% of any life limit.
+func (r *PlaneRepository) CountPartsOverLimit(ctx context.Context, planeID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	result := conn(ctx, r.db).Model(&models.PlanePart{}).
+		Where("plane_id = ?", planeID).
+		Where(lifeUsedPercentSQL+" > ?", models.LifeLimitPercent).
+		Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count parts over limit: %w", result.Error)
+	}
+
+	return count, nil
+}
+
+// CountByStatus counts live planes per status. Statuses without planes are
+// left out.
+func (r *PlaneRepository) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	result := conn(ctx, r.db).Model(&models.Plane{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to count planes by status: %w", result.Error)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// Delete soft-deletes the plane together with the parts installed on it,
+// stamping them all with the same deleted_at so Restore can bring the same
+// parts back. It returns the parts that were deleted.
+func (r *PlaneRepository) Delete(ctx context.Context, id int64) ([]models.PlanePart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now().Truncate(time.Microsecond)
+	var parts []models.PlanePart
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Plane{}).Where("id = ?", id).Update("deleted_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("plane not found")
+		}
+
+		return tx.Model(&parts).
+			Clauses(clause.Returning{}).
+			Where("plane_id = ?", id).
+			Update("deleted_at", now).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete plane: %w", err)
+	}
+
+	return parts, nil
+}
+
+// Restore undeletes a soft-deleted plane and the parts that were deleted
+// with it. Parts deleted on their own beforehand stay deleted. It returns
+// the parts that were restored.
+func (r *PlaneRepository) Restore(ctx context.Context, id int64) ([]models.PlanePart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var parts []models.PlanePart
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&parts).
+			Clauses(clause.Returning{}).
+			Where("plane_id = ? AND deleted_at = (SELECT deleted_at FROM planes WHERE id = ?)", id, id).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Model(&models.Plane{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("plane not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore plane: %w", err)
+	}
+
+	return parts, nil
+}
+
+// HasHistory reports whether anything has been recorded against the plane:
+// parts, deleted or not, flights, installations or status changes. The
+// foreign keys refuse to delete a plane that has any.
+func (r *PlaneRepository) HasHistory(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var exists bool
+	err := conn(ctx, r.db).Raw(`SELECT
+		EXISTS (SELECT 1 FROM plane_parts WHERE plane_id = @id) OR
+		EXISTS (SELECT 1 FROM flights WHERE plane_id = @id) OR
+		EXISTS (SELECT 1 FROM part_installations WHERE plane_id = @id) OR
+		EXISTS (SELECT 1 FROM plane_status_changes WHERE plane_id = @id)`,
+		sql.Named("id", id)).Scan(&exists).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check plane history: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Purge permanently removes a soft-deleted plane. Callers check HasHistory
+// first; the foreign keys refuse the delete otherwise.
+func (r *PlaneRepository) Purge(ctx context.Context, id int64) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Delete(&models.Plane{}, id)
+	result := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.Plane{}, id)
 	if result.Error != nil {
-		return fmt.Errorf("failed to delete plane: %w", result.Error)
+		return fmt.Errorf("failed to purge plane: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
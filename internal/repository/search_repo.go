@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+// Planes and parts are ranked by trigram similarity, with a boost for exact
+// and prefix identifier matches so "N123" puts N123AB above N9123. Part
+// names and categories also match by full-text word.
+const (
+	planeSearchSQL = `
+SELECT 'plane' AS type, id, tail_number AS title, model AS subtitle,
+	NULL::varchar AS category, id AS plane_id,
+	GREATEST(similarity(tail_number, @q), similarity(model, @q))
+		+ CASE WHEN lower(tail_number) = lower(@q) THEN 1
+			WHEN tail_number ILIKE @prefix THEN 0.5 ELSE 0 END AS score
+FROM planes
+WHERE deleted_at IS NULL AND (tail_number ILIKE @contains OR model ILIKE @contains
+	OR tail_number % @q OR model % @q)`
+
+	partSearchSQL = `
+SELECT 'part' AS type, id, serial_number AS title, part_name AS subtitle,
+	category, plane_id,
+	GREATEST(similarity(serial_number, @q), similarity(part_name, @q), similarity(category, @q),
+		ts_rank(to_tsvector('simple', part_name || ' ' || category), plainto_tsquery('simple', @q)))
+		+ CASE WHEN lower(serial_number) = lower(@q) THEN 1
+			WHEN serial_number ILIKE @prefix THEN 0.5 ELSE 0 END AS score
+FROM plane_parts
+WHERE deleted_at IS NULL AND (serial_number ILIKE @contains OR part_name ILIKE @contains OR category ILIKE @contains
+	OR serial_number % @q OR part_name % @q
+	OR to_tsvector('simple', part_name || ' ' || category) @@ plainto_tsquery('simple', @q))`
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search returns one page of planes and/or parts matching term, best match
+// first, and the total number of matches.
+func (r *SearchRepository) Search(ctx context.Context, term, resultType string, offset, limit int) ([]models.SearchResult, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var parts []string
+	if resultType == "" || resultType == models.SearchTypePlane {
+		parts = append(parts, planeSearchSQL)
+	}
+	if resultType == "" || resultType == models.SearchTypePart {
+		parts = append(parts, partSearchSQL)
+	}
+	union := strings.Join(parts, "\nUNION ALL\n")
+
+	escaped := likeEscaper.Replace(term)
+	args := map[string]interface{}{
+		"q":        term,
+		"contains": "%" + escaped + "%",
+		"prefix":   escaped + "%",
+		"offset":   offset,
+		"limit":    limit,
+	}
+
+	var total int64
+	if err := conn(ctx, r.db).Raw("SELECT COUNT(*) FROM ("+union+") AS results", args).Scan(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	var results []models.SearchResult
+	result := conn(ctx, r.db).
+		Raw("SELECT * FROM ("+union+") AS results ORDER BY score DESC, type, id OFFSET @offset LIMIT @limit", args).
+		Scan(&results)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to search: %w", result.Error)
+	}
+
+	return results, total, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// Create opens a session together with its first refresh token.
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session, token *models.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		token.SessionID = session.ID
+		return tx.Omit("Session").Create(token).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var token models.RefreshToken
+	result := conn(ctx, r.db).Preload("Session").Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", result.Error)
+	}
+
+	return &token, nil
+}
+
+// Rotate marks the old refresh token used and stores its replacement,
+// sliding the session's expiry forward. It returns false without writing
+// if the old token was already used, so of two concurrent refreshes only
+// one rotates and the other can be treated as reuse.
+func (r *SessionRepository) Rotate(ctx context.Context, oldTokenID int64, next *models.RefreshToken) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rotated := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var old models.RefreshToken
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&old, oldTokenID).Error; err != nil {
+			return err
+		}
+		if old.UsedAt != nil {
+			return nil
+		}
+
+		now := time.Now()
+		if err := tx.Model(&old).Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		next.SessionID = old.SessionID
+		if err := tx.Omit("Session").Create(next).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Session{}).Where("id = ?", old.SessionID).Updates(map[string]interface{}{
+			"expires_at":   next.ExpiresAt,
+			"last_used_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		rotated = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return rotated, nil
+}
+
+// IsActive reports whether the session exists and has neither been revoked
+// nor expired.
+func (r *SessionRepository) IsActive(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	result := conn(ctx, r.db).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, time.Now()).
+		Count(&count)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to check session: %w", result.Error)
+	}
+
+	return count > 0, nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id int64, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke session: %w", result.Error)
+	}
+
+	return nil
+}
+
+// RevokeAllForUser revokes every open session of the user and returns how
+// many were revoked.
+func (r *SessionRepository) RevokeAllForUser(ctx context.Context, userID int64, reason string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to revoke user sessions: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
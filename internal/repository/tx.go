@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// txState is the transaction a context carries, with the work queued to run
+// just before it commits.
+type txState struct {
+	db           *gorm.DB
+	beforeCommit []func(tx *gorm.DB) error
+}
+
+// Transactor runs several repository calls as one transaction. Repositories
+// take the transaction from the context, so their signatures don't change;
+// called with a context from InTx, they join it instead of using the pool.
+type Transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// InTx runs fn in a transaction and commits it when fn returns nil. Any
+// error from fn, or from work queued with beforeCommit, rolls everything
+// back and is returned. Called inside another InTx, fn joins the outer
+// transaction.
+func (t *Transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		state := &txState{db: tx}
+		if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+			return err
+		}
+		for _, hook := range state.beforeCommit {
+			if err := hook(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// conn returns the transaction ctx carries, or db when there is none.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.db.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// beforeCommit queues hook to run last in ctx's transaction. It reports
+// false, and queues nothing, when ctx carries no transaction.
+func beforeCommit(ctx context.Context, hook func(tx *gorm.DB) error) bool {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return false
+	}
+	state.beforeCommit = append(state.beforeCommit, hook)
+	return true
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type UserInviteRepository struct {
+	db *gorm.DB
+}
+
+func NewUserInviteRepository(db *gorm.DB) *UserInviteRepository {
+	return &UserInviteRepository{db: db}
+}
+
+func (r *UserInviteRepository) Create(ctx context.Context, invite *models.UserInvite) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Create(invite)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create user invite: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *UserInviteRepository) GetByID(ctx context.Context, id int64) (*models.UserInvite, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var invite models.UserInvite
+	result := conn(ctx, r.db).First(&invite, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user invite by id: %w", result.Error)
+	}
+
+	return &invite, nil
+}
+
+func (r *UserInviteRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.UserInvite, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var invite models.UserInvite
+	result := conn(ctx, r.db).Where("token_hash = ?", tokenHash).First(&invite)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user invite by token: %w", result.Error)
+	}
+
+	return &invite, nil
+}
+
+func (r *UserInviteRepository) GetAll(ctx context.Context) ([]models.UserInvite, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var invites []models.UserInvite
+	result := conn(ctx, r.db).Order("created_at DESC").Find(&invites)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user invites: %w", result.Error)
+	}
+
+	return invites, nil
+}
+
+// Redeem creates user with the invite's role and marks the invite used, so a
+// token can never create two accounts.
+func (r *UserInviteRepository) Redeem(ctx context.Context, inviteID int64, user *models.User) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var invite models.UserInvite
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invite, inviteID).Error; err != nil {
+			return err
+		}
+		if invite.RedeemedAt != nil || !invite.ExpiresAt.After(time.Now()) {
+			return fmt.Errorf("user invite is no longer valid")
+		}
+
+		user.Role = invite.Role
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&invite).Updates(map[string]interface{}{
+			"redeemed_by": user.ID,
+			"redeemed_at": time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to redeem user invite: %w", err)
+	}
+
+	return nil
+}
+
+// Delete revokes an invite that has not been redeemed yet.
+func (r *UserInviteRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Where("redeemed_at IS NULL").Delete(&models.UserInvite{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete user invite: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user invite not found")
+	}
+
+	return nil
+}
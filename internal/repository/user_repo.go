@@ -22,7 +22,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Create(user)
+	result := conn(ctx, r.db).Create(user)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create user: %w", result.Error)
 	}
@@ -35,7 +35,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 	defer cancel()
 
 	var user models.User
-	result := r.db.WithContext(ctx).First(&user, id)
+	result := conn(ctx, r.db).First(&user, id)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -51,7 +51,7 @@ func (r *UserRepository) GetByName(ctx context.Context, name string) (*models.Us
 	defer cancel()
 
 	var user models.User
-	result := r.db.WithContext(ctx).Where("name = ?", name).First(&user)
+	result := conn(ctx, r.db).Where("name = ?", name).First(&user)
 	if result.Error == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
@@ -62,36 +62,108 @@ func (r *UserRepository) GetByName(ctx context.Context, name string) (*models.Us
 	return &user, nil
 }
 
-func (r *UserRepository) GetAll(ctx context.Context) ([]models.User, error) {
+// GetByNameWithDeleted also finds soft-deleted users, whose names are still
+// taken.
+func (r *UserRepository) GetByNameWithDeleted(ctx context.Context, name string) (*models.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	var user models.User
+	result := conn(ctx, r.db).Unscoped().Where("name = ?", name).First(&user)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user by name: %w", result.Error)
+	}
+
+	return &user, nil
+}
+
+// GetByIDWithDeleted is GetByID including soft-deleted users.
+func (r *UserRepository) GetByIDWithDeleted(ctx context.Context, id int64) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var user models.User
+	result := conn(ctx, r.db).Unscoped().First(&user, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user by id: %w", result.Error)
+	}
+
+	return &user, nil
+}
+
+var userSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"role":       "role",
+	"created_at": "created_at",
+}
+
+func (r *UserRepository) List(ctx context.Context, query *models.UserQuery) ([]models.User, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	db := scopeDeleted(conn(ctx, r.db).Model(&models.User{}), query.DeletedFilter)
+	if query.Name != "" {
+		db = db.Where("name ILIKE ?", "%"+query.Name+"%")
+	}
+	if query.Role != "" {
+		db = db.Where("role = ?", query.Role)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
 	var users []models.User
-	result := r.db.WithContext(ctx).Order("id").Find(&users)
+	result := db.
+		Order(orderBy(userSortColumns, query.Sort, query.Order, "id", models.SortAsc)).
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&users)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get all users: %w", result.Error)
+		return nil, 0, fmt.Errorf("failed to get users: %w", result.Error)
 	}
 
-	return users, nil
+	return users, total, nil
 }
 
+// Update saves the user's name, password and role. Deleted users are left
+// alone.
 func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Save(user)
+	result := conn(ctx, r.db).Model(&models.User{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]any{
+			"name":     user.Name,
+			"password": user.Password,
+			"role":     user.Role,
+		})
 	if result.Error != nil {
 		return fmt.Errorf("failed to update user: %w", result.Error)
 	}
 
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
 	return nil
 }
 
+// Delete soft-deletes the user. Their name stays reserved until a purge.
 func (r *UserRepository) Delete(ctx context.Context, id int64) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Delete(&models.User{}, id)
+	result := conn(ctx, r.db).Delete(&models.User{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete user: %w", result.Error)
 	}
@@ -102,3 +174,39 @@ func (r *UserRepository) Delete(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+func (r *UserRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Unscoped().Model(&models.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// Purge permanently removes a soft-deleted user. Records they authored keep
+// a NULL author through the foreign keys.
+func (r *UserRepository) Purge(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.User{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
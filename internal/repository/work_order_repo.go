@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+type WorkOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkOrderRepository(db *gorm.DB) *WorkOrderRepository {
+	return &WorkOrderRepository{db: db}
+}
+
+func (r *WorkOrderRepository) Create(ctx context.Context, workOrder *models.WorkOrder) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Omit("Parts.Part").Create(workOrder)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create work order: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *WorkOrderRepository) GetByID(ctx context.Context, id int64) (*models.WorkOrder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var workOrder models.WorkOrder
+	result := conn(ctx, r.db).
+		Preload("Parts", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Parts.Part", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		First(&workOrder, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get work order by id: %w", result.Error)
+	}
+
+	return &workOrder, nil
+}
+
+// GetByIDForUpdate is GetByID that also locks the work order's row until the
+// transaction in ctx ends, so its status cannot change underneath the caller.
+func (r *WorkOrderRepository) GetByIDForUpdate(ctx context.Context, id int64) (*models.WorkOrder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var workOrder models.WorkOrder
+	result := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Parts", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Parts.Part", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		First(&workOrder, id)
+	if result.Error == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get work order by id: %w", result.Error)
+	}
+
+	return &workOrder, nil
+}
+
+func (r *WorkOrderRepository) List(ctx context.Context, status string, assignedTo *int64, offset, limit int) ([]models.WorkOrder, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := conn(ctx, r.db).Model(&models.WorkOrder{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if assignedTo != nil {
+		query = query.Where("assigned_to = ?", *assignedTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count work orders: %w", err)
+	}
+
+	var workOrders []models.WorkOrder
+	result := query.
+		Preload("Parts", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&workOrders)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to get work orders: %w", result.Error)
+	}
+
+	return workOrders, total, nil
+}
+
+// Assign sets the work order's mechanic. It reports false without changing
+// anything when the work order has moved past in progress.
+func (r *WorkOrderRepository) Assign(ctx context.Context, workOrder *models.WorkOrder) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(workOrder).Omit(clause.Associations).
+		Where("status IN ?", []string{models.WorkOrderStatusOpen, models.WorkOrderStatusInProgress}).
+		Updates(map[string]interface{}{
+			"assigned_to": workOrder.AssignedTo,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to assign work order: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ChangeStatus writes the work order's status and the sign-off and close
+// stamps that go with it. It reports false without changing anything when
+// the work order is no longer in fromStatus.
+func (r *WorkOrderRepository) ChangeStatus(ctx context.Context, workOrder *models.WorkOrder, fromStatus string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	changed, err := changeWorkOrderStatus(conn(ctx, r.db), workOrder, fromStatus)
+	if err != nil {
+		return false, fmt.Errorf("failed to change work order status: %w", err)
+	}
+
+	return changed, nil
+}
+
+func changeWorkOrderStatus(tx *gorm.DB, workOrder *models.WorkOrder, fromStatus string) (bool, error) {
+	result := tx.Model(workOrder).Omit(clause.Associations).
+		Where("status = ?", fromStatus).
+		Updates(map[string]interface{}{
+			"status":        workOrder.Status,
+			"signed_off_by": workOrder.SignedOffBy,
+			"signed_off_at": workOrder.SignedOffAt,
+			"closed_at":     workOrder.ClosedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SignOff marks the work order signed off and applies the action recorded on
+// each of its parts, all in one transaction. A reset appends a ledger entry
+// that brings the part's hours and cycles back to zero. A replacement removes
+// the part from its plane and installs the matching entry of replacements,
+// keyed by the removed part's ID, in its place. It reports false without
+// changing anything when the work order is no longer in progress.
+func (r *WorkOrderRepository) SignOff(ctx context.Context, workOrder *models.WorkOrder, replacements map[int64]*models.PlanePart, signedOffBy *int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	signedOff := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		changed, err := changeWorkOrderStatus(tx, workOrder, models.WorkOrderStatusInProgress)
+		if err != nil || !changed {
+			return err
+		}
+		signedOff = true
+
+		for i := range workOrder.Parts {
+			item := &workOrder.Parts[i]
+
+			var part models.PlanePart
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&part, item.PartID).Error; err != nil {
+				return err
+			}
+
+			switch *item.Action {
+			case models.WorkOrderActionReset:
+				if part.UsageHours == 0 && part.UsageCycles == 0 {
+					break
+				}
+				if err := tx.Create(&models.PartUsageEntry{
+					PartID:      part.ID,
+					DeltaHours:  -part.UsageHours,
+					DeltaCycles: -part.UsageCycles,
+					RecordedBy:  signedOffBy,
+					Source:      models.UsageSourceWorkOrder,
+				}).Error; err != nil {
+					return err
+				}
+				if _, err := syncUsage(tx, part.ID); err != nil {
+					return err
+				}
+
+			case models.WorkOrderActionReplace:
+				replacement := replacements[part.ID]
+				planeID := part.PlaneID
+				if planeID != nil {
+					reason := fmt.Sprintf("replaced under work order #%d", workOrder.ID)
+					if err := removePart(tx, &part, signedOffBy, &reason); err != nil {
+						return err
+					}
+				}
+
+				replacement.PlaneID = planeID
+				if err := createPart(tx, replacement, signedOffBy); err != nil {
+					return err
+				}
+				item.ReplacementPartID = &replacement.ID
+			}
+
+			if err := tx.Model(item).Omit(clause.Associations).Updates(map[string]interface{}{
+				"action":              item.Action,
+				"replacement_part_id": item.ReplacementPartID,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to sign off work order: %w", err)
+	}
+
+	return signedOff, nil
+}
@@ -0,0 +1,28 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupAircraftModelRoutes registers the aircraft model registry. Every role
+// can read it; only admins maintain it and scaffold planes from it.
+func SetupAircraftModelRoutes(router *gin.RouterGroup, aircraftModelCtrl *controller.AircraftModelController, auth middleware.TokenValidator, logger *util.Logger) {
+	admin := middleware.RoleMiddleware(logger, models.RoleAdmin)
+
+	// Protected routes (authentication required)
+	aircraftModels := router.Group("/aircraft-models")
+	aircraftModels.Use(middleware.AuthMiddleware(logger, auth))
+	{
+		aircraftModels.GET("", aircraftModelCtrl.ListAircraftModels)
+		aircraftModels.GET("/:id", aircraftModelCtrl.GetAircraftModel)
+		aircraftModels.POST("", admin, aircraftModelCtrl.CreateAircraftModel)
+		aircraftModels.PUT("/:id", admin, aircraftModelCtrl.UpdateAircraftModel)
+		aircraftModels.DELETE("/:id", admin, aircraftModelCtrl.DeleteAircraftModel)
+		aircraftModels.POST("/:id/planes", admin, aircraftModelCtrl.ScaffoldPlane)
+	}
+}
@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupAirworthinessRoutes registers the airworthiness report. Every
+// authenticated role can check whether a plane is cleared to fly.
+func SetupAirworthinessRoutes(router *gin.RouterGroup, airworthinessCtrl *controller.AirworthinessController, auth middleware.TokenValidator, logger *util.Logger) {
+	// Protected routes (authentication required)
+	planes := router.Group("/planes")
+	planes.Use(middleware.AuthMiddleware(logger, auth))
+	{
+		planes.GET("/:id/airworthiness", airworthinessCtrl.GetPlaneAirworthiness)
+	}
+}
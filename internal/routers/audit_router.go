@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupAuditRoutes registers the audit log, which only admins can read.
+func SetupAuditRoutes(router *gin.RouterGroup, auditCtrl *controller.AuditController, auth middleware.TokenValidator, logger *util.Logger) {
+	// Protected routes (authentication required)
+	audit := router.Group("/audit")
+	audit.Use(middleware.AuthMiddleware(logger, auth), middleware.RoleMiddleware(logger, models.RoleAdmin))
+	{
+		audit.GET("", auditCtrl.GetAuditLog)
+		audit.GET("/verify", auditCtrl.VerifyAuditLog)
+	}
+}
@@ -0,0 +1,27 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupCatalogRoutes registers the part catalog. Every role can browse it;
+// only admins maintain it.
+func SetupCatalogRoutes(router *gin.RouterGroup, catalogCtrl *controller.CatalogController, auth middleware.TokenValidator, logger *util.Logger) {
+	admin := middleware.RoleMiddleware(logger, models.RoleAdmin)
+
+	// Protected routes (authentication required)
+	catalog := router.Group("/catalog")
+	catalog.Use(middleware.AuthMiddleware(logger, auth))
+	{
+		catalog.GET("", catalogCtrl.ListCatalogParts)
+		catalog.GET("/:id", catalogCtrl.GetCatalogPart)
+		catalog.POST("", admin, catalogCtrl.CreateCatalogPart)
+		catalog.PUT("/:id", admin, catalogCtrl.UpdateCatalogPart)
+		catalog.DELETE("/:id", admin, catalogCtrl.DeleteCatalogPart)
+	}
+}
@@ -0,0 +1,22 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupExportRoutes registers the CSV and NDJSON exports. They mirror the
+// list endpoints, so every authenticated role can use them.
+func SetupExportRoutes(router *gin.RouterGroup, exportCtrl *controller.ExportController, auth middleware.TokenValidator, logger *util.Logger) {
+	// Protected routes (authentication required)
+	planes := router.Group("/planes")
+	planes.Use(middleware.AuthMiddleware(logger, auth))
+	{
+		planes.GET("/export", exportCtrl.ExportPlanes)
+		planes.GET("/parts/export", exportCtrl.ExportParts)
+		planes.GET("/maintenance/alerts/export", exportCtrl.ExportMaintenanceAlerts)
+	}
+}
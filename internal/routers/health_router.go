@@ -0,0 +1,16 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+)
+
+// SetupHealthRoutes registers the liveness and readiness probes. They are
+// public so orchestrators can call them without a token. /ping is kept as an
+// alias of /healthz for existing callers.
+func SetupHealthRoutes(router *gin.RouterGroup, healthCtrl *controller.HealthController) {
+	router.GET("/healthz", healthCtrl.Healthz)
+	router.GET("/ping", healthCtrl.Healthz)
+	router.GET("/readyz", healthCtrl.Readyz)
+}
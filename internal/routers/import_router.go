@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupImportRoutes registers bulk fleet import, which is limited to admins
+// like the single-record create routes.
+func SetupImportRoutes(router *gin.RouterGroup, importCtrl *controller.ImportController, auth middleware.TokenValidator, logger *util.Logger) {
+	// Protected routes (authentication required)
+	planes := router.Group("/planes")
+	planes.Use(middleware.AuthMiddleware(logger, auth), middleware.RoleMiddleware(logger, models.RoleAdmin))
+	{
+		planes.POST("/import", importCtrl.Import)
+	}
+}
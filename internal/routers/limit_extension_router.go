@@ -0,0 +1,30 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupLimitExtensionRoutes registers life-limit extension routes. Mechanics
+// request extensions; admins approve or reject them.
+func SetupLimitExtensionRoutes(router *gin.RouterGroup, extensionCtrl *controller.LimitExtensionController, auth middleware.TokenValidator, logger *util.Logger) {
+	mechanic := middleware.RoleMiddleware(logger, models.RoleMechanic)
+	admin := middleware.RoleMiddleware(logger, models.RoleAdmin)
+
+	// Protected routes (authentication required)
+	planes := router.Group("/planes")
+	planes.Use(middleware.AuthMiddleware(logger, auth))
+	{
+		planes.POST("/parts/:partId/extensions", mechanic, extensionCtrl.RequestExtension)
+		planes.GET("/parts/:partId/extensions", extensionCtrl.GetPartExtensions)
+
+		planes.GET("/extensions", extensionCtrl.ListExtensions)
+		planes.GET("/extensions/:extensionId", extensionCtrl.GetExtension)
+		planes.POST("/extensions/:extensionId/approve", admin, extensionCtrl.ApproveExtension)
+		planes.POST("/extensions/:extensionId/reject", admin, extensionCtrl.RejectExtension)
+	}
+}
@@ -5,34 +5,59 @@ import (
 
 	"github.com/JasperRosales/aircraft-system-be/internal/controller"
 	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
-	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
 	"github.com/JasperRosales/aircraft-system-be/internal/util"
 )
 
-func SetupPlaneRoutes(router *gin.RouterGroup, planeCtrl *controller.PlaneController, planePartCtrl *controller.PlanePartController, jwtSvc *service.JWTService, logger *util.Logger) {
+// SetupPlaneRoutes registers plane, part and flight routes. Every
+// authenticated role can read; mechanics record usage, flights, part moves
+// and plane status; only admins change the fleet and part definitions.
+func SetupPlaneRoutes(router *gin.RouterGroup, planeCtrl *controller.PlaneController, planePartCtrl *controller.PlanePartController, flightCtrl *controller.FlightController, forecastCtrl *controller.ForecastController, auth middleware.TokenValidator, logger *util.Logger) {
+	mechanic := middleware.RoleMiddleware(logger, models.RoleMechanic)
+	admin := middleware.RoleMiddleware(logger, models.RoleAdmin)
+
 	// Protected routes (authentication required)
 	planes := router.Group("/planes")
-	planes.Use(middleware.AuthMiddleware(logger, jwtSvc))
+	planes.Use(middleware.AuthMiddleware(logger, auth))
 	{
 		// Plane CRUD
-		planes.POST("", planeCtrl.CreatePlane)
+		planes.POST("", admin, planeCtrl.CreatePlane)
 		planes.GET("", planeCtrl.GetAllPlanes)
 		planes.GET("/:id", planeCtrl.GetPlane)
 		planes.GET("/tail/:tail_number", planeCtrl.GetPlaneByTailNumber)
-		planes.PUT("/:id", planeCtrl.UpdatePlane)
-		planes.DELETE("/:id", planeCtrl.DeletePlane)
+		planes.PUT("/:id", admin, planeCtrl.UpdatePlane)
+		planes.PUT("/:id/status", mechanic, planeCtrl.UpdatePlaneStatus)
+		planes.GET("/:id/status/history", planeCtrl.GetPlaneStatusHistory)
+		planes.DELETE("/:id", admin, planeCtrl.DeletePlane)
+		planes.POST("/:id/restore", admin, planeCtrl.RestorePlane)
+		planes.DELETE("/:id/purge", admin, planeCtrl.PurgePlane)
 		planes.GET("/:id/with-parts", planeCtrl.GetPlaneWithParts)
 
 		// Plane Parts
-		planes.POST("/:id/parts", planePartCtrl.AddPart)
+		planes.POST("/:id/parts", admin, planePartCtrl.AddPart)
 		planes.GET("/:id/parts", planePartCtrl.GetPartsByPlane)
 		planes.GET("/parts", planePartCtrl.GetAllParts)
+		planes.GET("/parts/spares", planePartCtrl.GetSpareParts)
+		planes.GET("/parts/serial/:serial/history", planePartCtrl.GetPartHistoryBySerial)
 		planes.GET("/parts/:partId", planePartCtrl.GetPart)
-		planes.PUT("/parts/:partId", planePartCtrl.UpdatePart)
-		planes.PUT("/parts/:partId/usage", planePartCtrl.UpdatePartUsage)
-		planes.DELETE("/parts/:partId", planePartCtrl.DeletePart)
+		planes.PUT("/parts/:partId", admin, planePartCtrl.UpdatePart)
+		planes.PUT("/parts/:partId/usage", mechanic, planePartCtrl.UpdatePartUsage)
+		planes.GET("/parts/:partId/usage/history", planePartCtrl.GetPartUsageHistory)
+		planes.POST("/parts/:partId/usage/history", mechanic, planePartCtrl.LogPartUsage)
+		planes.DELETE("/parts/:partId", admin, planePartCtrl.DeletePart)
+		planes.POST("/parts/:partId/restore", admin, planePartCtrl.RestorePart)
+		planes.DELETE("/parts/:partId/purge", admin, planePartCtrl.PurgePart)
+		planes.POST("/parts/:partId/install", mechanic, planePartCtrl.InstallPart)
+		planes.POST("/parts/:partId/remove", mechanic, planePartCtrl.RemovePart)
+
+		// Flights
+		planes.POST("/:id/flights", mechanic, flightCtrl.RecordFlight)
+		planes.GET("/:id/flights", flightCtrl.GetFlightsByPlane)
+		planes.GET("/flights/:flightId", flightCtrl.GetFlight)
 
 		// Maintenance Monitoring
 		planes.GET("/maintenance/alerts", planePartCtrl.GetPartsNeedingMaintenance)
+		planes.GET("/maintenance/forecast", forecastCtrl.GetFleetForecast)
+		planes.GET("/:id/forecast", forecastCtrl.GetPlaneForecast)
 	}
 }
@@ -0,0 +1,19 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupSearchRoutes registers fleet search, open to every authenticated role.
+func SetupSearchRoutes(router *gin.RouterGroup, searchCtrl *controller.SearchController, auth middleware.TokenValidator, logger *util.Logger) {
+	// Protected routes (authentication required)
+	search := router.Group("/search")
+	search.Use(middleware.AuthMiddleware(logger, auth))
+	{
+		search.GET("", searchCtrl.Search)
+	}
+}
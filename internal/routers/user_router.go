@@ -5,25 +5,40 @@ import (
 
 	"github.com/JasperRosales/aircraft-system-be/internal/controller"
 	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
-	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
 	"github.com/JasperRosales/aircraft-system-be/internal/util"
 )
 
-func SetupUserRoutes(router *gin.RouterGroup, userCtrl *controller.UserController, jwtSvc *service.JWTService, logger *util.Logger) {
+// SetupUserRoutes registers user routes. Users may read and update their own
+// account (UserService enforces this); listing and deleting accounts and
+// managing invites is admin-only.
+func SetupUserRoutes(router *gin.RouterGroup, userCtrl *controller.UserController, auth middleware.TokenValidator, logger *util.Logger) {
+	admin := middleware.RoleMiddleware(logger, models.RoleAdmin)
+
 	// Public routes (no authentication required)
 	users := router.Group("/users")
 	users.POST("/register", userCtrl.Register)
 	users.POST("/login", userCtrl.Login)
+	users.POST("/refresh", userCtrl.Refresh)
 	users.POST("/logout", userCtrl.Logout)
 
 	// Protected routes (authentication required)
 	protected := users.Group("")
-	protected.Use(middleware.AuthMiddleware(logger, jwtSvc))
+	protected.Use(middleware.AuthMiddleware(logger, auth))
 	{
 		protected.GET("/me", userCtrl.GetMe)
+
+		// Invites
+		protected.POST("/invites", admin, userCtrl.CreateInvite)
+		protected.GET("/invites", admin, userCtrl.GetInvites)
+		protected.DELETE("/invites/:id", admin, userCtrl.RevokeInvite)
+
 		protected.GET("/:id", userCtrl.GetByID)
-		protected.GET("", userCtrl.GetAll)
+		protected.GET("", admin, userCtrl.GetAll)
 		protected.PUT("/:id", userCtrl.Update)
-		protected.DELETE("/:id", userCtrl.Delete)
+		protected.DELETE("/:id", admin, userCtrl.Delete)
+		protected.POST("/:id/restore", admin, userCtrl.Restore)
+		protected.DELETE("/:id/purge", admin, userCtrl.Purge)
+		protected.DELETE("/:id/sessions", userCtrl.RevokeSessions)
 	}
 }
@@ -0,0 +1,32 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// SetupWorkOrderRoutes registers work order routes. Mechanics raise, start
+// and sign off work orders; admins assign and close them.
+func SetupWorkOrderRoutes(router *gin.RouterGroup, workOrderCtrl *controller.WorkOrderController, auth middleware.TokenValidator, logger *util.Logger) {
+	mechanic := middleware.RoleMiddleware(logger, models.RoleMechanic)
+	admin := middleware.RoleMiddleware(logger, models.RoleAdmin)
+
+	// Protected routes (authentication required)
+	workOrders := router.Group("/work-orders")
+	workOrders.Use(middleware.AuthMiddleware(logger, auth))
+	{
+		workOrders.POST("", mechanic, workOrderCtrl.CreateWorkOrder)
+		workOrders.GET("", workOrderCtrl.ListWorkOrders)
+		workOrders.GET("/:id", workOrderCtrl.GetWorkOrder)
+
+		// Workflow
+		workOrders.POST("/:id/assign", admin, workOrderCtrl.AssignWorkOrder)
+		workOrders.POST("/:id/start", mechanic, workOrderCtrl.StartWorkOrder)
+		workOrders.POST("/:id/sign-off", mechanic, workOrderCtrl.SignOffWorkOrder)
+		workOrders.POST("/:id/close", admin, workOrderCtrl.CloseWorkOrder)
+	}
+}
@@ -0,0 +1,508 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const (
+	AircraftModelNotFoundErr = "aircraft model not found"
+	AircraftModelExistsErr   = "aircraft model with this name already exists"
+	AircraftModelInUseErr    = "aircraft model is used by planes"
+	SlotNameErr              = "slot names must be unique within an aircraft model"
+	SlotNotFoundErr          = "slot not found in aircraft model"
+	SlotOverfilledErr        = "more parts than the slot requires"
+	SlotLimitRequiredErr     = "usage_limit_hours is required for slots without a catalog part"
+	SerialNumberRepeatedErr  = "serial number is repeated in the request"
+)
+
+type AircraftModelService struct {
+	tx                *repository.Transactor
+	aircraftModelRepo *repository.AircraftModelRepository
+	planeRepo         *repository.PlaneRepository
+	planePartRepo     *repository.PlanePartRepository
+	catalogRepo       *repository.CatalogPartRepository
+	importRepo        *repository.ImportRepository
+	airworthiness     *AirworthinessService
+	audit             *AuditService
+	logger            *util.Logger
+}
+
+func NewAircraftModelService(tx *repository.Transactor, aircraftModelRepo *repository.AircraftModelRepository, planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, catalogRepo *repository.CatalogPartRepository, importRepo *repository.ImportRepository, airworthiness *AirworthinessService, audit *AuditService, logger *util.Logger) *AircraftModelService {
+	return &AircraftModelService{
+		tx:                tx,
+		aircraftModelRepo: aircraftModelRepo,
+		planeRepo:         planeRepo,
+		planePartRepo:     planePartRepo,
+		catalogRepo:       catalogRepo,
+		importRepo:        importRepo,
+		airworthiness:     airworthiness,
+		audit:             audit,
+		logger:            logger,
+	}
+}
+
+func (s *AircraftModelService) CreateAircraftModel(ctx context.Context, req *models.CreateAircraftModelRequest) (*models.AircraftModelResponse, error) {
+	s.logger.InfoContext(ctx, "AircraftModelService: Creating aircraft model",
+		"name", req.Name,
+		"manufacturer", req.Manufacturer,
+	)
+
+	existing, err := s.aircraftModelRepo.GetByName(ctx, req.Name)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to check existing aircraft model",
+			"name", req.Name,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to check existing aircraft model: %w", err)
+	}
+	if existing != nil {
+		s.logger.WarnContext(ctx, "AircraftModelService: Aircraft model already exists",
+			"name", req.Name,
+		)
+		return nil, errors.New(AircraftModelExistsErr)
+	}
+
+	slots, err := s.buildSlots(ctx, req.Name, req.Slots)
+	if err != nil {
+		return nil, err
+	}
+
+	aircraftModel := &models.AircraftModel{
+		Name:         req.Name,
+		Manufacturer: req.Manufacturer,
+		Slots:        slots,
+	}
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.aircraftModelRepo.Create(ctx, aircraftModel); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityAircraftModel, EntityID: aircraftModel.ID, Action: models.AuditActionCreate, After: aircraftModel})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to create aircraft model",
+			"name", req.Name,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to create aircraft model: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "AircraftModelService: Aircraft model created successfully",
+		"aircraft_model_id", aircraftModel.ID,
+		"name", aircraftModel.Name,
+	)
+
+	resp := aircraftModel.ToResponse()
+	return &resp, nil
+}
+
+func (s *AircraftModelService) GetAircraftModel(ctx context.Context, id int64) (*models.AircraftModelResponse, error) {
+	s.logger.InfoContext(ctx, "AircraftModelService: GetAircraftModel",
+		"aircraft_model_id", id,
+	)
+
+	aircraftModel, err := s.getAircraftModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := aircraftModel.ToResponse()
+	return &resp, nil
+}
+
+func (s *AircraftModelService) ListAircraftModels(ctx context.Context, query *models.AircraftModelQuery) (*models.PaginatedResponse[models.AircraftModelResponse], error) {
+	s.logger.InfoContext(ctx, "AircraftModelService: ListAircraftModels",
+		"name", query.Name,
+		"manufacturer", query.Manufacturer,
+	)
+
+	query.Normalize()
+	aircraftModels, total, err := s.aircraftModelRepo.List(ctx, query)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to list aircraft models",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to list aircraft models: %w", err)
+	}
+
+	responses := make([]models.AircraftModelResponse, len(aircraftModels))
+	for i, aircraftModel := range aircraftModels {
+		responses[i] = aircraftModel.ToResponse()
+	}
+
+	return &models.PaginatedResponse[models.AircraftModelResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+// UpdateAircraftModel changes the manufacturer or replaces the slot template.
+// Existing planes are measured against the new template from then on.
+func (s *AircraftModelService) UpdateAircraftModel(ctx context.Context, id int64, req *models.UpdateAircraftModelRequest) (*models.AircraftModelResponse, error) {
+	s.logger.InfoContext(ctx, "AircraftModelService: UpdateAircraftModel",
+		"aircraft_model_id", id,
+	)
+
+	aircraftModel, err := s.getAircraftModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *aircraftModel
+
+	if req.Manufacturer != nil {
+		aircraftModel.Manufacturer = *req.Manufacturer
+	}
+	if req.Slots != nil {
+		slots, err := s.buildSlots(ctx, aircraftModel.Name, req.Slots)
+		if err != nil {
+			return nil, err
+		}
+		aircraftModel.Slots = slots
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.aircraftModelRepo.Update(ctx, aircraftModel, req.Slots != nil); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityAircraftModel, EntityID: id, Action: models.AuditActionUpdate, Before: before, After: aircraftModel})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to update aircraft model",
+			"aircraft_model_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to update aircraft model: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "AircraftModelService: UpdateAircraftModel successful",
+		"aircraft_model_id", id,
+	)
+
+	resp := aircraftModel.ToResponse()
+	return &resp, nil
+}
+
+// DeleteAircraftModel removes a model no plane uses, deleted planes included.
+func (s *AircraftModelService) DeleteAircraftModel(ctx context.Context, id int64) error {
+	s.logger.InfoContext(ctx, "AircraftModelService: DeleteAircraftModel",
+		"aircraft_model_id", id,
+	)
+
+	aircraftModel, err := s.getAircraftModel(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.aircraftModelRepo.CountPlanes(ctx, aircraftModel.Name)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to check aircraft model usage",
+			"aircraft_model_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to check aircraft model usage: %w", err)
+	}
+	if count > 0 {
+		s.logger.WarnContext(ctx, "AircraftModelService: Aircraft model is in use",
+			"aircraft_model_id", id,
+			"planes", count,
+		)
+		return errors.New(AircraftModelInUseErr)
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.aircraftModelRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityAircraftModel, EntityID: id, Action: models.AuditActionDelete, Before: aircraftModel})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to delete aircraft model",
+			"aircraft_model_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to delete aircraft model: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "AircraftModelService: Delete successful",
+		"aircraft_model_id", id,
+	)
+
+	return nil
+}
+
+// ScaffoldPlane creates a plane of this model and the parts for its slots in
+// one transaction, then reports how the result compares with the template.
+// Parts are checked against their slot before anything is written: a slot
+// may not receive more parts than it requires, and slots with a catalog
+// part apply that part's name, category and default limits.
+func (s *AircraftModelService) ScaffoldPlane(ctx context.Context, id int64, req *models.ScaffoldPlaneRequest, actorID int64) (*models.PlaneWithPartsResponse, error) {
+	s.logger.InfoContext(ctx, "AircraftModelService: ScaffoldPlane",
+		"aircraft_model_id", id,
+		"tail_number", req.TailNumber,
+		"parts", len(req.Parts),
+		"actor_id", actorID,
+	)
+
+	aircraftModel, err := s.getAircraftModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.planeRepo.GetByTailNumberWithDeleted(ctx, req.TailNumber)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to check existing plane",
+			"tail_number", req.TailNumber,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to check existing plane: %w", err)
+	}
+	if existing != nil {
+		s.logger.WarnContext(ctx, "AircraftModelService: Plane with tail number already exists",
+			"tail_number", req.TailNumber,
+		)
+		return nil, errors.New(PlaneExistsErr)
+	}
+
+	parts, err := s.buildParts(ctx, aircraftModel, req.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	planes := []models.Plane{{TailNumber: req.TailNumber, Model: aircraftModel.Name, Status: models.PlaneStatusActive}}
+	partTails := make([]string, len(parts))
+	for i := range partTails {
+		partTails[i] = req.TailNumber
+	}
+	plane := &planes[0]
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.importRepo.Import(ctx, planes, parts, partTails, actorRef(actorID)); err != nil {
+			return err
+		}
+
+		changes := make([]AuditChange, 0, len(parts)+1)
+		changes = append(changes, AuditChange{EntityType: models.AuditEntityPlane, EntityID: plane.ID, Action: models.AuditActionCreate, After: plane})
+		for i := range parts {
+			changes = append(changes, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: parts[i].ID, Action: models.AuditActionCreate, After: parts[i]})
+		}
+		if err := s.audit.Record(ctx, changes...); err != nil {
+			return err
+		}
+		_, err := s.airworthiness.GroundIfOverLimit(ctx, plane.ID, actorID)
+		return err
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to scaffold plane",
+			"tail_number", req.TailNumber,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to scaffold plane: %w", err)
+	}
+
+	// Reload the plane, since grounding may have changed its status.
+	reloaded, err := s.planeRepo.GetByID(ctx, plane.ID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to reload plane",
+			"plane_id", plane.ID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get plane: %w", err)
+	}
+	if reloaded != nil {
+		plane = reloaded
+	}
+
+	resp := &models.PlaneWithPartsResponse{
+		Plane: plane.ToResponse(),
+		Parts: make([]models.PlanePartResponse, len(parts)),
+	}
+	for i := range parts {
+		resp.Parts[i] = parts[i].ToResponse()
+	}
+	report := aircraftModel.CheckSlots(parts)
+	resp.Slots = &report
+
+	s.logger.InfoContext(ctx, "AircraftModelService: ScaffoldPlane successful",
+		"plane_id", plane.ID,
+		"parts", len(parts),
+		"complete", report.Complete,
+	)
+
+	return resp, nil
+}
+
+// buildSlots validates a slot template. Slot names must be unique, and a
+// slot naming a catalog part takes its category from it and must fit the
+// model.
+func (s *AircraftModelService) buildSlots(ctx context.Context, modelName string, reqs []models.AircraftModelSlotRequest) ([]models.AircraftModelSlot, error) {
+	slots := make([]models.AircraftModelSlot, 0, len(reqs))
+	seen := make(map[string]bool, len(reqs))
+	for _, req := range reqs {
+		key := strings.ToLower(strings.TrimSpace(req.Name))
+		if seen[key] {
+			s.logger.WarnContext(ctx, "AircraftModelService: Slot name repeated",
+				"slot", req.Name,
+			)
+			return nil, errors.New(SlotNameErr)
+		}
+		seen[key] = true
+
+		slot := models.AircraftModelSlot{
+			Name:          strings.TrimSpace(req.Name),
+			Category:      req.Category,
+			CatalogPartID: req.CatalogPartID,
+			Quantity:      req.Quantity,
+		}
+		if req.CatalogPartID != nil {
+			catalogPart, err := s.getCatalogPart(ctx, *req.CatalogPartID)
+			if err != nil {
+				return nil, err
+			}
+			if !catalogPart.AppliesTo(modelName) {
+				s.logger.WarnContext(ctx, "AircraftModelService: Catalog part does not fit model",
+					"catalog_part_id", catalogPart.ID,
+					"model", modelName,
+				)
+				return nil, errors.New(CatalogModelErr)
+			}
+			slot.Category = catalogPart.Category
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// buildParts turns scaffold rows into parts, checking slots, serial numbers
+// and catalog limits.
+func (s *AircraftModelService) buildParts(ctx context.Context, aircraftModel *models.AircraftModel, reqs []models.ScaffoldPartRequest) ([]models.PlanePart, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+
+	serials := make([]string, len(reqs))
+	seen := make(map[string]bool, len(reqs))
+	for i, req := range reqs {
+		if seen[req.SerialNumber] {
+			s.logger.WarnContext(ctx, "AircraftModelService: Serial number repeated",
+				"serial_number", req.SerialNumber,
+			)
+			return nil, errors.New(SerialNumberRepeatedErr)
+		}
+		seen[req.SerialNumber] = true
+		serials[i] = req.SerialNumber
+	}
+	existing, err := s.planePartRepo.GetBySerialNumbers(ctx, serials)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to check existing parts",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to check existing parts: %w", err)
+	}
+	if len(existing) > 0 {
+		s.logger.WarnContext(ctx, "AircraftModelService: Part with serial number already exists",
+			"serial_number", existing[0].SerialNumber,
+		)
+		return nil, errors.New(PlanePartExistsErr)
+	}
+
+	filled := make(map[int64]int)
+	catalogParts := make(map[int64]*models.CatalogPart)
+	parts := make([]models.PlanePart, len(reqs))
+	for i, req := range reqs {
+		slot := aircraftModel.Slot(req.Slot)
+		if slot == nil {
+			s.logger.WarnContext(ctx, "AircraftModelService: Slot not found",
+				"aircraft_model_id", aircraftModel.ID,
+				"slot", req.Slot,
+			)
+			return nil, errors.New(SlotNotFoundErr)
+		}
+		filled[slot.ID]++
+		if filled[slot.ID] > slot.Quantity {
+			s.logger.WarnContext(ctx, "AircraftModelService: Slot overfilled",
+				"aircraft_model_id", aircraftModel.ID,
+				"slot", slot.Name,
+				"quantity", slot.Quantity,
+			)
+			return nil, errors.New(SlotOverfilledErr)
+		}
+
+		part := models.PlanePart{
+			PartName:          slot.Name,
+			SerialNumber:      req.SerialNumber,
+			Category:          slot.Category,
+			UsageHours:        req.UsageHours,
+			UsageLimitHours:   req.UsageLimitHours,
+			UsageCycles:       req.UsageCycles,
+			UsageLimitCycles:  req.UsageLimitCycles,
+			CalendarLimitDays: req.CalendarLimitDays,
+		}
+		if slot.CatalogPartID == nil {
+			if part.UsageLimitHours == 0 {
+				return nil, errors.New(SlotLimitRequiredErr)
+			}
+		} else {
+			catalogPart, ok := catalogParts[*slot.CatalogPartID]
+			if !ok {
+				catalogPart, err = s.getCatalogPart(ctx, *slot.CatalogPartID)
+				if err != nil {
+					return nil, err
+				}
+				catalogParts[catalogPart.ID] = catalogPart
+			}
+			catalogPart.Inherit(&part)
+			if !catalogPart.WithinDefaults(&part) {
+				s.logger.WarnContext(ctx, "AircraftModelService: Part limits exceed catalog defaults",
+					"catalog_part_id", catalogPart.ID,
+					"serial_number", req.SerialNumber,
+				)
+				return nil, errors.New(CatalogLimitErr)
+			}
+		}
+		parts[i] = part
+	}
+	return parts, nil
+}
+
+func (s *AircraftModelService) getCatalogPart(ctx context.Context, id int64) (*models.CatalogPart, error) {
+	catalogPart, err := s.catalogRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to get catalog part",
+			"catalog_part_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get catalog part: %w", err)
+	}
+	if catalogPart == nil {
+		s.logger.WarnContext(ctx, "AircraftModelService: Catalog part not found",
+			"catalog_part_id", id,
+		)
+		return nil, errors.New(CatalogPartNotFoundErr)
+	}
+	return catalogPart, nil
+}
+
+func (s *AircraftModelService) getAircraftModel(ctx context.Context, id int64) (*models.AircraftModel, error) {
+	aircraftModel, err := s.aircraftModelRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AircraftModelService: Failed to get aircraft model",
+			"aircraft_model_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get aircraft model: %w", err)
+	}
+	if aircraftModel == nil {
+		s.logger.WarnContext(ctx, "AircraftModelService: Aircraft model not found",
+			"aircraft_model_id", id,
+		)
+		return nil, errors.New(AircraftModelNotFoundErr)
+	}
+	return aircraftModel, nil
+}
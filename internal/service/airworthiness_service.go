@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// maxGroundingReason matches plane_status_changes.reason.
+const maxGroundingReason = 500
+
+// AirworthinessService grounds planes whose installed parts overrun a life
+// limit and reports whether a plane may fly. Grounding is sticky: the plane
+// only returns to service through maintenance, once the part has been
+// replaced or its limit extended.
+type AirworthinessService struct {
+	tx            *repository.Transactor
+	planeRepo     *repository.PlaneRepository
+	planePartRepo *repository.PlanePartRepository
+	audit         *AuditService
+	logger        *util.Logger
+}
+
+func NewAirworthinessService(tx *repository.Transactor, planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, audit *AuditService, logger *util.Logger) *AirworthinessService {
+	return &AirworthinessService{
+		tx:            tx,
+		planeRepo:     planeRepo,
+		planePartRepo: planePartRepo,
+		audit:         audit,
+		logger:        logger,
+	}
+}
+
+// GroundIfOverLimit grounds the plane when any installed part has overrun a
+// life limit, calendar limits included, and reports whether one has. Call it
+// with the context of the transaction that changed the parts, so the change
+// and the grounding commit together; an error must fail that change.
+func (s *AirworthinessService) GroundIfOverLimit(ctx context.Context, planeID int64, actorID int64) (bool, error) {
+	parts, err := s.planePartRepo.GetOverLimit(ctx, planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AirworthinessService: Failed to check part limits",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return false, fmt.Errorf("failed to check part limits: %w", err)
+	}
+	if len(parts) == 0 {
+		return false, nil
+	}
+
+	plane, err := s.planeRepo.GetByID(ctx, planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AirworthinessService: Failed to get plane",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return true, fmt.Errorf("failed to get plane: %w", err)
+	}
+	if plane == nil || !plane.Operational() {
+		return true, nil
+	}
+
+	reason := groundingReason(parts)
+	change := &models.PlaneStatusChange{
+		PlaneID:    planeID,
+		FromStatus: plane.Status,
+		ToStatus:   models.PlaneStatusGrounded,
+		Reason:     &reason,
+		ChangedBy:  actorRef(actorID),
+	}
+	var changed bool
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		changed, err = s.planeRepo.ChangeStatus(ctx, change)
+		if err != nil || !changed {
+			return err
+		}
+		before := *plane
+		plane.Status = models.PlaneStatusGrounded
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlane, EntityID: planeID, Action: models.AuditActionStatus, Before: before, After: plane})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AirworthinessService: Failed to ground plane",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return true, fmt.Errorf("failed to ground plane: %w", err)
+	}
+	if !changed {
+		s.logger.WarnContext(ctx, "AirworthinessService: Plane status changed before grounding",
+			"plane_id", planeID,
+		)
+		return true, nil
+	}
+
+	s.logger.WarnContext(ctx, "AirworthinessService: Plane grounded",
+		"plane_id", planeID,
+		"parts_over_limit", len(parts),
+	)
+	return true, nil
+}
+
+// groundingReason names the overrun parts, falling back to a count when the
+// serials do not fit.
+func groundingReason(parts []models.PlanePart) string {
+	serials := make([]string, len(parts))
+	for i, part := range parts {
+		serials[i] = part.SerialNumber
+	}
+
+	reason := "life limit exceeded: " + strings.Join(serials, ", ")
+	if len(reason) > maxGroundingReason {
+		reason = fmt.Sprintf("life limit exceeded on %d parts", len(parts))
+	}
+	return reason
+}
+
+func (s *AirworthinessService) GetReport(ctx context.Context, planeID int64) (*models.AirworthinessReport, error) {
+	s.logger.InfoContext(ctx, "AirworthinessService: GetReport",
+		"plane_id", planeID,
+	)
+
+	plane, err := s.planeRepo.GetByID(ctx, planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AirworthinessService: Failed to get plane",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get plane: %w", err)
+	}
+	if plane == nil {
+		s.logger.WarnContext(ctx, "AirworthinessService: Plane not found",
+			"plane_id", planeID,
+		)
+		return nil, errors.New(PlaneNotFoundErr)
+	}
+
+	parts, err := s.planePartRepo.GetOverLimit(ctx, planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AirworthinessService: Failed to get parts over limit",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get parts over limit: %w", err)
+	}
+
+	changes, _, err := s.planeRepo.GetStatusChanges(ctx, planeID, 0, 1)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AirworthinessService: Failed to get status history",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+
+	report := &models.AirworthinessReport{
+		PlaneID:        plane.ID,
+		TailNumber:     plane.TailNumber,
+		Status:         plane.Status,
+		Airworthy:      plane.Status == models.PlaneStatusActive && len(parts) == 0,
+		OverLimitParts: make([]models.PlanePartResponse, len(parts)),
+		CheckedAt:      time.Now(),
+	}
+	for i := range parts {
+		report.OverLimitParts[i] = parts[i].ToResponse()
+	}
+	if len(changes) > 0 {
+		last := changes[0].ToResponse()
+		report.LastStatusChange = &last
+	}
+
+	return report, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// AuditChange describes one mutation to record. Before is nil for creates
+// and After is nil for deletes; both are marshalled to JSON, so fields
+// tagged json:"-" such as password hashes are never stored.
+type AuditChange struct {
+	EntityType string
+	EntityID   int64
+	Action     string
+	Before     any
+	After      any
+}
+
+// errChainBroken stops Verify's scan at the first bad entry.
+var errChainBroken = errors.New("audit chain broken")
+
+type AuditService struct {
+	auditRepo *repository.AuditRepository
+	logger    *util.Logger
+}
+
+func NewAuditService(auditRepo *repository.AuditRepository, logger *util.Logger) *AuditService {
+	return &AuditService{auditRepo: auditRepo, logger: logger}
+}
+
+// Record appends changes to the audit log, taking the actor and request ID
+// from ctx. Call it with the context of the transaction making the change:
+// the entries commit or roll back with it, so an error must fail the change.
+func (s *AuditService) Record(ctx context.Context, changes ...AuditChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var actorID *int64
+	if id, ok := util.ActorID(ctx); ok {
+		actorID = &id
+	}
+	var requestID *string
+	if id := util.RequestID(ctx); id != "" {
+		requestID = &id
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	entries := make([]*models.AuditEntry, len(changes))
+	for i, change := range changes {
+		entry, err := newAuditEntry(change)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "AuditService: Failed to encode change",
+				"entity_type", change.EntityType,
+				"entity_id", change.EntityID,
+				"action", change.Action,
+				"error", err,
+			)
+			return fmt.Errorf("failed to encode audit entry: %w", err)
+		}
+		entry.ActorID = actorID
+		entry.RequestID = requestID
+		entry.CreatedAt = now
+		entries[i] = entry
+	}
+
+	if err := s.auditRepo.Append(ctx, entries); err != nil {
+		s.logger.ErrorContext(ctx, "AuditService: Failed to record changes",
+			"count", len(entries),
+			"error", err,
+		)
+		return fmt.Errorf("failed to record audit entries: %w", err)
+	}
+	return nil
+}
+
+func newAuditEntry(change AuditChange) (*models.AuditEntry, error) {
+	entry := &models.AuditEntry{
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+		Action:     change.Action,
+	}
+
+	before, err := auditJSON(change.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := auditJSON(change.After)
+	if err != nil {
+		return nil, err
+	}
+	entry.Before = before
+	entry.After = after
+
+	if before != nil && after != nil {
+		diff, err := models.AuditDiff([]byte(*before), []byte(*after))
+		if err != nil {
+			return nil, err
+		}
+		if entry.Changes, err = auditJSON(diff); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+func auditJSON(v any) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	s := string(b)
+	return &s, nil
+}
+
+func (s *AuditService) List(ctx context.Context, query *models.AuditQuery) (*models.PaginatedResponse[models.AuditEntryResponse], error) {
+	s.logger.InfoContext(ctx, "AuditService: List",
+		"entity_type", query.EntityType,
+		"entity_id", query.EntityID,
+		"actor_id", query.ActorID,
+	)
+
+	query.Normalize()
+	entries, total, err := s.auditRepo.List(ctx, query)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AuditService: Failed to list entries",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+
+	responses := make([]models.AuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = entry.ToResponse()
+	}
+
+	return &models.PaginatedResponse[models.AuditEntryResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+// Verify walks the whole chain and reports the first entry whose stored hash
+// does not match its contents or whose PrevHash does not match the entry
+// before it.
+func (s *AuditService) Verify(ctx context.Context) (*models.AuditVerifyResponse, error) {
+	s.logger.InfoContext(ctx, "AuditService: Verify")
+
+	resp := &models.AuditVerifyResponse{Valid: true}
+	prevHash := models.AuditGenesisHash
+	err := s.auditRepo.Stream(ctx, func(entry *models.AuditEntry) error {
+		resp.Checked++
+
+		reason := ""
+		switch {
+		case entry.PrevHash != prevHash:
+			reason = "previous hash does not match the preceding entry"
+		case entry.ComputeHash() != entry.Hash:
+			reason = "hash does not match entry contents"
+		}
+		if reason != "" {
+			id := entry.ID
+			resp.Valid = false
+			resp.FirstInvalidID = &id
+			resp.Reason = reason
+			return errChainBroken
+		}
+		prevHash = entry.Hash
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		s.logger.ErrorContext(ctx, "AuditService: Failed to verify chain",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to verify audit log: %w", err)
+	}
+
+	if resp.Valid {
+		s.logger.InfoContext(ctx, "AuditService: Chain verified",
+			"checked", resp.Checked,
+		)
+	} else {
+		s.logger.WarnContext(ctx, "AuditService: Chain broken",
+			"first_invalid_id", *resp.FirstInvalidID,
+			"reason", resp.Reason,
+		)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const (
+	CatalogPartNotFoundErr = "catalog part not found"
+	CatalogPartExistsErr   = "catalog part with this part number already exists"
+	CatalogPartInUseErr    = "catalog part is referenced by plane parts or aircraft model slots"
+	CatalogModelErr        = "catalog part is not applicable to this aircraft model"
+	CatalogLimitErr        = "part limits cannot exceed the catalog defaults"
+	CatalogFieldErr        = "part name and category are set by the catalog part"
+	CatalogRelinkErr       = "plane part is already linked to a different catalog part"
+)
+
+type CatalogService struct {
+	tx          *repository.Transactor
+	catalogRepo *repository.CatalogPartRepository
+	audit       *AuditService
+	logger      *util.Logger
+}
+
+func NewCatalogService(tx *repository.Transactor, catalogRepo *repository.CatalogPartRepository, audit *AuditService, logger *util.Logger) *CatalogService {
+	return &CatalogService{
+		tx:          tx,
+		catalogRepo: catalogRepo,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+func (s *CatalogService) CreateCatalogPart(ctx context.Context, req *models.CreateCatalogPartRequest) (*models.CatalogPartResponse, error) {
+	s.logger.InfoContext(ctx, "CatalogService: Creating catalog part",
+		"part_number", req.PartNumber,
+		"manufacturer", req.Manufacturer,
+	)
+
+	if err := s.checkPartNumber(ctx, req.PartNumber); err != nil {
+		return nil, err
+	}
+
+	catalogPart := &models.CatalogPart{
+		PartNumber:          req.PartNumber,
+		Manufacturer:        req.Manufacturer,
+		Description:         req.Description,
+		Category:            req.Category,
+		DefaultLimitHours:   req.DefaultLimitHours,
+		DefaultLimitCycles:  req.DefaultLimitCycles,
+		DefaultCalendarDays: req.DefaultCalendarDays,
+	}
+	catalogPart.SetApplicableModels(req.ApplicableModels)
+
+	err := s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.catalogRepo.Create(ctx, catalogPart); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityCatalogPart, EntityID: catalogPart.ID, Action: models.AuditActionCreate, After: catalogPart.ToResponse()})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "CatalogService: Failed to create catalog part",
+			"part_number", req.PartNumber,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to create catalog part: %w", err)
+	}
+	resp := catalogPart.ToResponse()
+
+	s.logger.InfoContext(ctx, "CatalogService: Catalog part created successfully",
+		"catalog_part_id", catalogPart.ID,
+		"part_number", catalogPart.PartNumber,
+	)
+
+	return &resp, nil
+}
+
+func (s *CatalogService) GetCatalogPart(ctx context.Context, id int64) (*models.CatalogPartResponse, error) {
+	s.logger.InfoContext(ctx, "CatalogService: GetCatalogPart",
+		"catalog_part_id", id,
+	)
+
+	catalogPart, err := s.getCatalogPart(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := catalogPart.ToResponse()
+	return &resp, nil
+}
+
+func (s *CatalogService) ListCatalogParts(ctx context.Context, query *models.CatalogQuery) (*models.PaginatedResponse[models.CatalogPartResponse], error) {
+	s.logger.InfoContext(ctx, "CatalogService: ListCatalogParts",
+		"category", query.Category,
+		"model", query.Model,
+	)
+
+	query.Normalize()
+	catalogParts, total, err := s.catalogRepo.List(ctx, query)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "CatalogService: Failed to list catalog parts",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to list catalog parts: %w", err)
+	}
+
+	responses := make([]models.CatalogPartResponse, len(catalogParts))
+	for i := range catalogParts {
+		responses[i] = catalogParts[i].ToResponse()
+	}
+
+	return &models.PaginatedResponse[models.CatalogPartResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+// UpdateCatalogPart changes a catalog entry. Parts already created from it
+// keep the name, category and limits they were created with.
+func (s *CatalogService) UpdateCatalogPart(ctx context.Context, id int64, req *models.UpdateCatalogPartRequest) (*models.CatalogPartResponse, error) {
+	s.logger.InfoContext(ctx, "CatalogService: UpdateCatalogPart",
+		"catalog_part_id", id,
+	)
+
+	catalogPart, err := s.getCatalogPart(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := catalogPart.ToResponse()
+
+	if req.PartNumber != nil && *req.PartNumber != catalogPart.PartNumber {
+		if err := s.checkPartNumber(ctx, *req.PartNumber); err != nil {
+			return nil, err
+		}
+		catalogPart.PartNumber = *req.PartNumber
+	}
+	if req.Manufacturer != nil {
+		catalogPart.Manufacturer = *req.Manufacturer
+	}
+	if req.Description != nil {
+		catalogPart.Description = *req.Description
+	}
+	if req.Category != nil {
+		catalogPart.Category = *req.Category
+	}
+	if req.DefaultLimitHours != nil {
+		catalogPart.DefaultLimitHours = *req.DefaultLimitHours
+	}
+	if req.DefaultLimitCycles != nil {
+		catalogPart.DefaultLimitCycles = req.DefaultLimitCycles
+	}
+	if req.DefaultCalendarDays != nil {
+		catalogPart.DefaultCalendarDays = req.DefaultCalendarDays
+	}
+	if req.ApplicableModels != nil {
+		catalogPart.SetApplicableModels(req.ApplicableModels)
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.catalogRepo.Update(ctx, catalogPart); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityCatalogPart, EntityID: id, Action: models.AuditActionUpdate, Before: before, After: catalogPart.ToResponse()})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "CatalogService: Failed to update catalog part",
+			"catalog_part_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to update catalog part: %w", err)
+	}
+	resp := catalogPart.ToResponse()
+
+	s.logger.InfoContext(ctx, "CatalogService: UpdateCatalogPart successful",
+		"catalog_part_id", id,
+	)
+
+	return &resp, nil
+}
+
+// DeleteCatalogPart removes a catalog entry that no part or aircraft model
+// slot references.
+func (s *CatalogService) DeleteCatalogPart(ctx context.Context, id int64) error {
+	s.logger.InfoContext(ctx, "CatalogService: DeleteCatalogPart",
+		"catalog_part_id", id,
+	)
+
+	catalogPart, err := s.getCatalogPart(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.catalogRepo.CountParts(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "CatalogService: Failed to check catalog part usage",
+			"catalog_part_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to check catalog part usage: %w", err)
+	}
+	if count > 0 {
+		s.logger.WarnContext(ctx, "CatalogService: Catalog part is in use",
+			"catalog_part_id", id,
+			"parts", count,
+		)
+		return errors.New(CatalogPartInUseErr)
+	}
+
+	slots, err := s.catalogRepo.CountSlots(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "CatalogService: Failed to check catalog part slots",
+			"catalog_part_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to check catalog part slots: %w", err)
+	}
+	if slots > 0 {
+		s.logger.WarnContext(ctx, "CatalogService: Catalog part is required by aircraft model slots",
+			"catalog_part_id", id,
+			"slots", slots,
+		)
+		return errors.New(CatalogPartInUseErr)
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.catalogRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityCatalogPart, EntityID: id, Action: models.AuditActionDelete, Before: catalogPart.ToResponse()})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "CatalogService: Failed to delete catalog part",
+			"catalog_part_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to delete catalog part: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "CatalogService: Delete successful",
+		"catalog_part_id", id,
+	)
+
+	return nil
+}
+
+func (s *CatalogService) checkPartNumber(ctx context.Context, partNumber string) error {
+	existing, err := s.catalogRepo.GetByPartNumber(ctx, partNumber)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "CatalogService: Failed to check existing catalog part",
+			"part_number", partNumber,
+			"error", err,
+		)
+		return fmt.Errorf("failed to check existing catalog part: %w", err)
+	}
+	if existing != nil {
+		s.logger.WarnContext(ctx, "CatalogService: Catalog part with part number already exists",
+			"part_number", partNumber,
+		)
+		return errors.New(CatalogPartExistsErr)
+	}
+	return nil
+}
+
+func (s *CatalogService) getCatalogPart(ctx context.Context, id int64) (*models.CatalogPart, error) {
+	catalogPart, err := s.catalogRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "CatalogService: Failed to get catalog part",
+			"catalog_part_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get catalog part: %w", err)
+	}
+	if catalogPart == nil {
+		s.logger.WarnContext(ctx, "CatalogService: Catalog part not found",
+			"catalog_part_id", id,
+		)
+		return nil, errors.New(CatalogPartNotFoundErr)
+	}
+	return catalogPart, nil
+}
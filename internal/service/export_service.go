@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// exportFlushRows is how many rows are buffered before they are pushed to
+// the client.
+const exportFlushRows = 500
+
+var (
+	planeExportColumns = []string{"id", "tail_number", "model", "status", "created_at"}
+	partExportColumns  = []string{
+		"id", "plane_id", "serial_number", "part_name", "category",
+		"usage_hours", "usage_limit_hours", "extension_hours", "usage_cycles", "usage_limit_cycles",
+		"calendar_limit_days", "calendar_due_at", "usage_percent", "limit_driver", "installed_at",
+		"in_service_at",
+	}
+)
+
+type ExportService struct {
+	planeRepo     *repository.PlaneRepository
+	planePartRepo *repository.PlanePartRepository
+	logger        *util.Logger
+}
+
+func NewExportService(planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, logger *util.Logger) *ExportService {
+	return &ExportService{
+		planeRepo:     planeRepo,
+		planePartRepo: planePartRepo,
+		logger:        logger,
+	}
+}
+
+func (s *ExportService) ExportPlanes(ctx context.Context, w io.Writer, format string, query *models.PlaneQuery) error {
+	s.logger.InfoContext(ctx, "ExportService: ExportPlanes",
+		"format", format,
+		"model", query.Model,
+		"tail_number", query.TailNumber,
+		"status", query.Status,
+	)
+
+	out := newExporter(w, format, planeExportColumns, planeRecord)
+	err := s.planeRepo.Stream(ctx, query, func(plane *models.Plane) error {
+		return out.write(plane)
+	})
+
+	return s.finish(ctx, "ExportPlanes", out, err)
+}
+
+func (s *ExportService) ExportParts(ctx context.Context, w io.Writer, format string, query *models.PartQuery) error {
+	s.logger.InfoContext(ctx, "ExportService: ExportParts",
+		"format", format,
+		"category", query.Category,
+		"sort", query.Sort,
+	)
+
+	out := newExporter(w, format, partExportColumns, partRecord)
+	err := s.planePartRepo.Stream(ctx, query, func(part *models.PlanePart) error {
+		resp := part.ToResponse()
+		return out.write(&resp)
+	})
+
+	return s.finish(ctx, "ExportParts", out, err)
+}
+
+func (s *ExportService) ExportMaintenanceAlerts(ctx context.Context, w io.Writer, format string, query *models.MaintenanceAlertQuery) error {
+	threshold := defaultMaintenanceThreshold
+	if query.Threshold != nil {
+		threshold = *query.Threshold
+	}
+
+	s.logger.InfoContext(ctx, "ExportService: ExportMaintenanceAlerts",
+		"format", format,
+		"threshold", threshold,
+	)
+
+	out := newExporter(w, format, partExportColumns, partRecord)
+	err := s.planePartRepo.StreamNeedingMaintenance(ctx, threshold, &query.PartQuery, func(part *models.PlanePart) error {
+		resp := part.ToResponse()
+		return out.write(&resp)
+	})
+
+	return s.finish(ctx, "ExportMaintenanceAlerts", out, err)
+}
+
+func (s *ExportService) finish(ctx context.Context, op string, out interface{ close() (int, error) }, streamErr error) error {
+	// A failed query is not closed, so an export that fails before its
+	// first row leaves the response untouched.
+	rows, err := 0, streamErr
+	if err == nil {
+		rows, err = out.close()
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ExportService: Failed to export",
+			"op", op,
+			"rows", rows,
+			"error", err,
+		)
+		return fmt.Errorf("failed to export: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "ExportService: "+op+" successful",
+		"rows", rows,
+	)
+
+	return nil
+}
+
+// exporter writes rows of T as CSV, with a header line, or as NDJSON, one
+// JSON object per line. Output is flushed every exportFlushRows rows so the
+// client receives data while the query is still running.
+type exporter[T any] struct {
+	w       io.Writer
+	csv     *csv.Writer
+	json    *json.Encoder
+	header  []string
+	record  func(*T) []string
+	rows    int
+	started bool
+}
+
+func newExporter[T any](w io.Writer, format string, header []string, record func(*T) []string) *exporter[T] {
+	e := &exporter[T]{w: w, header: header, record: record}
+	if format == models.ExportFormatNDJSON {
+		e.json = json.NewEncoder(w)
+	} else {
+		e.csv = csv.NewWriter(w)
+	}
+	return e
+}
+
+func (e *exporter[T]) write(v *T) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if e.json != nil {
+		if err := e.json.Encode(v); err != nil {
+			return err
+		}
+	} else if err := e.csv.Write(e.record(v)); err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// start writes the CSV header. It runs on the first row, or on close for an
+// empty export, so a query that fails up front leaves the response unwritten.
+func (e *exporter[T]) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	if e.csv != nil {
+		return e.csv.Write(e.header)
+	}
+	return nil
+}
+
+func (e *exporter[T]) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if f, ok := e.w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (e *exporter[T]) close() (int, error) {
+	if err := e.start(); err != nil {
+		return e.rows, err
+	}
+	return e.rows, e.flush()
+}
+
+func planeRecord(p *models.Plane) []string {
+	return []string{
+		strconv.FormatInt(p.ID, 10),
+		csvText(p.TailNumber),
+		csvText(p.Model),
+		p.Status,
+		p.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func partRecord(p *models.PlanePartResponse) []string {
+	return []string{
+		strconv.FormatInt(p.ID, 10),
+		formatOptionalInt64(p.PlaneID),
+		csvText(p.SerialNumber),
+		csvText(p.PartName),
+		csvText(p.Category),
+		strconv.FormatFloat(p.UsageHours, 'f', -1, 64),
+		strconv.FormatFloat(p.UsageLimitHours, 'f', -1, 64),
+		strconv.FormatFloat(p.ExtensionHours, 'f', -1, 64),
+		strconv.Itoa(p.UsageCycles),
+		formatOptionalInt(p.UsageLimitCycles),
+		formatOptionalInt(p.CalendarLimitDays),
+		formatOptionalTime(p.CalendarDueAt),
+		strconv.FormatFloat(p.UsagePercent, 'f', 2, 64),
+		p.LimitDriver,
+		p.InstalledAt.UTC().Format(time.RFC3339),
+		p.InServiceAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// csvText stops spreadsheets from evaluating free-text cells as formulas.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func formatOptionalInt64(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func formatOptionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatOptionalTime(v *time.Time) string {
+	if v == nil {
+		return ""
+	}
+	return v.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const collectTimeout = 10 * time.Second
+
+var planeStatuses = []string{
+	models.PlaneStatusActive,
+	models.PlaneStatusInMaintenance,
+	models.PlaneStatusGrounded,
+	models.PlaneStatusRetired,
+}
+
+// FleetCollector is a Prometheus collector for fleet health. It queries the
+// database on every scrape, so the gauges are never stale; a failed query
+// drops its gauges from that scrape and is logged.
+//
+// A scrape gives up after collectTimeout so a slow database can't hold the
+// metrics endpoint open.
+type FleetCollector struct {
+	planeRepo     *repository.PlaneRepository
+	planePartRepo *repository.PlanePartRepository
+	logger        *util.Logger
+
+	planes            *prometheus.Desc
+	partsUsage        *prometheus.Desc
+	partsUsageByPlane *prometheus.Desc
+}
+
+func NewFleetCollector(planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, logger *util.Logger) *FleetCollector {
+	return &FleetCollector{
+		planeRepo:     planeRepo,
+		planePartRepo: planePartRepo,
+		logger:        logger,
+		planes: prometheus.NewDesc("fleet_planes",
+			"Planes by status, deleted planes excluded.",
+			[]string{"status"}, nil),
+		partsUsage: prometheus.NewDesc("fleet_plane_parts_life_used",
+			"Installed parts at or above 80% or 90% of a life limit, or over it (threshold 100).",
+			[]string{"threshold"}, nil),
+		partsUsageByPlane: prometheus.NewDesc("fleet_plane_parts_life_used_by_plane",
+			"Installed parts per plane at or above 80% or 90% of a life limit, or over it (threshold 100).",
+			[]string{"plane_id", "threshold"}, nil),
+	}
+}
+
+func (c *FleetCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.planes
+	ch <- c.partsUsage
+	ch <- c.partsUsageByPlane
+}
+
+func (c *FleetCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	counts, err := c.planeRepo.CountByStatus(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "FleetCollector: Failed to count planes",
+			"error", err,
+		)
+	} else {
+		for _, status := range planeStatuses {
+			ch <- prometheus.MustNewConstMetric(c.planes, prometheus.GaugeValue, float64(counts[status]), status)
+		}
+	}
+
+	// Per-plane series are labelled by plane ID rather than tail number so
+	// the registration isn't published to anyone who can scrape.
+	usage, err := c.planePartRepo.CountUsage(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "FleetCollector: Failed to count part usage",
+			"error", err,
+		)
+		return
+	}
+	var total models.PlanePartUsage
+	for _, plane := range usage {
+		planeID := strconv.FormatInt(plane.PlaneID, 10)
+		ch <- prometheus.MustNewConstMetric(c.partsUsageByPlane, prometheus.GaugeValue, float64(plane.Above80), planeID, "80")
+		ch <- prometheus.MustNewConstMetric(c.partsUsageByPlane, prometheus.GaugeValue, float64(plane.Above90), planeID, "90")
+		ch <- prometheus.MustNewConstMetric(c.partsUsageByPlane, prometheus.GaugeValue, float64(plane.OverLimit), planeID, "100")
+		total.Above80 += plane.Above80
+		total.Above90 += plane.Above90
+		total.OverLimit += plane.OverLimit
+	}
+	ch <- prometheus.MustNewConstMetric(c.partsUsage, prometheus.GaugeValue, float64(total.Above80), "80")
+	ch <- prometheus.MustNewConstMetric(c.partsUsage, prometheus.GaugeValue, float64(total.Above90), "90")
+	ch <- prometheus.MustNewConstMetric(c.partsUsage, prometheus.GaugeValue, float64(total.OverLimit), "100")
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const (
+	FlightNotFoundErr      = "flight not found"
+	FlightPlaneNotFoundErr = "plane not found"
+)
+
+type FlightService struct {
+	tx            *repository.Transactor
+	planeRepo     *repository.PlaneRepository
+	flightRepo    *repository.FlightRepository
+	airworthiness *AirworthinessService
+	audit         *AuditService
+	logger        *util.Logger
+}
+
+func NewFlightService(tx *repository.Transactor, planeRepo *repository.PlaneRepository, flightRepo *repository.FlightRepository, airworthiness *AirworthinessService, audit *AuditService, logger *util.Logger) *FlightService {
+	return &FlightService{
+		tx:            tx,
+		planeRepo:     planeRepo,
+		flightRepo:    flightRepo,
+		airworthiness: airworthiness,
+		audit:         audit,
+		logger:        logger,
+	}
+}
+
+func (s *FlightService) RecordFlight(ctx context.Context, planeID int64, req *models.CreateFlightRequest, actorID int64) (*models.FlightResponse, error) {
+	s.logger.InfoContext(ctx, "FlightService: Recording flight",
+		"plane_id", planeID,
+		"departure_at", req.DepartureAt,
+		"arrival_at", req.ArrivalAt,
+	)
+
+	plane, err := s.planeRepo.GetByID(ctx, planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "FlightService: Failed to verify plane",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to verify plane: %w", err)
+	}
+	if plane == nil {
+		s.logger.WarnContext(ctx, "FlightService: Plane not found",
+			"plane_id", planeID,
+		)
+		return nil, errors.New(FlightPlaneNotFoundErr)
+	}
+	if !plane.Operational() {
+		s.logger.WarnContext(ctx, "FlightService: Plane is not operational",
+			"plane_id", planeID,
+			"status", plane.Status,
+		)
+		return nil, errors.New(PlaneNotOperationalErr)
+	}
+
+	// Block hours default to the scheduled gate-to-gate time.
+	blockHours := req.ArrivalAt.Sub(req.DepartureAt).Hours()
+	if req.BlockHours != nil {
+		blockHours = *req.BlockHours
+	}
+	cycles := req.Cycles
+	if cycles == 0 {
+		cycles = 1
+	}
+
+	flight := &models.Flight{
+		PlaneID:     planeID,
+		DepartureAt: req.DepartureAt,
+		ArrivalAt:   req.ArrivalAt,
+		BlockHours:  blockHours,
+		Cycles:      cycles,
+		RecordedBy:  actorRef(actorID),
+	}
+
+	var updated int
+	var overLimit bool
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		// A part may have overrun its limit without a write grounding the
+		// plane, such as a calendar limit lapsing. Ground it now and keep
+		// that, but refuse the flight.
+		var err error
+		overLimit, err = s.airworthiness.GroundIfOverLimit(ctx, planeID, actorID)
+		if err != nil || overLimit {
+			return err
+		}
+
+		updated, err = s.flightRepo.CreateWithAccrual(ctx, flight)
+		if err != nil {
+			return err
+		}
+		// The flight's parts are locked; lock the plane too, in the order
+		// grounding takes them, and check it was not grounded meanwhile.
+		locked, err := s.planeRepo.GetByIDForUpdate(ctx, planeID)
+		if err != nil {
+			return err
+		}
+		if locked == nil || !locked.Operational() {
+			return errors.New(PlaneNotOperationalErr)
+		}
+		if err := s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityFlight, EntityID: flight.ID, Action: models.AuditActionCreate, After: flight}); err != nil {
+			return err
+		}
+		// Overruns this flight causes are recorded as flown; the plane is
+		// grounded instead.
+		_, err = s.airworthiness.GroundIfOverLimit(ctx, planeID, actorID)
+		return err
+	})
+	if err != nil {
+		if err.Error() == PlaneNotOperationalErr {
+			s.logger.WarnContext(ctx, "FlightService: Plane stopped being operational",
+				"plane_id", planeID,
+			)
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "FlightService: Failed to record flight",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to record flight: %w", err)
+	}
+	if overLimit {
+		s.logger.WarnContext(ctx, "FlightService: Plane has parts over their life limit",
+			"plane_id", planeID,
+		)
+		return nil, errors.New(PlanePartsOverLimitErr)
+	}
+
+	s.logger.InfoContext(ctx, "FlightService: Flight recorded successfully",
+		"flight_id", flight.ID,
+		"plane_id", planeID,
+		"block_hours", blockHours,
+		"parts_updated", updated,
+	)
+
+	resp := flight.ToResponse()
+	resp.PartsUpdated = &updated
+	return &resp, nil
+}
+
+func (s *FlightService) GetFlight(ctx context.Context, id int64) (*models.FlightResponse, error) {
+	s.logger.InfoContext(ctx, "FlightService: GetFlight",
+		"flight_id", id,
+	)
+
+	flight, err := s.flightRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "FlightService: Failed to get flight",
+			"flight_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get flight: %w", err)
+	}
+	if flight == nil {
+		s.logger.WarnContext(ctx, "FlightService: Flight not found",
+			"flight_id", id,
+		)
+		return nil, errors.New(FlightNotFoundErr)
+	}
+
+	resp := flight.ToResponse()
+	return &resp, nil
+}
+
+func (s *FlightService) GetFlightsByPlane(ctx context.Context, planeID int64, query *models.PaginationQuery) (*models.PaginatedResponse[models.FlightResponse], error) {
+	s.logger.InfoContext(ctx, "FlightService: GetFlightsByPlane",
+		"plane_id", planeID,
+	)
+
+	plane, err := s.planeRepo.GetByID(ctx, planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "FlightService: Failed to verify plane",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to verify plane: %w", err)
+	}
+	if plane == nil {
+		s.logger.WarnContext(ctx, "FlightService: Plane not found",
+			"plane_id", planeID,
+		)
+		return nil, errors.New(FlightPlaneNotFoundErr)
+	}
+
+	query.Normalize()
+	flights, total, err := s.flightRepo.GetByPlaneID(ctx, planeID, query.Offset(), query.PageSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "FlightService: Failed to get flights",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get flights: %w", err)
+	}
+
+	responses := make([]models.FlightResponse, len(flights))
+	for i, flight := range flights {
+		responses[i] = flight.ToResponse()
+	}
+
+	return &models.PaginatedResponse[models.FlightResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
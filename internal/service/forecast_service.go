@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const (
+	ForecastPlaneNotFoundErr = "plane not found"
+)
+
+// ForecastService projects when installed parts reach their life limits,
+// using each plane's average daily flying over a lookback window.
+type ForecastService struct {
+	planeRepo     *repository.PlaneRepository
+	planePartRepo *repository.PlanePartRepository
+	flightRepo    *repository.FlightRepository
+	logger        *util.Logger
+}
+
+func NewForecastService(planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, flightRepo *repository.FlightRepository, logger *util.Logger) *ForecastService {
+	return &ForecastService{
+		planeRepo:     planeRepo,
+		planePartRepo: planePartRepo,
+		flightRepo:    flightRepo,
+		logger:        logger,
+	}
+}
+
+func (s *ForecastService) GetPlaneForecast(ctx context.Context, planeID int64, query *models.ForecastQuery) (*models.PlaneForecastResponse, error) {
+	lookbackDays := lookbackOrDefault(query)
+	s.logger.InfoContext(ctx, "ForecastService: GetPlaneForecast",
+		"plane_id", planeID,
+		"lookback_days", lookbackDays,
+	)
+
+	plane, err := s.planeRepo.GetByID(ctx, planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ForecastService: Failed to get plane",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get plane: %w", err)
+	}
+	if plane == nil {
+		s.logger.WarnContext(ctx, "ForecastService: Plane not found",
+			"plane_id", planeID,
+		)
+		return nil, errors.New(ForecastPlaneNotFoundErr)
+	}
+
+	now := time.Now()
+	utilization, err := s.flightRepo.GetUtilization(ctx, now.AddDate(0, 0, -lookbackDays), &planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ForecastService: Failed to get utilization",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get utilization: %w", err)
+	}
+
+	parts, err := s.planePartRepo.GetInstalled(ctx, &planeID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ForecastService: Failed to get installed parts",
+			"plane_id", planeID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get parts: %w", err)
+	}
+
+	resp := &models.PlaneForecastResponse{
+		PlaneID:      plane.ID,
+		TailNumber:   plane.TailNumber,
+		LookbackDays: lookbackDays,
+		Parts:        make([]models.PartForecast, len(parts)),
+	}
+	if len(utilization) > 0 {
+		resp.AvgDailyHours, resp.AvgDailyCycles = utilization[0].DailyRates(now)
+	}
+	for i, part := range parts {
+		resp.Parts[i] = part.Forecast(now, resp.AvgDailyHours, resp.AvgDailyCycles)
+	}
+	models.SortForecasts(resp.Parts)
+
+	s.logger.InfoContext(ctx, "ForecastService: GetPlaneForecast successful",
+		"plane_id", planeID,
+		"avg_daily_hours", resp.AvgDailyHours,
+		"parts", len(parts),
+	)
+
+	return resp, nil
+}
+
+// GetFleetForecast lists every installed part due within the next query.Days
+// days, grouped by due date, plus anything already overdue.
+func (s *ForecastService) GetFleetForecast(ctx context.Context, query *models.FleetForecastQuery) (*models.FleetForecastResponse, error) {
+	lookbackDays := lookbackOrDefault(&query.ForecastQuery)
+	days := query.Days
+	if days == 0 {
+		days = models.DefaultForecastHorizonDays
+	}
+	s.logger.InfoContext(ctx, "ForecastService: GetFleetForecast",
+		"days", days,
+		"lookback_days", lookbackDays,
+	)
+
+	now := time.Now()
+	utilization, err := s.flightRepo.GetUtilization(ctx, now.AddDate(0, 0, -lookbackDays), nil)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ForecastService: Failed to get utilization",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get utilization: %w", err)
+	}
+	byPlane := make(map[int64]models.PlaneUtilization, len(utilization))
+	for _, u := range utilization {
+		byPlane[u.PlaneID] = u
+	}
+
+	parts, err := s.planePartRepo.GetInstalled(ctx, nil)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ForecastService: Failed to get installed parts",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get parts: %w", err)
+	}
+
+	to := now.AddDate(0, 0, days)
+	var due []models.PartForecast
+	for _, part := range parts {
+		u := byPlane[*part.PlaneID]
+		dailyHours, dailyCycles := 0.0, 0.0
+		if u.PlaneID != 0 {
+			dailyHours, dailyCycles = u.DailyRates(now)
+		}
+
+		forecast := part.Forecast(now, dailyHours, dailyCycles)
+		if forecast.DueAt == nil || forecast.DueAt.After(to) {
+			continue
+		}
+		forecast.TailNumber = u.TailNumber
+		due = append(due, forecast)
+	}
+	models.SortForecasts(due)
+
+	resp := &models.FleetForecastResponse{
+		From:         now,
+		To:           to,
+		LookbackDays: lookbackDays,
+		Overdue:      []models.PartForecast{},
+		Days:         []models.ForecastDay{},
+	}
+	for _, forecast := range due {
+		if forecast.Overdue {
+			resp.Overdue = append(resp.Overdue, forecast)
+			continue
+		}
+		date := forecast.DueAt.UTC().Format("2006-01-02")
+		if n := len(resp.Days); n == 0 || resp.Days[n-1].Date != date {
+			resp.Days = append(resp.Days, models.ForecastDay{Date: date})
+		}
+		last := &resp.Days[len(resp.Days)-1]
+		last.Items = append(last.Items, forecast)
+	}
+
+	s.logger.InfoContext(ctx, "ForecastService: GetFleetForecast successful",
+		"due", len(due),
+		"overdue", len(resp.Overdue),
+	)
+
+	return resp, nil
+}
+
+func lookbackOrDefault(query *models.ForecastQuery) int {
+	if query.LookbackDays == 0 {
+		return models.DefaultForecastLookbackDays
+	}
+	return query.LookbackDays
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+type HealthService struct {
+	healthRepo      *repository.HealthRepository
+	expectedVersion int64
+	logger          *util.Logger
+}
+
+// NewHealthService takes the newest migration version the binary was built
+// with; readiness fails until the database has caught up with it.
+func NewHealthService(healthRepo *repository.HealthRepository, expectedVersion int64, logger *util.Logger) *HealthService {
+	return &HealthService{
+		healthRepo:      healthRepo,
+		expectedVersion: expectedVersion,
+		logger:          logger,
+	}
+}
+
+// Ready checks the database connection and schema version. Errors are
+// logged in full but reported in general terms, since the endpoint is
+// unauthenticated.
+func (s *HealthService) Ready(ctx context.Context) *models.ReadinessReport {
+	report := &models.ReadinessReport{
+		Database:   models.HealthCheck{Status: models.HealthStatusOK},
+		Migrations: models.MigrationCheck{Status: models.HealthStatusOK, Expected: s.expectedVersion},
+		CheckedAt:  time.Now(),
+	}
+
+	start := time.Now()
+	err := s.healthRepo.Ping(ctx)
+	report.Database.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "HealthService: Database unreachable",
+			"error", err,
+		)
+		report.Database.Status = models.HealthStatusFail
+		report.Database.Error = "database unreachable"
+		report.Migrations.Status = models.HealthStatusFail
+		report.Migrations.Error = "database unreachable"
+		return report
+	}
+
+	version, err := s.healthRepo.MigrationVersion(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "HealthService: Failed to read migration version",
+			"error", err,
+		)
+		report.Migrations.Status = models.HealthStatusFail
+		report.Migrations.Error = "migration version unavailable"
+		return report
+	}
+	report.Migrations.Current = version
+	if version < s.expectedVersion {
+		s.logger.WarnContext(ctx, "HealthService: Migrations pending",
+			"current", version,
+			"expected", s.expectedVersion,
+		)
+		report.Migrations.Status = models.HealthStatusFail
+		report.Migrations.Error = "migrations pending"
+		return report
+	}
+
+	report.Ready = true
+	return report
+}
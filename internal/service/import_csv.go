@@ -0,0 +1,191 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+// csvTable maps a CSV header onto column positions so files can list
+// columns in any order.
+type csvTable struct {
+	section string
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVTable(r io.Reader, section string, required, optional []string) (*csvTable, []models.ImportRowError) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, []models.ImportRowError{{Section: section, Message: "file is empty"}}
+	}
+	if err != nil {
+		return nil, []models.ImportRowError{{Section: section, Message: err.Error()}}
+	}
+
+	known := make(map[string]bool)
+	for _, name := range append(required, optional...) {
+		known[name] = true
+	}
+
+	var errs []models.ImportRowError
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !known[name] {
+			errs = append(errs, models.ImportRowError{Section: section, Field: name, Message: "unknown column"})
+			continue
+		}
+		if _, dup := columns[name]; dup {
+			errs = append(errs, models.ImportRowError{Section: section, Field: name, Message: "duplicate column"})
+			continue
+		}
+		columns[name] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			errs = append(errs, models.ImportRowError{Section: section, Field: name, Message: "missing column"})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &csvTable{section: section, reader: reader, columns: columns}, nil
+}
+
+// each calls fn for every data row with a 1-based row number, stopping with
+// an error once the section exceeds models.MaxImportRows.
+func (t *csvTable) each(fn func(row int, get func(string) string)) []models.ImportRowError {
+	var errs []models.ImportRowError
+	for row := 1; ; row++ {
+		record, err := t.reader.Read()
+		if errors.Is(err, io.EOF) {
+			return errs
+		}
+		if row > models.MaxImportRows {
+			return append(errs, models.ImportRowError{Section: t.section, Message: ImportTooLargeErr})
+		}
+		if err != nil {
+			// Keep the row's position with blank cells; validation skips
+			// rows that already have errors.
+			errs = append(errs, models.ImportRowError{Section: t.section, Row: row, Message: err.Error()})
+			record = nil
+		}
+
+		fn(row, func(name string) string {
+			i, ok := t.columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		})
+	}
+}
+
+// ParsePlanesCSV reads planes with the columns tail_number and model.
+func ParsePlanesCSV(r io.Reader) ([]models.ImportPlaneRow, []models.ImportRowError) {
+	table, errs := newCSVTable(r, models.ImportSectionPlanes, []string{"tail_number", "model"}, nil)
+	if errs != nil {
+		return nil, errs
+	}
+
+	var rows []models.ImportPlaneRow
+	errs = table.each(func(_ int, get func(string) string) {
+		rows = append(rows, models.ImportPlaneRow{TailNumber: get("tail_number"), Model: get("model")})
+	})
+	return rows, errs
+}
+
+// ParsePartsCSV reads parts. serial_number is the only required column;
+// part_name, category and usage_limit_hours may be left out when every row
+// gives a part_number. The other columns are optional and blank cells leave
+// the field unset.
+func ParsePartsCSV(r io.Reader) ([]models.ImportPartRow, []models.ImportRowError) {
+	table, errs := newCSVTable(r, models.ImportSectionParts,
+		[]string{"serial_number"},
+		[]string{"tail_number", "part_number", "part_name", "category", "usage_hours", "usage_limit_hours",
+			"usage_cycles", "usage_limit_cycles", "calendar_limit_days"})
+	if errs != nil {
+		return nil, errs
+	}
+
+	var rows []models.ImportPartRow
+	var cellErrs []models.ImportRowError
+	errs = table.each(func(row int, get func(string) string) {
+		part := models.ImportPartRow{
+			TailNumber:   get("tail_number"),
+			PartNumber:   get("part_number"),
+			PartName:     get("part_name"),
+			SerialNumber: get("serial_number"),
+			Category:     get("category"),
+		}
+
+		invalid := func(field string, err error) {
+			cellErrs = append(cellErrs, models.ImportRowError{Section: models.ImportSectionParts, Row: row, Field: field, Message: err.Error()})
+		}
+		if err := parseFloatCell(get("usage_hours"), &part.UsageHours); err != nil {
+			invalid("usage_hours", err)
+		}
+		if err := parseFloatCell(get("usage_limit_hours"), &part.UsageLimitHours); err != nil {
+			invalid("usage_limit_hours", err)
+		}
+		if err := parseIntCell(get("usage_cycles"), &part.UsageCycles); err != nil {
+			invalid("usage_cycles", err)
+		}
+		var err error
+		if part.UsageLimitCycles, err = parseOptionalIntCell(get("usage_limit_cycles")); err != nil {
+			invalid("usage_limit_cycles", err)
+		}
+		if part.CalendarLimitDays, err = parseOptionalIntCell(get("calendar_limit_days")); err != nil {
+			invalid("calendar_limit_days", err)
+		}
+
+		rows = append(rows, part)
+	})
+	return rows, append(cellErrs, errs...)
+}
+
+func parseFloatCell(cell string, dst *float64) error {
+	if cell == "" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(cell, 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", cell)
+	}
+	*dst = v
+	return nil
+}
+
+func parseIntCell(cell string, dst *int) error {
+	if cell == "" {
+		return nil
+	}
+	v, err := strconv.Atoi(cell)
+	if err != nil {
+		return fmt.Errorf("%q is not a whole number", cell)
+	}
+	*dst = v
+	return nil
+}
+
+func parseOptionalIntCell(cell string) (*int, error) {
+	if cell == "" {
+		return nil, nil
+	}
+	var v int
+	if err := parseIntCell(cell, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
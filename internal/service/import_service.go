@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const (
+	ImportEmptyErr    = "import contains no planes or parts"
+	ImportTooLargeErr = "import exceeds 5000 rows"
+)
+
+type ImportService struct {
+	tx            *repository.Transactor
+	importRepo    *repository.ImportRepository
+	planeRepo     *repository.PlaneRepository
+	planePartRepo *repository.PlanePartRepository
+	catalogRepo   *repository.CatalogPartRepository
+	airworthiness *AirworthinessService
+	audit         *AuditService
+	logger        *util.Logger
+}
+
+func NewImportService(tx *repository.Transactor, importRepo *repository.ImportRepository, planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, catalogRepo *repository.CatalogPartRepository, airworthiness *AirworthinessService, audit *AuditService, logger *util.Logger) *ImportService {
+	return &ImportService{
+		tx:            tx,
+		importRepo:    importRepo,
+		planeRepo:     planeRepo,
+		planePartRepo: planePartRepo,
+		catalogRepo:   catalogRepo,
+		airworthiness: airworthiness,
+		audit:         audit,
+		logger:        logger,
+	}
+}
+
+// ImportCSV parses the planes and parts files, either of which may be nil,
+// and imports them like Import.
+func (s *ImportService) ImportCSV(ctx context.Context, planesCSV, partsCSV io.Reader, dryRun bool, actorID int64) (*models.ImportResponse, error) {
+	var req models.ImportRequest
+	var errs []models.ImportRowError
+	if planesCSV != nil {
+		rows, rowErrs := ParsePlanesCSV(planesCSV)
+		req.Planes = rows
+		errs = append(errs, rowErrs...)
+	}
+	if partsCSV != nil {
+		rows, rowErrs := ParsePartsCSV(partsCSV)
+		req.Parts = rows
+		errs = append(errs, rowErrs...)
+	}
+
+	return s.run(ctx, &req, errs, dryRun, actorID)
+}
+
+// Import validates every row before writing anything and then creates all
+// planes and parts in one transaction. When any row is invalid, or on a dry
+// run, nothing is written and the response lists what would happen.
+func (s *ImportService) Import(ctx context.Context, req *models.ImportRequest, dryRun bool, actorID int64) (*models.ImportResponse, error) {
+	return s.run(ctx, req, nil, dryRun, actorID)
+}
+
+func (s *ImportService) run(ctx context.Context, req *models.ImportRequest, errs []models.ImportRowError, dryRun bool, actorID int64) (*models.ImportResponse, error) {
+	s.logger.InfoContext(ctx, "ImportService: Importing planes and parts",
+		"planes", len(req.Planes),
+		"parts", len(req.Parts),
+		"dry_run", dryRun,
+		"actor_id", actorID,
+	)
+
+	if len(req.Planes) == 0 && len(req.Parts) == 0 && len(errs) == 0 {
+		return nil, errors.New(ImportEmptyErr)
+	}
+	if len(req.Planes) > models.MaxImportRows || len(req.Parts) > models.MaxImportRows {
+		return nil, errors.New(ImportTooLargeErr)
+	}
+
+	errs = append(errs, ValidateImportRows(req, errs)...)
+	existingErrs, catalog, err := s.checkExisting(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	errs = append(errs, existingErrs...)
+	sortImportErrors(errs)
+
+	resp := &models.ImportResponse{
+		DryRun: dryRun,
+		Valid:  len(errs) == 0,
+		Planes: len(req.Planes),
+		Parts:  len(req.Parts),
+		Errors: errs,
+	}
+	if resp.Errors == nil {
+		resp.Errors = []models.ImportRowError{}
+	}
+
+	if !resp.Valid {
+		s.logger.WarnContext(ctx, "ImportService: Import rejected",
+			"errors", len(errs),
+		)
+		return resp, nil
+	}
+	if dryRun {
+		s.logger.InfoContext(ctx, "ImportService: Dry run successful",
+			"planes", resp.Planes,
+			"parts", resp.Parts,
+		)
+		return resp, nil
+	}
+
+	planes := make([]models.Plane, len(req.Planes))
+	for i, row := range req.Planes {
+		planes[i] = models.Plane{TailNumber: row.TailNumber, Model: row.Model, Status: models.PlaneStatusActive}
+	}
+	parts := make([]models.PlanePart, len(req.Parts))
+	partTails := make([]string, len(req.Parts))
+	for i, row := range req.Parts {
+		parts[i] = importedPart(row)
+		if catalogPart := catalog[row.PartNumber]; catalogPart != nil {
+			catalogPart.Inherit(&parts[i])
+		}
+		partTails[i] = row.TailNumber
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.importRepo.Import(ctx, planes, parts, partTails, actorRef(actorID)); err != nil {
+			return err
+		}
+
+		changes := make([]AuditChange, 0, len(planes)+len(parts))
+		for i := range planes {
+			changes = append(changes, AuditChange{EntityType: models.AuditEntityPlane, EntityID: planes[i].ID, Action: models.AuditActionImport, After: planes[i]})
+		}
+		for i := range parts {
+			changes = append(changes, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: parts[i].ID, Action: models.AuditActionImport, After: parts[i]})
+		}
+		if err := s.audit.Record(ctx, changes...); err != nil {
+			return err
+		}
+
+		// Imported parts may already be past their limits.
+		grounded := make(map[int64]bool)
+		for i := range parts {
+			if planeID := parts[i].PlaneID; planeID != nil && !grounded[*planeID] && parts[i].OverLimit(time.Now()) {
+				grounded[*planeID] = true
+				if _, err := s.airworthiness.GroundIfOverLimit(ctx, *planeID, actorID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ImportService: Failed to import",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to import: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "ImportService: Import successful",
+		"planes", resp.Planes,
+		"parts", resp.Parts,
+	)
+
+	return resp, nil
+}
+
+// ValidateImportRows applies the create-request field rules to every row and
+// rejects tail numbers and serial numbers repeated within the import. Rows
+// that already appear in prior, such as CSV cells that failed to parse, are
+// not checked again.
+func ValidateImportRows(req *models.ImportRequest, prior []models.ImportRowError) []models.ImportRowError {
+	skip := make(map[string]map[int]bool)
+	for _, e := range prior {
+		if skip[e.Section] == nil {
+			skip[e.Section] = make(map[int]bool)
+		}
+		skip[e.Section][e.Row] = true
+	}
+
+	var errs []models.ImportRowError
+	add := func(section string, row int, field, message string) {
+		errs = append(errs, models.ImportRowError{Section: section, Row: row, Field: field, Message: message})
+	}
+
+	tails := make(map[string]int)
+	for i, plane := range req.Planes {
+		row := i + 1
+		if skip[models.ImportSectionPlanes][row] {
+			continue
+		}
+		checkLength(add, models.ImportSectionPlanes, row, "tail_number", plane.TailNumber, 2, 50)
+		checkLength(add, models.ImportSectionPlanes, row, "model", plane.Model, 2, 100)
+		if first, dup := tails[plane.TailNumber]; dup {
+			add(models.ImportSectionPlanes, row, "tail_number", fmt.Sprintf("duplicates row %d", first))
+		} else if plane.TailNumber != "" {
+			tails[plane.TailNumber] = row
+		}
+	}
+
+	serials := make(map[string]int)
+	for i, part := range req.Parts {
+		row := i + 1
+		if skip[models.ImportSectionParts][row] {
+			continue
+		}
+		// As on create, a catalog part takes its name and category from the
+		// catalog and its hours limit defaults to the catalog's.
+		if part.PartNumber == "" {
+			checkLength(add, models.ImportSectionParts, row, "part_name", part.PartName, 2, 255)
+			checkLength(add, models.ImportSectionParts, row, "category", part.Category, 2, 150)
+		} else {
+			checkLength(add, models.ImportSectionParts, row, "part_number", part.PartNumber, 2, 100)
+			if part.PartName != "" {
+				add(models.ImportSectionParts, row, "part_name", "not allowed with part_number")
+			}
+			if part.Category != "" {
+				add(models.ImportSectionParts, row, "category", "not allowed with part_number")
+			}
+		}
+		checkLength(add, models.ImportSectionParts, row, "serial_number", part.SerialNumber, 2, 100)
+		if part.UsageHours < 0 {
+			add(models.ImportSectionParts, row, "usage_hours", "must not be negative")
+		}
+		if part.UsageLimitHours < 0 || (part.UsageLimitHours == 0 && part.PartNumber == "") {
+			add(models.ImportSectionParts, row, "usage_limit_hours", "must be greater than 0")
+		}
+		if part.UsageCycles < 0 {
+			add(models.ImportSectionParts, row, "usage_cycles", "must not be negative")
+		}
+		if part.UsageLimitCycles != nil && *part.UsageLimitCycles <= 0 {
+			add(models.ImportSectionParts, row, "usage_limit_cycles", "must be greater than 0")
+		}
+		if part.CalendarLimitDays != nil && *part.CalendarLimitDays <= 0 {
+			add(models.ImportSectionParts, row, "calendar_limit_days", "must be greater than 0")
+		}
+		if first, dup := serials[part.SerialNumber]; dup {
+			add(models.ImportSectionParts, row, "serial_number", fmt.Sprintf("duplicates row %d", first))
+		} else if part.SerialNumber != "" {
+			serials[part.SerialNumber] = row
+		}
+	}
+
+	return errs
+}
+
+func checkLength(add func(section string, row int, field, message string), section string, row int, field, value string, min, max int) {
+	n := len([]rune(value))
+	switch {
+	case strings.TrimSpace(value) == "":
+		add(section, row, field, "is required")
+	case n < min || n > max:
+		add(section, row, field, fmt.Sprintf("must be %d to %d characters", min, max))
+	}
+}
+
+// checkExisting rejects planes and parts that already exist, deleted ones
+// included, and parts whose tail number matches neither a plane in the
+// import nor a live one in the fleet. It also checks the rows' catalog
+// links and returns the catalog entries they name by part number.
+func (s *ImportService) checkExisting(ctx context.Context, req *models.ImportRequest) ([]models.ImportRowError, map[string]*models.CatalogPart, error) {
+	var errs []models.ImportRowError
+
+	importedTails := make(map[string]bool)
+	tailModels := make(map[string]string)
+	var tails []string
+	for _, plane := range req.Planes {
+		importedTails[plane.TailNumber] = true
+		tailModels[plane.TailNumber] = plane.Model
+		tails = append(tails, plane.TailNumber)
+	}
+	for _, part := range req.Parts {
+		if part.TailNumber != "" && !importedTails[part.TailNumber] {
+			tails = append(tails, part.TailNumber)
+		}
+	}
+
+	existingTails := make(map[string]bool)
+	liveTails := make(map[string]bool)
+	if len(tails) > 0 {
+		planes, err := s.planeRepo.GetByTailNumbers(ctx, tails)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "ImportService: Failed to look up planes",
+				"error", err,
+			)
+			return nil, nil, fmt.Errorf("failed to look up planes: %w", err)
+		}
+		for _, plane := range planes {
+			existingTails[plane.TailNumber] = true
+			liveTails[plane.TailNumber] = !plane.DeletedAt.Valid
+			if !plane.DeletedAt.Valid && !importedTails[plane.TailNumber] {
+				tailModels[plane.TailNumber] = plane.Model
+			}
+		}
+	}
+
+	var serials []string
+	for _, part := range req.Parts {
+		serials = append(serials, part.SerialNumber)
+	}
+	existingSerials := make(map[string]bool)
+	if len(serials) > 0 {
+		parts, err := s.planePartRepo.GetBySerialNumbers(ctx, serials)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "ImportService: Failed to look up parts",
+				"error", err,
+			)
+			return nil, nil, fmt.Errorf("failed to look up parts: %w", err)
+		}
+		for _, part := range parts {
+			existingSerials[part.SerialNumber] = true
+		}
+	}
+
+	for i, plane := range req.Planes {
+		if existingTails[plane.TailNumber] {
+			errs = append(errs, models.ImportRowError{Section: models.ImportSectionPlanes, Row: i + 1, Field: "tail_number", Message: PlaneExistsErr})
+		}
+	}
+	for i, part := range req.Parts {
+		if existingSerials[part.SerialNumber] {
+			errs = append(errs, models.ImportRowError{Section: models.ImportSectionParts, Row: i + 1, Field: "serial_number", Message: PlanePartExistsErr})
+		}
+		if part.TailNumber != "" && !importedTails[part.TailNumber] && !liveTails[part.TailNumber] {
+			errs = append(errs, models.ImportRowError{Section: models.ImportSectionParts, Row: i + 1, Field: "tail_number", Message: PlaneNotFoundErrPart})
+		}
+	}
+
+	catalog, catalogErrs, err := s.checkCatalog(ctx, req, tailModels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(errs, catalogErrs...), catalog, nil
+}
+
+// checkCatalog looks up the part numbers the rows name and rejects rows
+// whose entry doesn't exist, doesn't fit the plane's model, or has defaults
+// looser than the row's limits.
+func (s *ImportService) checkCatalog(ctx context.Context, req *models.ImportRequest, tailModels map[string]string) (map[string]*models.CatalogPart, []models.ImportRowError, error) {
+	var partNumbers []string
+	for _, part := range req.Parts {
+		if part.PartNumber != "" {
+			partNumbers = append(partNumbers, part.PartNumber)
+		}
+	}
+	catalog := make(map[string]*models.CatalogPart)
+	if len(partNumbers) == 0 {
+		return catalog, nil, nil
+	}
+
+	catalogParts, err := s.catalogRepo.GetByPartNumbers(ctx, partNumbers)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "ImportService: Failed to look up catalog parts",
+			"error", err,
+		)
+		return nil, nil, fmt.Errorf("failed to look up catalog parts: %w", err)
+	}
+	for i := range catalogParts {
+		catalog[catalogParts[i].PartNumber] = &catalogParts[i]
+	}
+
+	var errs []models.ImportRowError
+	for i, row := range req.Parts {
+		if row.PartNumber == "" {
+			continue
+		}
+		add := func(message string) {
+			errs = append(errs, models.ImportRowError{Section: models.ImportSectionParts, Row: i + 1, Field: "part_number", Message: message})
+		}
+
+		catalogPart := catalog[row.PartNumber]
+		if catalogPart == nil {
+			add(CatalogPartNotFoundErr)
+			continue
+		}
+		if model, ok := tailModels[row.TailNumber]; ok && row.TailNumber != "" && !catalogPart.AppliesTo(model) {
+			add(CatalogModelErr)
+		}
+		part := importedPart(row)
+		catalogPart.Inherit(&part)
+		if !catalogPart.WithinDefaults(&part) {
+			add(CatalogLimitErr)
+		}
+	}
+
+	return catalog, errs, nil
+}
+
+func importedPart(row models.ImportPartRow) models.PlanePart {
+	return models.PlanePart{
+		PartName:          row.PartName,
+		SerialNumber:      row.SerialNumber,
+		Category:          row.Category,
+		UsageHours:        row.UsageHours,
+		UsageLimitHours:   row.UsageLimitHours,
+		UsageCycles:       row.UsageCycles,
+		UsageLimitCycles:  row.UsageLimitCycles,
+		CalendarLimitDays: row.CalendarLimitDays,
+	}
+}
+
+// sortImportErrors orders errors planes first, then by row.
+func sortImportErrors(errs []models.ImportRowError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Section != errs[j].Section {
+			return errs[i].Section == models.ImportSectionPlanes
+		}
+		return errs[i].Row < errs[j].Row
+	})
+}
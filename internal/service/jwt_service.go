@@ -3,16 +3,16 @@ package service
 import (
 	"errors"
 	"fmt"
-	"log"
-	"os"
-	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/config"
 )
 
 const (
-	CookieName = "auth_token"
+	CookieName        = "auth_token"
+	RefreshCookieName = "refresh_token"
 )
 
 var (
@@ -21,45 +21,35 @@ var (
 )
 
 type JWTClaims struct {
-	UserID int64  `json:"user_id"`
-	Name   string `json:"name"`
-	Role   string `json:"role"`
+	UserID    int64  `json:"user_id"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	SessionID int64  `json:"sid"`
 	jwt.RegisteredClaims
 }
 
+// JWTService signs short-lived access tokens. Long-lived logins are kept
+// alive through refresh tokens handled by SessionService.
 type JWTService struct {
-	secretKey   []byte
-	expiryHours int
+	secretKey []byte
+	expiry    time.Duration
 }
 
-func NewJWTService() *JWTService {
-	secret := os.Getenv("SECRET")
-	if secret == "" {
-		log.Fatal("SECRET environment variable is required for JWTService")
-	}
-
-	expiryHours := 24 
-	if exp := os.Getenv("TOKEN_EXP"); exp != "" {
-		var err error
-		expiryHours, err = strconv.Atoi(exp)
-		if err != nil {
-			expiryHours = 24
-		}
-	}
-
+func NewJWTService(cfg config.AuthConfig) *JWTService {
 	return &JWTService{
-		secretKey:   []byte(secret),
-		expiryHours: expiryHours,
+		secretKey: []byte(cfg.Secret),
+		expiry:    cfg.AccessTokenExpiry,
 	}
 }
 
-func (s *JWTService) GenerateToken(userID int64, name, role string) (string, error) {
+func (s *JWTService) GenerateToken(userID int64, name, role string, sessionID int64) (string, error) {
 	claims := JWTClaims{
-		UserID: userID,
-		Name:   name,
-		Role:   role,
+		UserID:    userID,
+		Name:      name,
+		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(s.expiryHours) * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "aircraft-system",
@@ -95,5 +85,5 @@ func (s *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
 }
 
 func (s *JWTService) GetExpiryDuration() time.Duration {
-	return time.Duration(s.expiryHours) * time.Hour
+	return s.expiry
 }
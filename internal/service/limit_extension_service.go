@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const (
+	LimitExtensionNotFoundErr   = "limit extension not found"
+	LimitExtensionReviewedErr   = "limit extension has already been reviewed"
+	LimitExtensionSelfReviewErr = "limit extensions must be reviewed by someone other than the requester"
+)
+
+// LimitExtensionService handles requests for extra hours on a part's life
+// limit. Mechanics raise them; admins approve or reject them. Only approved
+// extensions count towards the part's effective limit.
+type LimitExtensionService struct {
+	tx            *repository.Transactor
+	extensionRepo *repository.LimitExtensionRepository
+	planePartRepo *repository.PlanePartRepository
+	audit         *AuditService
+	logger        *util.Logger
+}
+
+func NewLimitExtensionService(tx *repository.Transactor, extensionRepo *repository.LimitExtensionRepository, planePartRepo *repository.PlanePartRepository, audit *AuditService, logger *util.Logger) *LimitExtensionService {
+	return &LimitExtensionService{
+		tx:            tx,
+		extensionRepo: extensionRepo,
+		planePartRepo: planePartRepo,
+		audit:         audit,
+		logger:        logger,
+	}
+}
+
+func (s *LimitExtensionService) RequestExtension(ctx context.Context, partID int64, req *models.CreateLimitExtensionRequest, actorID int64) (*models.PartLimitExtensionResponse, error) {
+	s.logger.InfoContext(ctx, "LimitExtensionService: RequestExtension",
+		"part_id", partID,
+		"requested_hours", req.RequestedHours,
+	)
+
+	if _, err := s.getPart(ctx, partID); err != nil {
+		return nil, err
+	}
+
+	extension := &models.PartLimitExtension{
+		PartID:         partID,
+		RequestedHours: req.RequestedHours,
+		Justification:  req.Justification,
+		Status:         models.ExtensionStatusPending,
+		RequestedBy:    actorRef(actorID),
+	}
+	err := s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.extensionRepo.Create(ctx, extension); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityExtension, EntityID: extension.ID, Action: models.AuditActionCreate, After: extension})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "LimitExtensionService: Failed to create extension",
+			"part_id", partID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to create limit extension: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "LimitExtensionService: RequestExtension successful",
+		"extension_id", extension.ID,
+		"part_id", partID,
+	)
+
+	resp := extension.ToResponse()
+	return &resp, nil
+}
+
+func (s *LimitExtensionService) GetExtension(ctx context.Context, id int64) (*models.PartLimitExtensionResponse, error) {
+	s.logger.InfoContext(ctx, "LimitExtensionService: GetExtension",
+		"extension_id", id,
+	)
+
+	extension, err := s.getExtension(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := extension.ToResponse()
+	return &resp, nil
+}
+
+func (s *LimitExtensionService) ListExtensions(ctx context.Context, query *models.LimitExtensionQuery) (*models.PaginatedResponse[models.PartLimitExtensionResponse], error) {
+	s.logger.InfoContext(ctx, "LimitExtensionService: ListExtensions",
+		"status", query.Status,
+		"part_id", query.PartID,
+	)
+
+	query.Normalize()
+	extensions, total, err := s.extensionRepo.List(ctx, query.Status, query.PartID, query.Offset(), query.PageSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "LimitExtensionService: Failed to list extensions",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to list limit extensions: %w", err)
+	}
+
+	responses := make([]models.PartLimitExtensionResponse, len(extensions))
+	for i, extension := range extensions {
+		responses[i] = extension.ToResponse()
+	}
+
+	return &models.PaginatedResponse[models.PartLimitExtensionResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+func (s *LimitExtensionService) ApproveExtension(ctx context.Context, id int64, req *models.ReviewLimitExtensionRequest, actorID int64) (*models.PartLimitExtensionResponse, error) {
+	return s.review(ctx, id, models.ExtensionStatusApproved, req, actorID)
+}
+
+func (s *LimitExtensionService) RejectExtension(ctx context.Context, id int64, req *models.ReviewLimitExtensionRequest, actorID int64) (*models.PartLimitExtensionResponse, error) {
+	return s.review(ctx, id, models.ExtensionStatusRejected, req, actorID)
+}
+
+// review settles a pending extension. An approval raises the part's
+// effective limit but leaves a grounded plane grounded; it returns to
+// service through maintenance like any other grounding.
+func (s *LimitExtensionService) review(ctx context.Context, id int64, status string, req *models.ReviewLimitExtensionRequest, actorID int64) (*models.PartLimitExtensionResponse, error) {
+	s.logger.InfoContext(ctx, "LimitExtensionService: Review",
+		"extension_id", id,
+		"status", status,
+		"actor_id", actorID,
+	)
+
+	extension, err := s.getExtension(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if extension.Status != models.ExtensionStatusPending {
+		s.logger.WarnContext(ctx, "LimitExtensionService: Extension already reviewed",
+			"extension_id", id,
+			"status", extension.Status,
+		)
+		return nil, errors.New(LimitExtensionReviewedErr)
+	}
+	if extension.RequestedBy != nil && *extension.RequestedBy == actorID {
+		s.logger.WarnContext(ctx, "LimitExtensionService: Requester cannot review own extension",
+			"extension_id", id,
+			"actor_id", actorID,
+		)
+		return nil, errors.New(LimitExtensionSelfReviewErr)
+	}
+
+	part, err := s.getPart(ctx, extension.PartID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *extension
+	now := time.Now()
+	extension.Status = status
+	extension.ReviewedBy = actorRef(actorID)
+	extension.ReviewedAt = &now
+	if req.Note != "" {
+		extension.ReviewNote = &req.Note
+	}
+
+	var reviewed bool
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		reviewed, err = s.extensionRepo.Review(ctx, extension)
+		if err != nil || !reviewed {
+			return err
+		}
+
+		changes := []AuditChange{{EntityType: models.AuditEntityExtension, EntityID: id, Action: models.AuditActionReject, Before: before, After: extension}}
+		if status == models.ExtensionStatusApproved {
+			changes[0].Action = models.AuditActionApprove
+			partBefore := *part
+			part.ExtensionHours += extension.RequestedHours
+			changes = append(changes, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: part.ID, Action: models.AuditActionUpdate, Before: partBefore, After: part})
+		}
+		return s.audit.Record(ctx, changes...)
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "LimitExtensionService: Failed to review extension",
+			"extension_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to review limit extension: %w", err)
+	}
+	if !reviewed {
+		s.logger.WarnContext(ctx, "LimitExtensionService: Extension reviewed concurrently",
+			"extension_id", id,
+		)
+		return nil, errors.New(LimitExtensionReviewedErr)
+	}
+
+	s.logger.InfoContext(ctx, "LimitExtensionService: Review successful",
+		"extension_id", id,
+		"status", status,
+	)
+
+	resp := extension.ToResponse()
+	return &resp, nil
+}
+
+func (s *LimitExtensionService) getExtension(ctx context.Context, id int64) (*models.PartLimitExtension, error) {
+	extension, err := s.extensionRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "LimitExtensionService: Failed to get extension",
+			"extension_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get limit extension: %w", err)
+	}
+	if extension == nil {
+		s.logger.WarnContext(ctx, "LimitExtensionService: Extension not found",
+			"extension_id", id,
+		)
+		return nil, errors.New(LimitExtensionNotFoundErr)
+	}
+	return extension, nil
+}
+
+func (s *LimitExtensionService) getPart(ctx context.Context, partID int64) (*models.PlanePart, error) {
+	part, err := s.planePartRepo.GetByID(ctx, partID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "LimitExtensionService: Failed to get part",
+			"part_id", partID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get part: %w", err)
+	}
+	if part == nil {
+		s.logger.WarnContext(ctx, "LimitExtensionService: Part not found",
+			"part_id", partID,
+		)
+		return nil, errors.New(PlanePartNotFoundErr)
+	}
+	return part, nil
+}
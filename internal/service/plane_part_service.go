@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/JasperRosales/aircraft-system-be/internal/models"
 	"github.com/JasperRosales/aircraft-system-be/internal/repository"
@@ -13,27 +14,62 @@ import (
 const (
 	PlanePartNotFoundErr = "plane part not found"
 	PlanePartExistsErr   = "plane part with this serial number already exists"
-	InvalidUsageHoursErr = "usage hours cannot exceed limit"
+	NegativeUsageErr     = "usage hours cannot be negative"
+	NegativeCyclesErr    = "usage cycles cannot be negative"
+	EmptyUsageEntryErr   = "usage entry must change hours or cycles"
+	PartInstalledErr     = "plane part is already installed"
+	PartNotInstalledErr  = "plane part is not installed"
 	PlaneNotMatchErr     = "plane part does not belong to this plane"
 	PlaneNotFoundErrPart = "plane not found"
+	PartNotDeletedErr    = "plane part is not deleted"
+	PartHasHistoryErr    = "plane part has recorded history and cannot be purged"
+	PartPlaneDeletedErr  = "plane part's plane is deleted; restore the plane first"
+	LimitRaiseErr        = "usage limit hours can only be raised through an approved limit extension"
+	CyclesLimitRaiseErr  = "usage limit cycles cannot be raised"
+	CalendarRaiseErr     = "calendar limit days cannot be raised"
 )
 
+// defaultMaintenanceThreshold is the life-used percentage at which parts show
+// up in maintenance alerts when no threshold is given.
+const defaultMaintenanceThreshold = 80.0
+
 type PlanePartService struct {
 	planeRepo     *repository.PlaneRepository
 	planePartRepo *repository.PlanePartRepository
+	usageRepo     *repository.PartUsageRepository
+	installRepo   *repository.PartInstallationRepository
+	catalogRepo   *repository.CatalogPartRepository
+	airworthiness *AirworthinessService
+	tx            *repository.Transactor
+	audit         *AuditService
 	logger        *util.Logger
 }
 
-func NewPlanePartService(planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, logger *util.Logger) *PlanePartService {
+func NewPlanePartService(tx *repository.Transactor, planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, usageRepo *repository.PartUsageRepository, installRepo *repository.PartInstallationRepository, catalogRepo *repository.CatalogPartRepository, airworthiness *AirworthinessService, audit *AuditService, logger *util.Logger) *PlanePartService {
 	return &PlanePartService{
+		tx:            tx,
 		planeRepo:     planeRepo,
 		planePartRepo: planePartRepo,
+		usageRepo:     usageRepo,
+		installRepo:   installRepo,
+		catalogRepo:   catalogRepo,
+		airworthiness: airworthiness,
+		audit:         audit,
 		logger:        logger,
 	}
 }
 
-func (s *PlanePartService) AddPart(ctx context.Context, req *models.CreatePlanePartRequest) (*models.PlanePartResponse, error) {
-	s.logger.Info("PlanePartService: Adding new part to plane",
+// actorRef converts the authenticated user ID into a nullable reference;
+// zero means the action was not attributable to a user.
+func actorRef(userID int64) *int64 {
+	if userID == 0 {
+		return nil
+	}
+	return &userID
+}
+
+func (s *PlanePartService) AddPart(ctx context.Context, req *models.CreatePlanePartRequest, actorID int64) (*models.PlanePartResponse, error) {
+	s.logger.InfoContext(ctx, "PlanePartService: Adding new part to plane",
 		"plane_id", req.PlaneID,
 		"part_name", req.PartName,
 		"serial_number", req.SerialNumber,
@@ -41,52 +77,78 @@ func (s *PlanePartService) AddPart(ctx context.Context, req *models.CreatePlaneP
 
 	plane, err := s.planeRepo.GetByID(ctx, req.PlaneID)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to verify plane",
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to verify plane",
 			"plane_id", req.PlaneID,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to verify plane: %w", err)
 	}
 	if plane == nil {
-		s.logger.Warn("PlanePartService: Plane not found",
+		s.logger.WarnContext(ctx, "PlanePartService: Plane not found",
 			"plane_id", req.PlaneID,
 		)
 		return nil, errors.New(PlaneNotFoundErrPart)
 	}
 
-	existing, err := s.planePartRepo.GetBySerialNumber(ctx, req.SerialNumber)
+	existing, err := s.planePartRepo.GetBySerialNumberWithDeleted(ctx, req.SerialNumber)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to check existing part",
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to check existing part",
 			"serial_number", req.SerialNumber,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to check existing part: %w", err)
 	}
 	if existing != nil {
-		s.logger.Warn("PlanePartService: Part with serial number already exists",
+		s.logger.WarnContext(ctx, "PlanePartService: Part with serial number already exists",
 			"serial_number", req.SerialNumber,
 		)
 		return nil, errors.New(PlanePartExistsErr)
 	}
 
 	part := &models.PlanePart{
-		PlaneID:         req.PlaneID,
-		PartName:        req.PartName,
-		SerialNumber:    req.SerialNumber,
-		Category:        req.Category,
-		UsageHours:      req.UsageHours,
-		UsageLimitHours: req.UsageLimitHours,
+		PlaneID:           &req.PlaneID,
+		PartName:          req.PartName,
+		SerialNumber:      req.SerialNumber,
+		Category:          req.Category,
+		UsageHours:        req.UsageHours,
+		UsageLimitHours:   req.UsageLimitHours,
+		UsageCycles:       req.UsageCycles,
+		UsageLimitCycles:  req.UsageLimitCycles,
+		CalendarLimitDays: req.CalendarLimitDays,
+	}
+	if req.CatalogPartID != nil {
+		catalogPart, err := s.getApplicableCatalogPart(ctx, *req.CatalogPartID, plane)
+		if err != nil {
+			return nil, err
+		}
+		catalogPart.Inherit(part)
+		if !catalogPart.WithinDefaults(part) {
+			s.logger.WarnContext(ctx, "PlanePartService: Part limits exceed catalog defaults",
+				"catalog_part_id", catalogPart.ID,
+				"serial_number", req.SerialNumber,
+			)
+			return nil, errors.New(CatalogLimitErr)
+		}
 	}
 
-	if err := s.planePartRepo.Create(ctx, part); err != nil {
-		s.logger.Error("PlanePartService: Failed to create part",
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.planePartRepo.Create(ctx, part, actorRef(actorID)); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: part.ID, Action: models.AuditActionCreate, After: part}); err != nil {
+			return err
+		}
+		return s.groundIfOverLimit(ctx, part, actorID)
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to create part",
 			"serial_number", req.SerialNumber,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to create part: %w", err)
 	}
 
-	s.logger.Info("PlanePartService: Part added successfully",
+	s.logger.InfoContext(ctx, "PlanePartService: Part added successfully",
 		"part_id", part.ID,
 		"plane_id", req.PlaneID,
 		"serial_number", req.SerialNumber,
@@ -97,20 +159,20 @@ func (s *PlanePartService) AddPart(ctx context.Context, req *models.CreatePlaneP
 }
 
 func (s *PlanePartService) GetPart(ctx context.Context, id int64) (*models.PlanePartResponse, error) {
-	s.logger.Info("PlanePartService: GetPart",
+	s.logger.InfoContext(ctx, "PlanePartService: GetPart",
 		"part_id", id,
 	)
 
 	part, err := s.planePartRepo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to get part",
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get part",
 			"part_id", id,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get part: %w", err)
 	}
 	if part == nil {
-		s.logger.Warn("PlanePartService: Part not found",
+		s.logger.WarnContext(ctx, "PlanePartService: Part not found",
 			"part_id", id,
 		)
 		return nil, errors.New(PlanePartNotFoundErr)
@@ -120,105 +182,170 @@ func (s *PlanePartService) GetPart(ctx context.Context, id int64) (*models.Plane
 	return &resp, nil
 }
 
-func (s *PlanePartService) GetPartsByPlane(ctx context.Context, planeID int64, category *string) ([]models.PlanePartResponse, error) {
-	s.logger.Info("PlanePartService: GetPartsByPlane",
+func (s *PlanePartService) GetPartsByPlane(ctx context.Context, planeID int64, query *models.PartQuery) (*models.PaginatedResponse[models.PlanePartResponse], error) {
+	s.logger.InfoContext(ctx, "PlanePartService: GetPartsByPlane",
 		"plane_id", planeID,
 	)
 
 	// Verify plane exists
 	plane, err := s.planeRepo.GetByID(ctx, planeID)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to verify plane",
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to verify plane",
 			"plane_id", planeID,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to verify plane: %w", err)
 	}
 	if plane == nil {
-		s.logger.Warn("PlanePartService: Plane not found",
+		s.logger.WarnContext(ctx, "PlanePartService: Plane not found",
 			"plane_id", planeID,
 		)
 		return nil, errors.New(PlaneNotFoundErrPart)
 	}
 
-	var parts []models.PlanePart
-	if category != nil && *category != "" {
-		parts, err = s.planePartRepo.GetByPlaneIDAndCategory(ctx, planeID, *category)
-		if err != nil {
-			s.logger.Error("PlanePartService: Failed to get parts by category",
-				"plane_id", planeID,
-				"category", *category,
-				"error", err,
-			)
-			return nil, fmt.Errorf("failed to get parts: %w", err)
-		}
-	} else {
-		parts, err = s.planePartRepo.GetByPlaneID(ctx, planeID)
-		if err != nil {
-			s.logger.Error("PlanePartService: Failed to get parts",
-				"plane_id", planeID,
-				"error", err,
-			)
-			return nil, fmt.Errorf("failed to get parts: %w", err)
-		}
-	}
+	query.PlaneID = &planeID
+	return s.listParts(ctx, "GetPartsByPlane", query)
+}
 
-	s.logger.Info("PlanePartService: GetPartsByPlane successful",
-		"plane_id", planeID,
-		"count", len(parts),
+func (s *PlanePartService) GetAllParts(ctx context.Context, query *models.PartQuery) (*models.PaginatedResponse[models.PlanePartResponse], error) {
+	s.logger.InfoContext(ctx, "PlanePartService: GetAllParts",
+		"category", query.Category,
+		"sort", query.Sort,
 	)
 
-	responses := make([]models.PlanePartResponse, len(parts))
-	for i, part := range parts {
-		responses[i] = part.ToResponse()
-	}
-
-	return responses, nil
+	return s.listParts(ctx, "GetAllParts", query)
 }
 
-func (s *PlanePartService) GetAllParts(ctx context.Context) ([]models.PlanePartResponse, error) {
-	s.logger.Info("PlanePartService: GetAllParts")
-
-	parts, err := s.planePartRepo.GetAll(ctx)
+// listParts runs a filtered, paginated part query on behalf of op.
+func (s *PlanePartService) listParts(ctx context.Context, op string, query *models.PartQuery) (*models.PaginatedResponse[models.PlanePartResponse], error) {
+	query.Normalize()
+	parts, total, err := s.planePartRepo.List(ctx, query)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to get all parts",
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to list parts",
+			"op", op,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to get all parts: %w", err)
+		return nil, fmt.Errorf("failed to get parts: %w", err)
 	}
 
-	s.logger.Info("PlanePartService: GetAllParts successful",
+	s.logger.InfoContext(ctx, "PlanePartService: "+op+" successful",
 		"count", len(parts),
+		"total", total,
 	)
 
+	return partPage(parts, total, query), nil
+}
+
+func partPage(parts []models.PlanePart, total int64, query *models.PartQuery) *models.PaginatedResponse[models.PlanePartResponse] {
 	responses := make([]models.PlanePartResponse, len(parts))
 	for i, part := range parts {
 		responses[i] = part.ToResponse()
 	}
 
-	return responses, nil
+	return &models.PaginatedResponse[models.PlanePartResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
 }
 
-func (s *PlanePartService) UpdatePart(ctx context.Context, id int64, req *models.UpdatePlanePartRequest) (*models.PlanePartResponse, error) {
-	s.logger.Info("PlanePartService: UpdatePart",
+func (s *PlanePartService) UpdatePart(ctx context.Context, id int64, req *models.UpdatePlanePartRequest, actorID int64) (*models.PlanePartResponse, error) {
+	s.logger.InfoContext(ctx, "PlanePartService: UpdatePart",
 		"part_id", id,
 	)
 
-	part, err := s.planePartRepo.GetByID(ctx, id)
+	// The part stays locked from the read to the write, so a flight, usage
+	// entry or installation can't land in between and be checked against
+	// stale limits.
+	var part *models.PlanePart
+	err := s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		part, err = s.planePartRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if part == nil {
+			s.logger.WarnContext(ctx, "PlanePartService: Part not found",
+				"part_id", id,
+			)
+			return errors.New(PlanePartNotFoundErr)
+		}
+		before := *part
+
+		if err := s.applyPartUpdate(ctx, part, req); err != nil {
+			return err
+		}
+		if err := s.planePartRepo.Update(ctx, part); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: id, Action: models.AuditActionUpdate, Before: before, After: part}); err != nil {
+			return err
+		}
+		// Tightening a limit can put an installed part past it.
+		return s.groundIfOverLimit(ctx, part, actorID)
+	})
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to get part",
+		switch err.Error() {
+		case PlanePartNotFoundErr, PlanePartExistsErr, PlaneNotFoundErrPart,
+			LimitRaiseErr, CyclesLimitRaiseErr, CalendarRaiseErr,
+			CatalogPartNotFoundErr, CatalogModelErr, CatalogFieldErr, CatalogLimitErr, CatalogRelinkErr:
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to update part",
 			"part_id", id,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to get part: %w", err)
+		return nil, fmt.Errorf("failed to update part: %w", err)
 	}
-	if part == nil {
-		s.logger.Warn("PlanePartService: Part not found",
-			"part_id", id,
-		)
-		return nil, errors.New(PlanePartNotFoundErr)
+
+	s.logger.InfoContext(ctx, "PlanePartService: UpdatePart successful",
+		"part_id", id,
+	)
+
+	resp := part.ToResponse()
+	return &resp, nil
+}
+
+// applyPartUpdate checks req against the part and its catalog entry and
+// applies it to part.
+func (s *PlanePartService) applyPartUpdate(ctx context.Context, part *models.PlanePart, req *models.UpdatePlanePartRequest) error {
+	// A part added without a catalog entry can be linked to one later. It
+	// then takes the entry's name and category and is held to its defaults
+	// like a part created from the catalog.
+	var catalogPart *models.CatalogPart
+	var err error
+	if req.CatalogPartID != nil && (part.CatalogPartID == nil || *part.CatalogPartID != *req.CatalogPartID) {
+		if part.CatalogPartID != nil {
+			s.logger.WarnContext(ctx, "PlanePartService: Refusing to relink catalog part",
+				"part_id", part.ID,
+				"catalog_part_id", *part.CatalogPartID,
+				"requested", *req.CatalogPartID,
+			)
+			return errors.New(CatalogRelinkErr)
+		}
+		catalogPart, err = s.getLinkableCatalogPart(ctx, *req.CatalogPartID, part)
+		if err != nil {
+			return err
+		}
+		catalogPart.Inherit(part)
+	} else if part.CatalogPartID != nil {
+		catalogPart, err = s.getCatalogPart(ctx, *part.CatalogPartID)
+		if err != nil {
+			return err
+		}
 	}
 
+	// Catalog parts keep the catalog's name and category.
+	renamed := req.PartName != nil && *req.PartName != part.PartName
+	recategorized := req.Category != nil && *req.Category != part.Category
+	if part.CatalogPartID != nil && (renamed || recategorized) {
+		s.logger.WarnContext(ctx, "PlanePartService: Refusing to rename catalog part",
+			"part_id", part.ID,
+			"catalog_part_id", *part.CatalogPartID,
+		)
+		return errors.New(CatalogFieldErr)
+	}
 	if req.PartName != nil {
 		part.PartName = *req.PartName
 	}
@@ -227,36 +354,354 @@ func (s *PlanePartService) UpdatePart(ctx context.Context, id int64, req *models
 	}
 	if req.SerialNumber != nil {
 		if *req.SerialNumber != part.SerialNumber {
-			existing, err := s.planePartRepo.GetBySerialNumber(ctx, *req.SerialNumber)
+			existing, err := s.planePartRepo.GetBySerialNumberWithDeleted(ctx, *req.SerialNumber)
 			if err != nil {
-				s.logger.Error("PlanePartService: Failed to check existing part",
+				s.logger.ErrorContext(ctx, "PlanePartService: Failed to check existing part",
 					"serial_number", *req.SerialNumber,
 					"error", err,
 				)
-				return nil, fmt.Errorf("failed to check existing part: %w", err)
+				return fmt.Errorf("failed to check existing part: %w", err)
 			}
 			if existing != nil {
-				s.logger.Warn("PlanePartService: Part with serial number already exists",
+				s.logger.WarnContext(ctx, "PlanePartService: Part with serial number already exists",
 					"serial_number", *req.SerialNumber,
 				)
-				return nil, errors.New(PlanePartExistsErr)
+				return errors.New(PlanePartExistsErr)
 			}
 		}
 		part.SerialNumber = *req.SerialNumber
 	}
 	if req.UsageLimitHours != nil {
+		// Lowering a limit is always safe; raising one needs an approved
+		// extension so the reason is on record.
+		if *req.UsageLimitHours > part.UsageLimitHours {
+			s.logger.WarnContext(ctx, "PlanePartService: Refusing to raise usage limit directly",
+				"part_id", part.ID,
+				"usage_limit_hours", part.UsageLimitHours,
+				"requested", *req.UsageLimitHours,
+			)
+			return errors.New(LimitRaiseErr)
+		}
 		part.UsageLimitHours = *req.UsageLimitHours
 	}
+	// Cycle and calendar limits have no extension process, so they can be
+	// set where there was none or lowered, but never raised.
+	if req.UsageLimitCycles != nil {
+		if part.UsageLimitCycles != nil && *req.UsageLimitCycles > *part.UsageLimitCycles {
+			s.logger.WarnContext(ctx, "PlanePartService: Refusing to raise cycle limit",
+				"part_id", part.ID,
+				"usage_limit_cycles", *part.UsageLimitCycles,
+				"requested", *req.UsageLimitCycles,
+			)
+			return errors.New(CyclesLimitRaiseErr)
+		}
+		part.UsageLimitCycles = req.UsageLimitCycles
+	}
+	if req.CalendarLimitDays != nil {
+		if part.CalendarLimitDays != nil && *req.CalendarLimitDays > *part.CalendarLimitDays {
+			s.logger.WarnContext(ctx, "PlanePartService: Refusing to raise calendar limit",
+				"part_id", part.ID,
+				"calendar_limit_days", *part.CalendarLimitDays,
+				"requested", *req.CalendarLimitDays,
+			)
+			return errors.New(CalendarRaiseErr)
+		}
+		part.CalendarLimitDays = req.CalendarLimitDays
+	}
+	if catalogPart != nil && !catalogPart.WithinDefaults(part) {
+		s.logger.WarnContext(ctx, "PlanePartService: Part limits exceed catalog defaults",
+			"part_id", part.ID,
+			"catalog_part_id", catalogPart.ID,
+		)
+		return errors.New(CatalogLimitErr)
+	}
 
-	if err := s.planePartRepo.Update(ctx, part); err != nil {
-		s.logger.Error("PlanePartService: Failed to update part",
+	return nil
+}
+
+func (s *PlanePartService) UpdatePartUsage(ctx context.Context, id int64, req *models.UpdatePartUsageRequest, actorID int64) (*models.PlanePartResponse, error) {
+	s.logger.InfoContext(ctx, "PlanePartService: UpdatePartUsage",
+		"part_id", id,
+		"new_usage_hours", req.UsageHours,
+	)
+
+	// The absolute reading is stored as the difference from the current total
+	// so the ledger stays append-only.
+	return s.recordUsage(ctx, id, models.UsageSourceManual, actorID, func(part *models.PlanePart) (float64, int) {
+		deltaCycles := 0
+		if req.UsageCycles != nil {
+			deltaCycles = *req.UsageCycles - part.UsageCycles
+		}
+		return req.UsageHours - part.UsageHours, deltaCycles
+	})
+}
+
+func (s *PlanePartService) LogPartUsage(ctx context.Context, id int64, req *models.LogPartUsageRequest, actorID int64) (*models.PlanePartResponse, error) {
+	s.logger.InfoContext(ctx, "PlanePartService: LogPartUsage",
+		"part_id", id,
+		"delta_hours", req.DeltaHours,
+		"delta_cycles", req.DeltaCycles,
+	)
+
+	if req.DeltaHours == 0 && req.DeltaCycles == 0 {
+		return nil, errors.New(EmptyUsageEntryErr)
+	}
+
+	source := req.Source
+	if source == "" {
+		source = models.UsageSourceManual
+	}
+
+	return s.recordUsage(ctx, id, source, actorID, func(*models.PlanePart) (float64, int) {
+		return req.DeltaHours, req.DeltaCycles
+	})
+}
+
+// recordUsage appends a ledger entry for the part with the deltas that delta
+// computes. The part's row is locked before delta sees it and stays locked
+// until the entry commits, so concurrent readings cannot both be taken from
+// the same total.
+func (s *PlanePartService) recordUsage(ctx context.Context, id int64, source string, actorID int64, delta func(part *models.PlanePart) (float64, int)) (*models.PlanePartResponse, error) {
+	var part *models.PlanePart
+	var entry *models.PartUsageEntry
+	err := s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		part, err = s.planePartRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if part == nil {
+			s.logger.WarnContext(ctx, "PlanePartService: Part not found",
+				"part_id", id,
+			)
+			return errors.New(PlanePartNotFoundErr)
+		}
+
+		deltaHours, deltaCycles := delta(part)
+		if deltaHours == 0 && deltaCycles == 0 {
+			return nil
+		}
+		if err := s.checkUsage(ctx, part, deltaHours, deltaCycles); err != nil {
+			return err
+		}
+
+		entry = &models.PartUsageEntry{
+			PartID:      part.ID,
+			DeltaHours:  deltaHours,
+			DeltaCycles: deltaCycles,
+			RecordedBy:  actorRef(actorID),
+			Source:      source,
+		}
+		before := *part
+		hours, cycles, err := s.usageRepo.Create(ctx, entry)
+		if err != nil {
+			return err
+		}
+		part.UsageHours = hours
+		part.UsageCycles = cycles
+		if err := s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: part.ID, Action: models.AuditActionUsage, Before: before, After: part}); err != nil {
+			return err
+		}
+		// Overruns are recorded as flown; the plane is grounded instead.
+		return s.groundIfOverLimit(ctx, part, actorID)
+	})
+	if err != nil {
+		switch err.Error() {
+		case PlanePartNotFoundErr, NegativeUsageErr, NegativeCyclesErr, PlaneNotOperationalErr:
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to record usage",
 			"part_id", id,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to update part: %w", err)
+		return nil, fmt.Errorf("failed to update usage: %w", err)
+	}
+
+	if entry != nil {
+		s.logger.InfoContext(ctx, "PlanePartService: Usage recorded",
+			"part_id", part.ID,
+			"entry_id", entry.ID,
+			"usage_hours", part.UsageHours,
+			"usage_cycles", part.UsageCycles,
+		)
+	}
+
+	resp := part.ToResponse()
+	return &resp, nil
+}
+
+// checkUsage refuses deltas that would take the part's totals below zero,
+// and deltas that add usage to a part on a grounded or retired plane.
+func (s *PlanePartService) checkUsage(ctx context.Context, part *models.PlanePart, deltaHours float64, deltaCycles int) error {
+	if part.UsageHours+deltaHours < 0 {
+		s.logger.WarnContext(ctx, "PlanePartService: Usage hours would become negative",
+			"part_id", part.ID,
+			"usage_hours", part.UsageHours,
+			"delta_hours", deltaHours,
+		)
+		return errors.New(NegativeUsageErr)
+	}
+
+	if part.UsageCycles+deltaCycles < 0 {
+		s.logger.WarnContext(ctx, "PlanePartService: Usage cycles would become negative",
+			"part_id", part.ID,
+			"usage_cycles", part.UsageCycles,
+			"delta_cycles", deltaCycles,
+		)
+		return errors.New(NegativeCyclesErr)
+	}
+
+	// Corrections that take usage back stay possible on a grounded plane so
+	// a bad entry can be undone; anything that adds usage is refused.
+	if part.PlaneID != nil && (deltaHours > 0 || deltaCycles > 0) {
+		plane, err := s.planeRepo.GetByID(ctx, *part.PlaneID)
+		if err != nil {
+			return err
+		}
+		if plane != nil && !plane.Operational() {
+			s.logger.WarnContext(ctx, "PlanePartService: Plane is not operational",
+				"part_id", part.ID,
+				"plane_id", plane.ID,
+				"status", plane.Status,
+			)
+			return errors.New(PlaneNotOperationalErr)
+		}
+	}
+
+	return nil
+}
+
+func (s *PlanePartService) GetPartUsageHistory(ctx context.Context, id int64, query *models.PaginationQuery) (*models.PaginatedResponse[models.PartUsageEntryResponse], error) {
+	s.logger.InfoContext(ctx, "PlanePartService: GetPartUsageHistory",
+		"part_id", id,
+	)
+
+	part, err := s.planePartRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get part",
+			"part_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get part: %w", err)
+	}
+	if part == nil {
+		s.logger.WarnContext(ctx, "PlanePartService: Part not found",
+			"part_id", id,
+		)
+		return nil, errors.New(PlanePartNotFoundErr)
+	}
+
+	query.Normalize()
+	entries, total, err := s.usageRepo.GetByPartID(ctx, id, query.Offset(), query.PageSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get usage history",
+			"part_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get usage history: %w", err)
+	}
+
+	responses := make([]models.PartUsageEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = entry.ToResponse()
+	}
+
+	return &models.PaginatedResponse[models.PartUsageEntryResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+func (s *PlanePartService) DeletePart(ctx context.Context, id int64) error {
+	s.logger.InfoContext(ctx, "PlanePartService: DeletePart",
+		"part_id", id,
+	)
+
+	part, err := s.planePartRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get part",
+			"part_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to get part: %w", err)
+	}
+	if part == nil {
+		s.logger.WarnContext(ctx, "PlanePartService: Part not found",
+			"part_id", id,
+		)
+		return errors.New(PlanePartNotFoundErr)
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.planePartRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: id, Action: models.AuditActionDelete, Before: part})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to delete part",
+			"part_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to delete part: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "PlanePartService: DeletePart successful",
+		"part_id", id,
+	)
+
+	return nil
+}
+
+// RestorePart undeletes a part deleted on its own. A part installed on a
+// deleted plane comes back with the plane instead.
+func (s *PlanePartService) RestorePart(ctx context.Context, id int64) (*models.PlanePartResponse, error) {
+	s.logger.InfoContext(ctx, "PlanePartService: RestorePart",
+		"part_id", id,
+	)
+
+	part, err := s.getDeletedPart(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *part
+
+	if part.PlaneID != nil {
+		plane, err := s.planeRepo.GetByID(ctx, *part.PlaneID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "PlanePartService: Failed to get plane",
+				"plane_id", *part.PlaneID,
+				"error", err,
+			)
+			return nil, fmt.Errorf("failed to get plane: %w", err)
+		}
+		if plane == nil {
+			s.logger.WarnContext(ctx, "PlanePartService: Part's plane is deleted",
+				"part_id", id,
+				"plane_id", *part.PlaneID,
+			)
+			return nil, errors.New(PartPlaneDeletedErr)
+		}
+	}
+
+	part.DeletedAt.Valid = false
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.planePartRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: id, Action: models.AuditActionRestore, Before: before, After: part})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to restore part",
+			"part_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to restore part: %w", err)
 	}
 
-	s.logger.Info("PlanePartService: UpdatePart successful",
+	s.logger.InfoContext(ctx, "PlanePartService: RestorePart successful",
 		"part_id", id,
 	)
 
@@ -264,151 +709,382 @@ func (s *PlanePartService) UpdatePart(ctx context.Context, id int64, req *models
 	return &resp, nil
 }
 
-func (s *PlanePartService) UpdatePartUsage(ctx context.Context, id int64, req *models.UpdatePartUsageRequest) (*models.PlanePartResponse, error) {
-	s.logger.Info("PlanePartService: UpdatePartUsage",
+// PurgePart permanently removes a deleted part that has no usage or
+// installation history; a part with history is refused with
+// PartHasHistoryErr.
+func (s *PlanePartService) PurgePart(ctx context.Context, id int64) error {
+	s.logger.InfoContext(ctx, "PlanePartService: PurgePart",
 		"part_id", id,
-		"new_usage_hours", req.UsageHours,
 	)
 
-	part, err := s.planePartRepo.GetByID(ctx, id)
+	part, err := s.getDeletedPart(ctx, id)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to get part",
+		return err
+	}
+
+	var hasHistory bool
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		hasHistory, err = s.planePartRepo.HasHistory(ctx, id)
+		if err != nil || hasHistory {
+			return err
+		}
+		if err := s.planePartRepo.Purge(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: id, Action: models.AuditActionPurge, Before: part})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to purge part",
+			"part_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to purge part: %w", err)
+	}
+	if hasHistory {
+		s.logger.WarnContext(ctx, "PlanePartService: Part has history",
+			"part_id", id,
+		)
+		return errors.New(PartHasHistoryErr)
+	}
+
+	s.logger.InfoContext(ctx, "PlanePartService: PurgePart successful",
+		"part_id", id,
+	)
+
+	return nil
+}
+
+// getDeletedPart loads a part that restore or purge can act on.
+func (s *PlanePartService) getDeletedPart(ctx context.Context, id int64) (*models.PlanePart, error) {
+	part, err := s.planePartRepo.GetByIDWithDeleted(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get part",
 			"part_id", id,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get part: %w", err)
 	}
 	if part == nil {
-		s.logger.Warn("PlanePartService: Part not found",
+		s.logger.WarnContext(ctx, "PlanePartService: Part not found",
 			"part_id", id,
 		)
 		return nil, errors.New(PlanePartNotFoundErr)
 	}
+	if !part.DeletedAt.Valid {
+		s.logger.WarnContext(ctx, "PlanePartService: Part is not deleted",
+			"part_id", id,
+		)
+		return nil, errors.New(PartNotDeletedErr)
+	}
+
+	return part, nil
+}
+
+// ============= Installation =============
 
-	if req.UsageHours > part.UsageLimitHours {
-		s.logger.Warn("PlanePartService: Usage hours exceeds limit",
+func (s *PlanePartService) InstallPart(ctx context.Context, id int64, req *models.InstallPartRequest, actorID int64) (*models.PlanePartResponse, error) {
+	s.logger.InfoContext(ctx, "PlanePartService: InstallPart",
+		"part_id", id,
+		"plane_id", req.PlaneID,
+	)
+
+	part, err := s.planePartRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get part",
 			"part_id", id,
-			"usage_hours", req.UsageHours,
-			"limit_hours", part.UsageLimitHours,
+			"error", err,
 		)
-		return nil, errors.New(InvalidUsageHoursErr)
+		return nil, fmt.Errorf("failed to get part: %w", err)
+	}
+	if part == nil {
+		s.logger.WarnContext(ctx, "PlanePartService: Part not found",
+			"part_id", id,
+		)
+		return nil, errors.New(PlanePartNotFoundErr)
+	}
+	if part.PlaneID != nil {
+		s.logger.WarnContext(ctx, "PlanePartService: Part is already installed",
+			"part_id", id,
+			"plane_id", *part.PlaneID,
+		)
+		return nil, errors.New(PartInstalledErr)
 	}
 
-	part.UsageHours = req.UsageHours
+	plane, err := s.planeRepo.GetByID(ctx, req.PlaneID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to verify plane",
+			"plane_id", req.PlaneID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to verify plane: %w", err)
+	}
+	if plane == nil {
+		s.logger.WarnContext(ctx, "PlanePartService: Plane not found",
+			"plane_id", req.PlaneID,
+		)
+		return nil, errors.New(PlaneNotFoundErrPart)
+	}
+	if !plane.Operational() {
+		s.logger.WarnContext(ctx, "PlanePartService: Plane is not operational",
+			"part_id", id,
+			"plane_id", plane.ID,
+			"status", plane.Status,
+		)
+		return nil, errors.New(PlaneNotOperationalErr)
+	}
+	if part.CatalogPartID != nil {
+		if _, err := s.getApplicableCatalogPart(ctx, *part.CatalogPartID, plane); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := s.planePartRepo.UpdateUsage(ctx, part); err != nil {
-		s.logger.Error("PlanePartService: Failed to update usage",
+	before := *part
+	var installation *models.PartInstallation
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		installation, err = s.installRepo.Install(ctx, id, req.PlaneID, actorRef(actorID))
+		if err != nil {
+			return err
+		}
+		if installation == nil {
+			// Installed since it was read.
+			s.logger.WarnContext(ctx, "PlanePartService: Part is already installed",
+				"part_id", id,
+			)
+			return errors.New(PartInstalledErr)
+		}
+		part.PlaneID = &req.PlaneID
+		part.InstalledAt = installation.InstalledAt
+		if err := s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: id, Action: models.AuditActionInstall, Before: before, After: part}); err != nil {
+			return err
+		}
+		return s.groundIfOverLimit(ctx, part, actorID)
+	})
+	if err != nil {
+		if err.Error() == PartInstalledErr {
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to install part",
 			"part_id", id,
+			"plane_id", req.PlaneID,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to update usage: %w", err)
+		return nil, fmt.Errorf("failed to install part: %w", err)
 	}
 
-	s.logger.Info("PlanePartService: UpdatePartUsage successful",
+	s.logger.InfoContext(ctx, "PlanePartService: InstallPart successful",
 		"part_id", id,
-		"usage_percent", part.UsagePercent,
+		"plane_id", req.PlaneID,
+		"installation_id", installation.ID,
 	)
 
 	resp := part.ToResponse()
 	return &resp, nil
 }
 
-func (s *PlanePartService) DeletePart(ctx context.Context, id int64) error {
-	s.logger.Info("PlanePartService: DeletePart",
+func (s *PlanePartService) RemovePart(ctx context.Context, id int64, req *models.RemovePartRequest, actorID int64) (*models.PlanePartResponse, error) {
+	s.logger.InfoContext(ctx, "PlanePartService: RemovePart",
 		"part_id", id,
 	)
 
 	part, err := s.planePartRepo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to get part",
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get part",
 			"part_id", id,
 			"error", err,
 		)
-		return fmt.Errorf("failed to get part: %w", err)
+		return nil, fmt.Errorf("failed to get part: %w", err)
 	}
 	if part == nil {
-		s.logger.Warn("PlanePartService: Part not found",
+		s.logger.WarnContext(ctx, "PlanePartService: Part not found",
 			"part_id", id,
 		)
-		return errors.New(PlanePartNotFoundErr)
+		return nil, errors.New(PlanePartNotFoundErr)
+	}
+	if part.PlaneID == nil {
+		s.logger.WarnContext(ctx, "PlanePartService: Part is not installed",
+			"part_id", id,
+		)
+		return nil, errors.New(PartNotInstalledErr)
 	}
 
-	if err := s.planePartRepo.Delete(ctx, id); err != nil {
-		s.logger.Error("PlanePartService: Failed to delete part",
+	var reason *string
+	if req.Reason != "" {
+		reason = &req.Reason
+	}
+
+	before := *part
+	part.PlaneID = nil
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.installRepo.Remove(ctx, id, actorRef(actorID), reason); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: id, Action: models.AuditActionRemove, Before: before, After: part})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to remove part",
 			"part_id", id,
 			"error", err,
 		)
-		return fmt.Errorf("failed to delete part: %w", err)
+		return nil, fmt.Errorf("failed to remove part: %w", err)
 	}
 
-	s.logger.Info("PlanePartService: DeletePart successful",
+	s.logger.InfoContext(ctx, "PlanePartService: RemovePart successful",
 		"part_id", id,
+		"plane_id", *before.PlaneID,
 	)
 
-	return nil
+	resp := part.ToResponse()
+	return &resp, nil
+}
+
+func (s *PlanePartService) GetSpareParts(ctx context.Context, query *models.PartQuery) (*models.PaginatedResponse[models.PlanePartResponse], error) {
+	s.logger.InfoContext(ctx, "PlanePartService: GetSpareParts")
+
+	installed := false
+	query.PlaneID = nil
+	query.Installed = &installed
+	return s.listParts(ctx, "GetSpareParts", query)
+}
+
+func (s *PlanePartService) GetPartHistoryBySerial(ctx context.Context, serialNumber string) (*models.PartHistoryResponse, error) {
+	s.logger.InfoContext(ctx, "PlanePartService: GetPartHistoryBySerial",
+		"serial_number", serialNumber,
+	)
+
+	part, err := s.planePartRepo.GetBySerialNumber(ctx, serialNumber)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get part",
+			"serial_number", serialNumber,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get part: %w", err)
+	}
+	if part == nil {
+		s.logger.WarnContext(ctx, "PlanePartService: Part not found",
+			"serial_number", serialNumber,
+		)
+		return nil, errors.New(PlanePartNotFoundErr)
+	}
+
+	installations, err := s.installRepo.GetByPartID(ctx, part.ID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get installations",
+			"part_id", part.ID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get installations: %w", err)
+	}
+
+	now := time.Now()
+	history := &models.PartHistoryResponse{
+		Part:          part.ToResponse(),
+		Installations: make([]models.PartInstallationResponse, len(installations)),
+	}
+	for i, installation := range installations {
+		history.Installations[i] = installation.ToResponse(part, now)
+	}
+
+	return history, nil
 }
 
 // ============= Maintenance Monitoring =============
 
-func (s *PlanePartService) GetPartsNeedingMaintenance(ctx context.Context, thresholdPercent float64) ([]models.PlanePartResponse, error) {
-	s.logger.Info("PlanePartService: GetPartsNeedingMaintenance",
-		"threshold", thresholdPercent,
+func (s *PlanePartService) GetPartsNeedingMaintenance(ctx context.Context, query *models.MaintenanceAlertQuery) (*models.PaginatedResponse[models.PlanePartResponse], error) {
+	threshold := defaultMaintenanceThreshold
+	if query.Threshold != nil {
+		threshold = *query.Threshold
+	}
+
+	s.logger.InfoContext(ctx, "PlanePartService: GetPartsNeedingMaintenance",
+		"threshold", threshold,
 	)
 
-	parts, err := s.planePartRepo.GetNeedingMaintenance(ctx, thresholdPercent)
+	query.Normalize()
+	parts, total, err := s.planePartRepo.ListNeedingMaintenance(ctx, threshold, &query.PartQuery)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to get parts needing maintenance",
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get parts needing maintenance",
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get parts: %w", err)
 	}
 
-	s.logger.Info("PlanePartService: GetPartsNeedingMaintenance successful",
+	s.logger.InfoContext(ctx, "PlanePartService: GetPartsNeedingMaintenance successful",
 		"count", len(parts),
+		"total", total,
 	)
 
-	responses := make([]models.PlanePartResponse, len(parts))
-	for i, part := range parts {
-		responses[i] = part.ToResponse()
-	}
+	return partPage(parts, total, &query.PartQuery), nil
+}
 
-	return responses, nil
+func (s *PlanePartService) getCatalogPart(ctx context.Context, catalogPartID int64) (*models.CatalogPart, error) {
+	catalogPart, err := s.catalogRepo.GetByID(ctx, catalogPartID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to get catalog part",
+			"catalog_part_id", catalogPartID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get catalog part: %w", err)
+	}
+	if catalogPart == nil {
+		s.logger.WarnContext(ctx, "PlanePartService: Catalog part not found",
+			"catalog_part_id", catalogPartID,
+		)
+		return nil, errors.New(CatalogPartNotFoundErr)
+	}
+	return catalogPart, nil
 }
 
-func (s *PlanePartService) GetPlaneWithParts(ctx context.Context, id int64) (*models.PlaneResponse, []models.PlanePartResponse, error) {
-	s.logger.Info("PlanePartService: GetPlaneWithParts",
-		"plane_id", id,
-	)
+// getLinkableCatalogPart loads a catalog entry for an existing part. A spare
+// may take any entry; an installed part only one that fits its plane's
+// model.
+func (s *PlanePartService) getLinkableCatalogPart(ctx context.Context, catalogPartID int64, part *models.PlanePart) (*models.CatalogPart, error) {
+	if part.PlaneID == nil {
+		return s.getCatalogPart(ctx, catalogPartID)
+	}
 
-	plane, err := s.planeRepo.GetByID(ctx, id)
+	plane, err := s.planeRepo.GetByID(ctx, *part.PlaneID)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to get plane",
-			"plane_id", id,
+		s.logger.ErrorContext(ctx, "PlanePartService: Failed to verify plane",
+			"plane_id", *part.PlaneID,
 			"error", err,
 		)
-		return nil, nil, fmt.Errorf("failed to get plane: %w", err)
+		return nil, fmt.Errorf("failed to verify plane: %w", err)
 	}
 	if plane == nil {
-		s.logger.Warn("PlanePartService: Plane not found",
-			"plane_id", id,
+		s.logger.WarnContext(ctx, "PlanePartService: Plane not found",
+			"plane_id", *part.PlaneID,
 		)
-		return nil, nil, errors.New(PlaneNotFoundErrPart)
+		return nil, errors.New(PlaneNotFoundErrPart)
 	}
+	return s.getApplicableCatalogPart(ctx, catalogPartID, plane)
+}
 
-	parts, err := s.planePartRepo.GetByPlaneIDWithDetails(ctx, id)
+// getApplicableCatalogPart loads a catalog entry and checks that it may be
+// fitted to the plane's model.
+func (s *PlanePartService) getApplicableCatalogPart(ctx context.Context, catalogPartID int64, plane *models.Plane) (*models.CatalogPart, error) {
+	catalogPart, err := s.getCatalogPart(ctx, catalogPartID)
 	if err != nil {
-		s.logger.Error("PlanePartService: Failed to get plane parts",
-			"plane_id", id,
-			"error", err,
+		return nil, err
+	}
+	if !catalogPart.AppliesTo(plane.Model) {
+		s.logger.WarnContext(ctx, "PlanePartService: Catalog part not applicable to plane model",
+			"catalog_part_id", catalogPartID,
+			"plane_id", plane.ID,
+			"model", plane.Model,
 		)
-		return nil, nil, fmt.Errorf("failed to get parts: %w", err)
+		return nil, errors.New(CatalogModelErr)
 	}
+	return catalogPart, nil
+}
 
-	partResponses := make([]models.PlanePartResponse, len(parts))
-	for i, part := range parts {
-		partResponses[i] = part.ToResponse()
+// groundIfOverLimit grounds the plane the part is installed on when the
+// part, or any other installed there, has overrun a life limit.
+func (s *PlanePartService) groundIfOverLimit(ctx context.Context, part *models.PlanePart, actorID int64) error {
+	if part.PlaneID == nil {
+		return nil
 	}
-
-	planeResp := plane.ToResponse()
-	return &planeResp, partResponses, nil
+	_, err := s.airworthiness.GroundIfOverLimit(ctx, *part.PlaneID, actorID)
+	return err
 }
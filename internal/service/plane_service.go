@@ -11,38 +11,52 @@ import (
 )
 
 const (
-	PlaneNotFoundErr = "plane not found"
-	PlaneExistsErr   = "plane with this tail number already exists"
+	PlaneNotFoundErr   = "plane not found"
+	PlaneExistsErr     = "plane with this tail number already exists"
+	PlaneNotDeletedErr = "plane is not deleted"
+	PlaneHasHistoryErr = "plane has recorded history and cannot be purged"
+
+	PlaneStatusTransitionErr = "invalid plane status transition"
+	PlanePartsOverLimitErr   = "plane has parts over their life limit"
+	PlaneNotOperationalErr   = "plane is grounded or retired"
 )
 
 type PlaneService struct {
-	planeRepo *repository.PlaneRepository
-	logger    *util.Logger
+	tx                *repository.Transactor
+	planeRepo         *repository.PlaneRepository
+	planePartRepo     *repository.PlanePartRepository
+	aircraftModelRepo *repository.AircraftModelRepository
+	audit             *AuditService
+	logger            *util.Logger
 }
 
-func NewPlaneService(planeRepo *repository.PlaneRepository, logger *util.Logger) *PlaneService {
+func NewPlaneService(tx *repository.Transactor, planeRepo *repository.PlaneRepository, planePartRepo *repository.PlanePartRepository, aircraftModelRepo *repository.AircraftModelRepository, audit *AuditService, logger *util.Logger) *PlaneService {
 	return &PlaneService{
-		planeRepo: planeRepo,
-		logger:    logger,
+		tx:                tx,
+		planeRepo:         planeRepo,
+		planePartRepo:     planePartRepo,
+		aircraftModelRepo: aircraftModelRepo,
+		audit:             audit,
+		logger:            logger,
 	}
 }
 
 func (s *PlaneService) CreatePlane(ctx context.Context, req *models.CreatePlaneRequest) (*models.PlaneResponse, error) {
-	s.logger.Info("PlaneService: Creating new plane",
+	s.logger.InfoContext(ctx, "PlaneService: Creating new plane",
 		"tail_number", req.TailNumber,
 		"model", req.Model,
 	)
 
-	existing, err := s.planeRepo.GetByTailNumber(ctx, req.TailNumber)
+	existing, err := s.planeRepo.GetByTailNumberWithDeleted(ctx, req.TailNumber)
 	if err != nil {
-		s.logger.Error("PlaneService: Failed to check existing plane",
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to check existing plane",
 			"tail_number", req.TailNumber,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to check existing plane: %w", err)
 	}
 	if existing != nil {
-		s.logger.Warn("PlaneService: Plane with tail number already exists",
+		s.logger.WarnContext(ctx, "PlaneService: Plane with tail number already exists",
 			"tail_number", req.TailNumber,
 		)
 		return nil, errors.New(PlaneExistsErr)
@@ -51,17 +65,24 @@ func (s *PlaneService) CreatePlane(ctx context.Context, req *models.CreatePlaneR
 	plane := &models.Plane{
 		TailNumber: req.TailNumber,
 		Model:      req.Model,
+		Status:     models.PlaneStatusActive,
 	}
 
-	if err := s.planeRepo.Create(ctx, plane); err != nil {
-		s.logger.Error("PlaneService: Failed to create plane",
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.planeRepo.Create(ctx, plane); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlane, EntityID: plane.ID, Action: models.AuditActionCreate, After: plane})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to create plane",
 			"tail_number", req.TailNumber,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to create plane: %w", err)
 	}
 
-	s.logger.Info("PlaneService: Plane created successfully",
+	s.logger.InfoContext(ctx, "PlaneService: Plane created successfully",
 		"plane_id", plane.ID,
 		"tail_number", plane.TailNumber,
 	)
@@ -71,20 +92,20 @@ func (s *PlaneService) CreatePlane(ctx context.Context, req *models.CreatePlaneR
 }
 
 func (s *PlaneService) GetPlane(ctx context.Context, id int64) (*models.PlaneResponse, error) {
-	s.logger.Info("PlaneService: GetPlane",
+	s.logger.InfoContext(ctx, "PlaneService: GetPlane",
 		"plane_id", id,
 	)
 
 	plane, err := s.planeRepo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("PlaneService: Failed to get plane",
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane",
 			"plane_id", id,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get plane: %w", err)
 	}
 	if plane == nil {
-		s.logger.Warn("PlaneService: Plane not found",
+		s.logger.WarnContext(ctx, "PlaneService: Plane not found",
 			"plane_id", id,
 		)
 		return nil, errors.New(PlaneNotFoundErr)
@@ -95,20 +116,20 @@ func (s *PlaneService) GetPlane(ctx context.Context, id int64) (*models.PlaneRes
 }
 
 func (s *PlaneService) GetPlaneByTailNumber(ctx context.Context, tailNumber string) (*models.PlaneResponse, error) {
-	s.logger.Info("PlaneService: GetPlaneByTailNumber",
+	s.logger.InfoContext(ctx, "PlaneService: GetPlaneByTailNumber",
 		"tail_number", tailNumber,
 	)
 
 	plane, err := s.planeRepo.GetByTailNumber(ctx, tailNumber)
 	if err != nil {
-		s.logger.Error("PlaneService: Failed to get plane by tail number",
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane by tail number",
 			"tail_number", tailNumber,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get plane: %w", err)
 	}
 	if plane == nil {
-		s.logger.Warn("PlaneService: Plane not found",
+		s.logger.WarnContext(ctx, "PlaneService: Plane not found",
 			"tail_number", tailNumber,
 		)
 		return nil, errors.New(PlaneNotFoundErr)
@@ -118,19 +139,26 @@ func (s *PlaneService) GetPlaneByTailNumber(ctx context.Context, tailNumber stri
 	return &resp, nil
 }
 
-func (s *PlaneService) GetAllPlanes(ctx context.Context) ([]models.PlaneResponse, error) {
-	s.logger.Info("PlaneService: GetAllPlanes")
+func (s *PlaneService) GetAllPlanes(ctx context.Context, query *models.PlaneQuery) (*models.PaginatedResponse[models.PlaneResponse], error) {
+	s.logger.InfoContext(ctx, "PlaneService: GetAllPlanes",
+		"model", query.Model,
+		"tail_number", query.TailNumber,
+		"status", query.Status,
+		"sort", query.Sort,
+	)
 
-	planes, err := s.planeRepo.GetAll(ctx)
+	query.Normalize()
+	planes, total, err := s.planeRepo.List(ctx, query)
 	if err != nil {
-		s.logger.Error("PlaneService: Failed to get planes",
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get planes",
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get planes: %w", err)
 	}
 
-	s.logger.Info("PlaneService: GetAllPlanes successful",
+	s.logger.InfoContext(ctx, "PlaneService: GetAllPlanes successful",
 		"count", len(planes),
+		"total", total,
 	)
 
 	responses := make([]models.PlaneResponse, len(planes))
@@ -138,41 +166,47 @@ func (s *PlaneService) GetAllPlanes(ctx context.Context) ([]models.PlaneResponse
 		responses[i] = plane.ToResponse()
 	}
 
-	return responses, nil
+	return &models.PaginatedResponse[models.PlaneResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
 }
 
 func (s *PlaneService) UpdatePlane(ctx context.Context, id int64, req *models.UpdatePlaneRequest) (*models.PlaneResponse, error) {
-	s.logger.Info("PlaneService: UpdatePlane",
+	s.logger.InfoContext(ctx, "PlaneService: UpdatePlane",
 		"plane_id", id,
 	)
 
 	plane, err := s.planeRepo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("PlaneService: Failed to get plane",
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane",
 			"plane_id", id,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get plane: %w", err)
 	}
 	if plane == nil {
-		s.logger.Warn("PlaneService: Plane not found",
+		s.logger.WarnContext(ctx, "PlaneService: Plane not found",
 			"plane_id", id,
 		)
 		return nil, errors.New(PlaneNotFoundErr)
 	}
+	before := *plane
 
 	if req.TailNumber != nil {
 		if *req.TailNumber != plane.TailNumber {
-			existing, err := s.planeRepo.GetByTailNumber(ctx, *req.TailNumber)
+			existing, err := s.planeRepo.GetByTailNumberWithDeleted(ctx, *req.TailNumber)
 			if err != nil {
-				s.logger.Error("PlaneService: Failed to check existing plane",
+				s.logger.ErrorContext(ctx, "PlaneService: Failed to check existing plane",
 					"tail_number", *req.TailNumber,
 					"error", err,
 				)
 				return nil, fmt.Errorf("failed to check existing plane: %w", err)
 			}
 			if existing != nil {
-				s.logger.Warn("PlaneService: Plane with tail number already exists",
+				s.logger.WarnContext(ctx, "PlaneService: Plane with tail number already exists",
 					"tail_number", *req.TailNumber,
 				)
 				return nil, errors.New(PlaneExistsErr)
@@ -184,15 +218,28 @@ func (s *PlaneService) UpdatePlane(ctx context.Context, id int64, req *models.Up
 		plane.Model = *req.Model
 	}
 
-	if err := s.planeRepo.Update(ctx, plane); err != nil {
-		s.logger.Error("PlaneService: Failed to update plane",
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.planeRepo.Update(ctx, plane); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlane, EntityID: id, Action: models.AuditActionUpdate, Before: before, After: plane})
+	})
+	if err != nil {
+		// Deleted since it was read.
+		if err.Error() == PlaneNotFoundErr {
+			s.logger.WarnContext(ctx, "PlaneService: Plane not found",
+				"plane_id", id,
+			)
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to update plane",
 			"plane_id", id,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to update plane: %w", err)
 	}
 
-	s.logger.Info("PlaneService: Update successful",
+	s.logger.InfoContext(ctx, "PlaneService: Update successful",
 		"plane_id", id,
 	)
 
@@ -200,61 +247,368 @@ func (s *PlaneService) UpdatePlane(ctx context.Context, id int64, req *models.Up
 	return &resp, nil
 }
 
+// ChangeStatus moves a plane to a new operational status. Only admins may
+// retire a plane, and a plane with parts over their life limit cannot return
+// to service.
+func (s *PlaneService) ChangeStatus(ctx context.Context, id int64, req *models.UpdatePlaneStatusRequest, actorID int64, actorRole string) (*models.PlaneResponse, error) {
+	s.logger.InfoContext(ctx, "PlaneService: ChangeStatus",
+		"plane_id", id,
+		"status", req.Status,
+		"actor_id", actorID,
+	)
+
+	if req.Status == models.PlaneStatusRetired && actorRole != models.RoleAdmin {
+		s.logger.WarnContext(ctx, "PlaneService: Only admins can retire planes",
+			"plane_id", id,
+			"actor_id", actorID,
+		)
+		return nil, errors.New(PermissionDenied)
+	}
+
+	plane, err := s.planeRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane",
+			"plane_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get plane: %w", err)
+	}
+	if plane == nil {
+		s.logger.WarnContext(ctx, "PlaneService: Plane not found",
+			"plane_id", id,
+		)
+		return nil, errors.New(PlaneNotFoundErr)
+	}
+
+	if !models.CanTransitionPlaneStatus(plane.Status, req.Status) {
+		s.logger.WarnContext(ctx, "PlaneService: Invalid status transition",
+			"plane_id", id,
+			"from", plane.Status,
+			"to", req.Status,
+		)
+		return nil, errors.New(PlaneStatusTransitionErr)
+	}
+
+	if req.Status == models.PlaneStatusActive {
+		overLimit, err := s.planeRepo.CountPartsOverLimit(ctx, id)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "PlaneService: Failed to check part limits",
+				"plane_id", id,
+				"error", err,
+			)
+			return nil, fmt.Errorf("failed to check part limits: %w", err)
+		}
+		if overLimit > 0 {
+			s.logger.WarnContext(ctx, "PlaneService: Plane has parts over their limit",
+				"plane_id", id,
+				"parts_over_limit", overLimit,
+			)
+			return nil, errors.New(PlanePartsOverLimitErr)
+		}
+	}
+
+	change := &models.PlaneStatusChange{
+		PlaneID:    id,
+		FromStatus: plane.Status,
+		ToStatus:   req.Status,
+		ChangedBy:  actorRef(actorID),
+	}
+	if req.Reason != "" {
+		change.Reason = &req.Reason
+	}
+
+	before := *plane
+	plane.Status = req.Status
+	var changed bool
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if changed, err = s.planeRepo.ChangeStatus(ctx, change); err != nil || !changed {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlane, EntityID: id, Action: models.AuditActionStatus, Before: before, After: plane})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to change status",
+			"plane_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to change plane status: %w", err)
+	}
+	if !changed {
+		s.logger.WarnContext(ctx, "PlaneService: Status changed concurrently",
+			"plane_id", id,
+		)
+		return nil, errors.New(PlaneStatusTransitionErr)
+	}
+
+	s.logger.InfoContext(ctx, "PlaneService: ChangeStatus successful",
+		"plane_id", id,
+		"from", change.FromStatus,
+		"to", change.ToStatus,
+	)
+
+	resp := plane.ToResponse()
+	return &resp, nil
+}
+
+func (s *PlaneService) GetStatusHistory(ctx context.Context, id int64, query *models.PaginationQuery) (*models.PaginatedResponse[models.PlaneStatusChangeResponse], error) {
+	s.logger.InfoContext(ctx, "PlaneService: GetStatusHistory",
+		"plane_id", id,
+	)
+
+	plane, err := s.planeRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane",
+			"plane_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get plane: %w", err)
+	}
+	if plane == nil {
+		s.logger.WarnContext(ctx, "PlaneService: Plane not found",
+			"plane_id", id,
+		)
+		return nil, errors.New(PlaneNotFoundErr)
+	}
+
+	query.Normalize()
+	changes, total, err := s.planeRepo.GetStatusChanges(ctx, id, query.Offset(), query.PageSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get status history",
+			"plane_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+
+	responses := make([]models.PlaneStatusChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = change.ToResponse()
+	}
+
+	return &models.PaginatedResponse[models.PlaneStatusChangeResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
 func (s *PlaneService) DeletePlane(ctx context.Context, id int64) error {
-	s.logger.Info("PlaneService: DeletePlane",
+	s.logger.InfoContext(ctx, "PlaneService: DeletePlane",
 		"plane_id", id,
 	)
 
 	plane, err := s.planeRepo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("PlaneService: Failed to get plane",
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane",
 			"plane_id", id,
 			"error", err,
 		)
 		return fmt.Errorf("failed to get plane: %w", err)
 	}
 	if plane == nil {
-		s.logger.Warn("PlaneService: Plane not found",
+		s.logger.WarnContext(ctx, "PlaneService: Plane not found",
 			"plane_id", id,
 		)
 		return errors.New(PlaneNotFoundErr)
 	}
 
-	if err := s.planeRepo.Delete(ctx, id); err != nil {
-		s.logger.Error("PlaneService: Failed to delete plane",
+	var parts []models.PlanePart
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if parts, err = s.planeRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		changes := []AuditChange{{EntityType: models.AuditEntityPlane, EntityID: id, Action: models.AuditActionDelete, Before: plane}}
+		for i := range parts {
+			changes = append(changes, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: parts[i].ID, Action: models.AuditActionDelete, Before: parts[i]})
+		}
+		return s.audit.Record(ctx, changes...)
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to delete plane",
 			"plane_id", id,
 			"error", err,
 		)
 		return fmt.Errorf("failed to delete plane: %w", err)
 	}
 
-	s.logger.Info("PlaneService: Delete successful",
+	s.logger.InfoContext(ctx, "PlaneService: Delete successful",
+		"plane_id", id,
+		"parts_deleted", len(parts),
+	)
+
+	return nil
+}
+
+// RestorePlane undeletes a plane and the parts that were deleted with it.
+func (s *PlaneService) RestorePlane(ctx context.Context, id int64) (*models.PlaneResponse, error) {
+	s.logger.InfoContext(ctx, "PlaneService: RestorePlane",
+		"plane_id", id,
+	)
+
+	plane, err := s.getDeletedPlane(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *plane
+
+	plane.DeletedAt.Valid = false
+	var parts []models.PlanePart
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if parts, err = s.planeRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		changes := []AuditChange{{EntityType: models.AuditEntityPlane, EntityID: id, Action: models.AuditActionRestore, Before: before, After: plane}}
+		for i := range parts {
+			changes = append(changes, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: parts[i].ID, Action: models.AuditActionRestore, After: parts[i]})
+		}
+		return s.audit.Record(ctx, changes...)
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to restore plane",
+			"plane_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to restore plane: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "PlaneService: RestorePlane successful",
+		"plane_id", id,
+		"parts_restored", len(parts),
+	)
+
+	resp := plane.ToResponse()
+	return &resp, nil
+}
+
+// PurgePlane permanently removes a plane that has already been deleted. It
+// is refused once anything has been recorded against the plane, which then
+// stays deleted.
+func (s *PlaneService) PurgePlane(ctx context.Context, id int64) error {
+	s.logger.InfoContext(ctx, "PlaneService: PurgePlane",
+		"plane_id", id,
+	)
+
+	plane, err := s.getDeletedPlane(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	var hasHistory bool
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		hasHistory, err = s.planeRepo.HasHistory(ctx, id)
+		if err != nil || hasHistory {
+			return err
+		}
+		if err := s.planeRepo.Purge(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityPlane, EntityID: id, Action: models.AuditActionPurge, Before: plane})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to purge plane",
+			"plane_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to purge plane: %w", err)
+	}
+	if hasHistory {
+		s.logger.WarnContext(ctx, "PlaneService: Plane has history",
+			"plane_id", id,
+		)
+		return errors.New(PlaneHasHistoryErr)
+	}
+
+	s.logger.InfoContext(ctx, "PlaneService: PurgePlane successful",
 		"plane_id", id,
 	)
 
 	return nil
 }
 
-func (s *PlaneService) GetPlaneWithParts(ctx context.Context, id int64) (*models.PlaneResponse, []models.PlanePartResponse, error) {
-	s.logger.Info("PlaneService: GetPlaneWithParts",
+// getDeletedPlane loads a plane that restore or purge can act on.
+func (s *PlaneService) getDeletedPlane(ctx context.Context, id int64) (*models.Plane, error) {
+	plane, err := s.planeRepo.GetByIDWithDeleted(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane",
+			"plane_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get plane: %w", err)
+	}
+	if plane == nil {
+		s.logger.WarnContext(ctx, "PlaneService: Plane not found",
+			"plane_id", id,
+		)
+		return nil, errors.New(PlaneNotFoundErr)
+	}
+	if !plane.DeletedAt.Valid {
+		s.logger.WarnContext(ctx, "PlaneService: Plane is not deleted",
+			"plane_id", id,
+		)
+		return nil, errors.New(PlaneNotDeletedErr)
+	}
+
+	return plane, nil
+}
+
+// GetPlaneWithParts returns the plane and its installed parts. When the
+// plane's model is registered, the parts are also checked against the
+// model's slot template.
+func (s *PlaneService) GetPlaneWithParts(ctx context.Context, id int64) (*models.PlaneWithPartsResponse, error) {
+	s.logger.InfoContext(ctx, "PlaneService: GetPlaneWithParts",
 		"plane_id", id,
 	)
 
 	plane, err := s.planeRepo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("PlaneService: Failed to get plane",
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane",
 			"plane_id", id,
 			"error", err,
 		)
-		return nil, nil, fmt.Errorf("failed to get plane: %w", err)
+		return nil, fmt.Errorf("failed to get plane: %w", err)
 	}
 	if plane == nil {
-		s.logger.Warn("PlaneService: Plane not found",
+		s.logger.WarnContext(ctx, "PlaneService: Plane not found",
+			"plane_id", id,
+		)
+		return nil, errors.New(PlaneNotFoundErr)
+	}
+
+	parts, err := s.planePartRepo.GetByPlaneIDWithDetails(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get plane parts",
+			"plane_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get parts: %w", err)
+	}
+
+	aircraftModel, err := s.aircraftModelRepo.GetByName(ctx, plane.Model)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PlaneService: Failed to get aircraft model",
 			"plane_id", id,
+			"model", plane.Model,
+			"error", err,
 		)
-		return nil, nil, errors.New(PlaneNotFoundErr)
+		return nil, fmt.Errorf("failed to get aircraft model: %w", err)
+	}
+
+	resp := &models.PlaneWithPartsResponse{
+		Plane: plane.ToResponse(),
+		Parts: make([]models.PlanePartResponse, len(parts)),
+	}
+	for i := range parts {
+		resp.Parts[i] = parts[i].ToResponse()
+	}
+	if aircraftModel != nil {
+		report := aircraftModel.CheckSlots(parts)
+		resp.Slots = &report
 	}
 
-	planeResp := plane.ToResponse()
-	return &planeResp, nil, nil
+	return resp, nil
 }
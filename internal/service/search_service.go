@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+type SearchService struct {
+	searchRepo *repository.SearchRepository
+	logger     *util.Logger
+}
+
+func NewSearchService(searchRepo *repository.SearchRepository, logger *util.Logger) *SearchService {
+	return &SearchService{searchRepo: searchRepo, logger: logger}
+}
+
+func (s *SearchService) Search(ctx context.Context, query *models.SearchQuery) (*models.PaginatedResponse[models.SearchResult], error) {
+	term := strings.TrimSpace(query.Q)
+	s.logger.InfoContext(ctx, "SearchService: Search",
+		"q", term,
+		"type", query.Type,
+	)
+
+	query.Normalize()
+	results, total, err := s.searchRepo.Search(ctx, term, query.Type, query.Offset(), query.PageSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SearchService: Failed to search",
+			"q", term,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "SearchService: Search successful",
+		"q", term,
+		"count", len(results),
+		"total", total,
+	)
+
+	if results == nil {
+		results = []models.SearchResult{}
+	}
+
+	return &models.PaginatedResponse[models.SearchResult]{
+		Data:     results,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/config"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const (
+	InvalidRefreshTokenErr = "invalid or expired refresh token"
+	SessionRevokedErr      = "session has been revoked"
+)
+
+// TokenPair is what a client receives on login and on every refresh.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// SessionService manages logins server-side: it issues access tokens bound
+// to a session, rotates refresh tokens and revokes sessions.
+type SessionService struct {
+	sessionRepo   *repository.SessionRepository
+	userRepo      *repository.UserRepository
+	jwtSvc        *JWTService
+	refreshExpiry time.Duration
+	logger        *util.Logger
+}
+
+func NewSessionService(sessionRepo *repository.SessionRepository, userRepo *repository.UserRepository, jwtSvc *JWTService, cfg config.AuthConfig, logger *util.Logger) *SessionService {
+	return &SessionService{
+		sessionRepo:   sessionRepo,
+		userRepo:      userRepo,
+		jwtSvc:        jwtSvc,
+		refreshExpiry: cfg.RefreshTokenExpiry,
+		logger:        logger,
+	}
+}
+
+func (s *SessionService) GetAccessExpiryDuration() time.Duration {
+	return s.jwtSvc.GetExpiryDuration()
+}
+
+func (s *SessionService) GetRefreshExpiryDuration() time.Duration {
+	return s.refreshExpiry
+}
+
+// Start opens a new session for user and returns its first token pair.
+func (s *SessionService) Start(ctx context.Context, user *models.User) (*TokenPair, error) {
+	s.logger.InfoContext(ctx, "SessionService: Start",
+		"user_id", user.ID,
+	)
+
+	refreshToken, err := util.GenerateToken()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to generate refresh token",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.refreshExpiry)
+	session := &models.Session{
+		UserID:    user.ID,
+		ExpiresAt: expiresAt,
+	}
+	token := &models.RefreshToken{
+		TokenHash: util.HashToken(refreshToken),
+		ExpiresAt: expiresAt,
+	}
+
+	if err := s.sessionRepo.Create(ctx, session, token); err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to create session",
+			"user_id", user.ID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	accessToken, err := s.jwtSvc.GenerateToken(user.ID, user.Name, user.Role, session.ID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to generate access token",
+			"user_id", user.ID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "SessionService: Session started",
+		"user_id", user.ID,
+		"session_id", session.ID,
+	)
+
+	return &TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// Refresh exchanges a refresh token for a new pair. Presenting a token that
+// was already used means it leaked, so the whole session is revoked.
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	s.logger.InfoContext(ctx, "SessionService: Refresh")
+
+	token, err := s.sessionRepo.GetRefreshToken(ctx, util.HashToken(refreshToken))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to get refresh token",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if token == nil || !token.ExpiresAt.After(time.Now()) {
+		s.logger.WarnContext(ctx, "SessionService: Unknown or expired refresh token")
+		return nil, errors.New(InvalidRefreshTokenErr)
+	}
+	if token.Session.RevokedAt != nil {
+		s.logger.WarnContext(ctx, "SessionService: Refresh on revoked session",
+			"session_id", token.SessionID,
+		)
+		return nil, errors.New(SessionRevokedErr)
+	}
+	if token.UsedAt != nil {
+		return nil, s.revokeForReuse(ctx, token)
+	}
+
+	// Re-read the user so role changes and deletions take effect on refresh.
+	user, err := s.userRepo.GetByID(ctx, token.Session.UserID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to get user",
+			"user_id", token.Session.UserID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		s.logger.WarnContext(ctx, "SessionService: Session user no longer exists",
+			"user_id", token.Session.UserID,
+		)
+		return nil, errors.New(InvalidRefreshTokenErr)
+	}
+
+	nextRefresh, err := util.GenerateToken()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to generate refresh token",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	next := &models.RefreshToken{
+		TokenHash: util.HashToken(nextRefresh),
+		ExpiresAt: time.Now().Add(s.refreshExpiry),
+	}
+	rotated, err := s.sessionRepo.Rotate(ctx, token.ID, next)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to rotate refresh token",
+			"session_id", token.SessionID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		// A concurrent refresh used the token first.
+		return nil, s.revokeForReuse(ctx, token)
+	}
+
+	accessToken, err := s.jwtSvc.GenerateToken(user.ID, user.Name, user.Role, token.SessionID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to generate access token",
+			"user_id", user.ID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "SessionService: Refresh successful",
+		"user_id", user.ID,
+		"session_id", token.SessionID,
+	)
+
+	return &TokenPair{AccessToken: accessToken, RefreshToken: nextRefresh}, nil
+}
+
+// revokeForReuse revokes the session of a refresh token that was presented
+// after it had already been used.
+func (s *SessionService) revokeForReuse(ctx context.Context, token *models.RefreshToken) error {
+	s.logger.WarnContext(ctx, "SessionService: Refresh token reuse detected, revoking session",
+		"session_id", token.SessionID,
+		"user_id", token.Session.UserID,
+	)
+	if err := s.sessionRepo.Revoke(ctx, token.SessionID, models.SessionRevokedTokenReuse); err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to revoke session",
+			"session_id", token.SessionID,
+			"error", err,
+		)
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return errors.New(SessionRevokedErr)
+}
+
+// End revokes the session the refresh token belongs to. Unknown tokens are
+// ignored so logout always succeeds.
+func (s *SessionService) End(ctx context.Context, refreshToken string) error {
+	s.logger.InfoContext(ctx, "SessionService: End")
+
+	token, err := s.sessionRepo.GetRefreshToken(ctx, util.HashToken(refreshToken))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to get refresh token",
+			"error", err,
+		)
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, token.SessionID, models.SessionRevokedLogout); err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to revoke session",
+			"session_id", token.SessionID,
+			"error", err,
+		)
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "SessionService: Session ended",
+		"session_id", token.SessionID,
+	)
+
+	return nil
+}
+
+// RevokeAll logs the user out everywhere.
+func (s *SessionService) RevokeAll(ctx context.Context, userID int64, reason string) (int64, error) {
+	s.logger.InfoContext(ctx, "SessionService: RevokeAll",
+		"user_id", userID,
+		"reason", reason,
+	)
+
+	count, err := s.sessionRepo.RevokeAllForUser(ctx, userID, reason)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SessionService: Failed to revoke sessions",
+			"user_id", userID,
+			"error", err,
+		)
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "SessionService: Sessions revoked",
+		"user_id", userID,
+		"count", count,
+	)
+
+	return count, nil
+}
+
+// ValidateAccessToken checks the token signature and expiry, then that its
+// session is still active.
+func (s *SessionService) ValidateAccessToken(ctx context.Context, accessToken string) (*JWTClaims, error) {
+	claims, err := s.jwtSvc.ValidateToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.sessionRepo.IsActive(ctx, claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check session: %w", err)
+	}
+	if !active {
+		return nil, errors.New(SessionRevokedErr)
+	}
+
+	return claims, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/JasperRosales/aircraft-system-be/internal/models"
 	"github.com/JasperRosales/aircraft-system-be/internal/repository"
@@ -14,38 +15,86 @@ const (
 	UserNotFoundErr    = "user not found"
 	UserExistsErr      = "user already exists"
 	InvalidPasswordErr = "invalid password"
+	PermissionDenied   = "insufficient permissions"
+	InviteRequiredErr  = "an invite is required to register with this role"
+	InvalidInviteErr   = "invite is invalid, expired or already used"
+	InviteNotFoundErr  = "invite not found"
+	UserNotDeletedErr  = "user is not deleted"
 )
 
+// defaultInviteExpiry applies when CreateInviteRequest omits expires_in_hours.
+const defaultInviteExpiry = 72 * time.Hour
+
 type UserService struct {
-	repo   *repository.UserRepository
-	jwtSvc *JWTService
-	logger *util.Logger
+	tx         *repository.Transactor
+	repo       *repository.UserRepository
+	inviteRepo *repository.UserInviteRepository
+	sessionSvc *SessionService
+	audit      *AuditService
+	logger     *util.Logger
+}
+
+func NewUserService(tx *repository.Transactor, repo *repository.UserRepository, inviteRepo *repository.UserInviteRepository, sessionSvc *SessionService, audit *AuditService, logger *util.Logger) *UserService {
+	return &UserService{tx: tx, repo: repo, inviteRepo: inviteRepo, sessionSvc: sessionSvc, audit: audit, logger: logger}
 }
 
-func NewUserService(repo *repository.UserRepository, jwtSvc *JWTService, logger *util.Logger) *UserService {
-	return &UserService{repo: repo, jwtSvc: jwtSvc, logger: logger}
+// auditUser is the audited form of a user. The password hash is never
+// recorded; PasswordChanged marks updates that set a new one.
+type auditUser struct {
+	models.UserResponse
+	PasswordChanged bool `json:"password_changed,omitempty"`
 }
 
 type LoginResponse struct {
-	User  models.UserResponse `json:"user"`
-	Token string              `json:"token"`
+	User         models.UserResponse `json:"user"`
+	Token        string              `json:"token"`
+	RefreshToken string              `json:"refresh_token"`
 }
 
+// Register creates a new account. Public sign-ups always get the "user" role;
+// mechanics and admins must redeem an invite, which decides the role.
 func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest) (*models.UserResponse, error) {
-	s.logger.Info("UserService: Registering new user",
+	s.logger.InfoContext(ctx, "UserService: Registering new user",
 		"name", req.Name,
+		"with_invite", req.InviteToken != "",
 	)
 
-	existing, err := s.repo.GetByName(ctx, req.Name)
+	var invite *models.UserInvite
+	if req.InviteToken == "" {
+		if req.Role != "" && req.Role != models.RoleUser {
+			s.logger.WarnContext(ctx, "UserService: Elevated role requested without invite",
+				"name", req.Name,
+				"role", req.Role,
+			)
+			return nil, errors.New(InviteRequiredErr)
+		}
+	} else {
+		var err error
+		invite, err = s.inviteRepo.GetByTokenHash(ctx, util.HashToken(req.InviteToken))
+		if err != nil {
+			s.logger.ErrorContext(ctx, "UserService: Failed to get invite",
+				"error", err,
+			)
+			return nil, fmt.Errorf("failed to get invite: %w", err)
+		}
+		if invite == nil || invite.RedeemedAt != nil || !invite.ExpiresAt.After(time.Now()) {
+			s.logger.WarnContext(ctx, "UserService: Invalid invite token",
+				"name", req.Name,
+			)
+			return nil, errors.New(InvalidInviteErr)
+		}
+	}
+
+	existing, err := s.repo.GetByNameWithDeleted(ctx, req.Name)
 	if err != nil {
-		s.logger.Error("UserService: Failed to check existing user",
+		s.logger.ErrorContext(ctx, "UserService: Failed to check existing user",
 			"name", req.Name,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to check existing user: %w", err)
 	}
 	if existing != nil {
-		s.logger.Warn("UserService: User already exists",
+		s.logger.WarnContext(ctx, "UserService: User already exists",
 			"name", req.Name,
 		)
 		return nil, errors.New(UserExistsErr)
@@ -53,7 +102,7 @@ func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest)
 
 	hashedPassword, err := util.HashPassword(req.Password)
 	if err != nil {
-		s.logger.Error("UserService: Failed to hash password",
+		s.logger.ErrorContext(ctx, "UserService: Failed to hash password",
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to hash password: %w", err)
@@ -62,25 +111,32 @@ func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest)
 	user := &models.User{
 		Name:     req.Name,
 		Password: hashedPassword,
-		Role:     req.Role,
-	}
-
-	// Default to "user" if role is not provided or invalid
-	if user.Role == "" {
-		user.Role = "user"
+		Role:     models.RoleUser,
 	}
 
-	if err := s.repo.Create(ctx, user); err != nil {
-		s.logger.Error("UserService: Failed to create user",
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if invite != nil {
+			// Redeem sets the role from the invite.
+			if err := s.inviteRepo.Redeem(ctx, invite.ID, user); err != nil {
+				return err
+			}
+		} else if err := s.repo.Create(ctx, user); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityUser, EntityID: user.ID, Action: models.AuditActionCreate, After: auditUser{UserResponse: user.ToResponse()}})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to create user",
 			"name", req.Name,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	s.logger.Info("UserService: User registered successfully",
+	s.logger.InfoContext(ctx, "UserService: User registered successfully",
 		"user_id", user.ID,
 		"name", user.Name,
+		"role", user.Role,
 	)
 
 	resp := user.ToResponse()
@@ -88,68 +144,77 @@ func (s *UserService) Register(ctx context.Context, req *models.RegisterRequest)
 }
 
 func (s *UserService) Login(ctx context.Context, req *models.LoginRequest) (*LoginResponse, error) {
-	s.logger.Info("UserService: Login attempt",
+	s.logger.InfoContext(ctx, "UserService: Login attempt",
 		"name", req.Name,
 	)
 
 	user, err := s.repo.GetByName(ctx, req.Name)
 	if err != nil {
-		s.logger.Error("UserService: Failed to find user",
+		s.logger.ErrorContext(ctx, "UserService: Failed to find user",
 			"name", req.Name,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
 	if user == nil {
-		s.logger.Warn("UserService: User not found",
+		s.logger.WarnContext(ctx, "UserService: User not found",
 			"name", req.Name,
 		)
 		return nil, errors.New(UserNotFoundErr)
 	}
 
 	if !util.CheckPassword(req.Password, user.Password) {
-		s.logger.Warn("UserService: Invalid password",
+		s.logger.WarnContext(ctx, "UserService: Invalid password",
 			"name", req.Name,
 		)
 		return nil, errors.New(InvalidPasswordErr)
 	}
 
-	token, err := s.jwtSvc.GenerateToken(user.ID, user.Name, user.Role)
+	tokens, err := s.sessionSvc.Start(ctx, user)
 	if err != nil {
-		s.logger.Error("UserService: Failed to generate token",
+		s.logger.ErrorContext(ctx, "UserService: Failed to start session",
 			"user_id", user.ID,
 			"error", err,
 		)
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, fmt.Errorf("failed to start session: %w", err)
 	}
 
-	s.logger.Info("UserService: Login successful",
+	s.logger.InfoContext(ctx, "UserService: Login successful",
 		"user_id", user.ID,
 		"name", user.Name,
 		"role", user.Role,
 	)
 
 	return &LoginResponse{
-		User:  user.ToResponse(),
-		Token: token,
+		User:         user.ToResponse(),
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}, nil
 }
 
-func (s *UserService) GetByID(ctx context.Context, id int64) (*models.UserResponse, error) {
-	s.logger.Info("UserService: GetByID",
+func (s *UserService) GetByID(ctx context.Context, id int64, actorID int64, actorRole string) (*models.UserResponse, error) {
+	s.logger.InfoContext(ctx, "UserService: GetByID",
 		"user_id", id,
 	)
 
+	if actorRole != models.RoleAdmin && id != actorID {
+		s.logger.WarnContext(ctx, "UserService: Cannot view another user's account",
+			"user_id", id,
+			"actor_id", actorID,
+		)
+		return nil, errors.New(PermissionDenied)
+	}
+
 	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("UserService: Failed to get user",
+		s.logger.ErrorContext(ctx, "UserService: Failed to get user",
 			"user_id", id,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		s.logger.Warn("UserService: User not found",
+		s.logger.WarnContext(ctx, "UserService: User not found",
 			"user_id", id,
 		)
 		return nil, errors.New(UserNotFoundErr)
@@ -159,19 +224,24 @@ func (s *UserService) GetByID(ctx context.Context, id int64) (*models.UserRespon
 	return &resp, nil
 }
 
-func (s *UserService) GetAll(ctx context.Context) ([]models.UserResponse, error) {
-	s.logger.Info("UserService: GetAll")
+func (s *UserService) GetAll(ctx context.Context, query *models.UserQuery) (*models.PaginatedResponse[models.UserResponse], error) {
+	s.logger.InfoContext(ctx, "UserService: GetAll",
+		"name", query.Name,
+		"role", query.Role,
+	)
 
-	users, err := s.repo.GetAll(ctx)
+	query.Normalize()
+	users, total, err := s.repo.List(ctx, query)
 	if err != nil {
-		s.logger.Error("UserService: Failed to get users",
+		s.logger.ErrorContext(ctx, "UserService: Failed to get users",
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
 
-	s.logger.Info("UserService: GetAll successful",
+	s.logger.InfoContext(ctx, "UserService: GetAll successful",
 		"count", len(users),
+		"total", total,
 	)
 
 	responses := make([]models.UserResponse, len(users))
@@ -179,55 +249,97 @@ func (s *UserService) GetAll(ctx context.Context) ([]models.UserResponse, error)
 		responses[i] = user.ToResponse()
 	}
 
-	return responses, nil
+	return &models.PaginatedResponse[models.UserResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
 }
 
-func (s *UserService) Update(ctx context.Context, id int64, req *models.UpdateRequest) (*models.UserResponse, error) {
-	s.logger.Info("UserService: Update",
+// Update applies req to the user. Non-admins may only change their own name
+// and password.
+func (s *UserService) Update(ctx context.Context, id int64, req *models.UpdateRequest, actorID int64, actorRole string) (*models.UserResponse, error) {
+	s.logger.InfoContext(ctx, "UserService: Update",
 		"user_id", id,
 	)
 
+	if actorRole != models.RoleAdmin && (id != actorID || req.Role != "") {
+		s.logger.WarnContext(ctx, "UserService: Self-service update not permitted",
+			"user_id", id,
+			"actor_id", actorID,
+			"role_change", req.Role != "",
+		)
+		return nil, errors.New(PermissionDenied)
+	}
+
 	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("UserService: Failed to get user",
+		s.logger.ErrorContext(ctx, "UserService: Failed to get user",
 			"user_id", id,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		s.logger.Warn("UserService: User not found",
+		s.logger.WarnContext(ctx, "UserService: User not found",
 			"user_id", id,
 		)
 		return nil, errors.New(UserNotFoundErr)
 	}
 
+	before := auditUser{UserResponse: user.ToResponse()}
+
+	// Changing the password or role ends every existing login so old
+	// credentials and claims stop working straight away.
+	revokeReason := ""
 	if req.Name != "" {
 		user.Name = req.Name
 	}
-	if req.Role != "" {
+	if req.Role != "" && req.Role != user.Role {
 		user.Role = req.Role
+		revokeReason = models.SessionRevokedRoleChanged
 	}
 	if req.Password != "" {
 		hashedPassword, err := util.HashPassword(req.Password)
 		if err != nil {
-			s.logger.Error("UserService: Failed to hash password",
+			s.logger.ErrorContext(ctx, "UserService: Failed to hash password",
 				"error", err,
 			)
 			return nil, fmt.Errorf("failed to hash password: %w", err)
 		}
 		user.Password = hashedPassword
+		revokeReason = models.SessionRevokedPasswordChanged
 	}
 
-	if err := s.repo.Update(ctx, user); err != nil {
-		s.logger.Error("UserService: Failed to update user",
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, user); err != nil {
+			return err
+		}
+		if revokeReason != "" {
+			if _, err := s.sessionSvc.RevokeAll(ctx, id, revokeReason); err != nil {
+				return err
+			}
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityUser, EntityID: id, Action: models.AuditActionUpdate,
+			Before: before, After: auditUser{UserResponse: user.ToResponse(), PasswordChanged: req.Password != ""}})
+	})
+	if err != nil {
+		// Deleted since it was read.
+		if err.Error() == UserNotFoundErr {
+			s.logger.WarnContext(ctx, "UserService: User not found",
+				"user_id", id,
+			)
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "UserService: Failed to update user",
 			"user_id", id,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
-	s.logger.Info("UserService: Update successful",
+	s.logger.InfoContext(ctx, "UserService: Update successful",
 		"user_id", id,
 	)
 
@@ -236,61 +348,214 @@ func (s *UserService) Update(ctx context.Context, id int64, req *models.UpdateRe
 }
 
 func (s *UserService) Delete(ctx context.Context, id int64) error {
-	s.logger.Info("UserService: Delete",
+	s.logger.InfoContext(ctx, "UserService: Delete",
 		"user_id", id,
 	)
 
 	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error("UserService: Failed to get user",
+		s.logger.ErrorContext(ctx, "UserService: Failed to get user",
 			"user_id", id,
 			"error", err,
 		)
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		s.logger.Warn("UserService: User not found",
+		s.logger.WarnContext(ctx, "UserService: User not found",
 			"user_id", id,
 		)
 		return errors.New(UserNotFoundErr)
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
-		s.logger.Error("UserService: Failed to delete user",
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		// Sessions outlive the soft delete, so end them in the same
+		// transaction rather than relying on the cascade a hard delete used
+		// to trigger: a deleted user is never left signed in.
+		if _, err := s.sessionSvc.RevokeAll(ctx, id, models.SessionRevokedUserDeleted); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityUser, EntityID: id, Action: models.AuditActionDelete, Before: auditUser{UserResponse: user.ToResponse()}})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to delete user",
 			"user_id", id,
 			"error", err,
 		)
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
-	s.logger.Info("UserService: Delete successful",
+	s.logger.InfoContext(ctx, "UserService: Delete successful",
+		"user_id", id,
+	)
+
+	return nil
+}
+
+// Restore undeletes a user. Their old sessions stay revoked; they sign in
+// again.
+func (s *UserService) Restore(ctx context.Context, id int64) (*models.UserResponse, error) {
+	s.logger.InfoContext(ctx, "UserService: Restore",
+		"user_id", id,
+	)
+
+	user, err := s.getDeletedUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := auditUser{UserResponse: user.ToResponse()}
+
+	user.DeletedAt.Valid = false
+	resp := user.ToResponse()
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityUser, EntityID: id, Action: models.AuditActionRestore, Before: before, After: auditUser{UserResponse: resp}})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to restore user",
+			"user_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "UserService: Restore successful",
+		"user_id", id,
+	)
+
+	return &resp, nil
+}
+
+// Purge permanently removes a deleted user and frees their name.
+func (s *UserService) Purge(ctx context.Context, id int64) error {
+	s.logger.InfoContext(ctx, "UserService: Purge",
+		"user_id", id,
+	)
+
+	user, err := s.getDeletedUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Purge(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityUser, EntityID: id, Action: models.AuditActionPurge, Before: auditUser{UserResponse: user.ToResponse()}})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to purge user",
+			"user_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to purge user: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "UserService: Purge successful",
 		"user_id", id,
 	)
 
 	return nil
 }
 
+// getDeletedUser loads a user that restore or purge can act on.
+func (s *UserService) getDeletedUser(ctx context.Context, id int64) (*models.User, error) {
+	user, err := s.repo.GetByIDWithDeleted(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to get user",
+			"user_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		s.logger.WarnContext(ctx, "UserService: User not found",
+			"user_id", id,
+		)
+		return nil, errors.New(UserNotFoundErr)
+	}
+	if !user.DeletedAt.Valid {
+		s.logger.WarnContext(ctx, "UserService: User is not deleted",
+			"user_id", id,
+		)
+		return nil, errors.New(UserNotDeletedErr)
+	}
+
+	return user, nil
+}
+
+// RevokeSessions logs the user out of every session. Users may revoke their
+// own sessions; admins may revoke anyone's.
+func (s *UserService) RevokeSessions(ctx context.Context, id int64, actorID int64, actorRole string) (int64, error) {
+	s.logger.InfoContext(ctx, "UserService: RevokeSessions",
+		"user_id", id,
+		"actor_id", actorID,
+	)
+
+	if actorRole != models.RoleAdmin && id != actorID {
+		s.logger.WarnContext(ctx, "UserService: Cannot revoke another user's sessions",
+			"user_id", id,
+			"actor_id", actorID,
+		)
+		return 0, errors.New(PermissionDenied)
+	}
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to get user",
+			"user_id", id,
+			"error", err,
+		)
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		s.logger.WarnContext(ctx, "UserService: User not found",
+			"user_id", id,
+		)
+		return 0, errors.New(UserNotFoundErr)
+	}
+
+	var revoked int64
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		revoked, err = s.sessionSvc.RevokeAll(ctx, id, models.SessionRevokedLogoutAll)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityUser, EntityID: id, Action: models.AuditActionRevokeSessions})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
 func (s *UserService) GetMe(ctx context.Context, userID int64) (*models.UserResponse, error) {
-	s.logger.Info("UserService: GetMe",
+	s.logger.InfoContext(ctx, "UserService: GetMe",
 		"user_id", userID,
 	)
 
 	user, err := s.repo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("UserService: Failed to get user",
+		s.logger.ErrorContext(ctx, "UserService: Failed to get user",
 			"user_id", userID,
 			"error", err,
 		)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		s.logger.Warn("UserService: User not found",
+		s.logger.WarnContext(ctx, "UserService: User not found",
 			"user_id", userID,
 		)
 		return nil, errors.New(UserNotFoundErr)
 	}
 
-	s.logger.Info("UserService: GetMe successful",
+	s.logger.InfoContext(ctx, "UserService: GetMe successful",
 		"user_id", userID,
 		"name", user.Name,
 		"role", user.Role,
@@ -299,3 +564,117 @@ func (s *UserService) GetMe(ctx context.Context, userID int64) (*models.UserResp
 	resp := user.ToResponse()
 	return &resp, nil
 }
+
+// CreateInvite issues a single-use registration token for role. The raw token
+// is only returned here; afterwards only its hash is kept.
+func (s *UserService) CreateInvite(ctx context.Context, req *models.CreateInviteRequest, actorID int64) (*models.InviteResponse, error) {
+	s.logger.InfoContext(ctx, "UserService: CreateInvite",
+		"role", req.Role,
+		"actor_id", actorID,
+	)
+
+	token, err := util.GenerateToken()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to generate invite token",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to generate invite token: %w", err)
+	}
+
+	expiry := defaultInviteExpiry
+	if req.ExpiresInHours > 0 {
+		expiry = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	invite := &models.UserInvite{
+		TokenHash: util.HashToken(token),
+		Role:      req.Role,
+		CreatedBy: &actorID,
+		ExpiresAt: time.Now().Add(expiry),
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.inviteRepo.Create(ctx, invite); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityUserInvite, EntityID: invite.ID, Action: models.AuditActionCreate, After: invite.ToResponse()})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to create invite",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "UserService: Invite created",
+		"invite_id", invite.ID,
+		"role", invite.Role,
+		"expires_at", invite.ExpiresAt,
+	)
+
+	resp := invite.ToResponse()
+	resp.Token = token
+	return &resp, nil
+}
+
+func (s *UserService) GetInvites(ctx context.Context) ([]models.InviteResponse, error) {
+	s.logger.InfoContext(ctx, "UserService: GetInvites")
+
+	invites, err := s.inviteRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to get invites",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get invites: %w", err)
+	}
+
+	responses := make([]models.InviteResponse, len(invites))
+	for i, invite := range invites {
+		responses[i] = invite.ToResponse()
+	}
+
+	return responses, nil
+}
+
+// RevokeInvite deletes an unredeemed invite. Redeemed invites are kept as a
+// record of who granted the role.
+func (s *UserService) RevokeInvite(ctx context.Context, id int64) error {
+	s.logger.InfoContext(ctx, "UserService: RevokeInvite",
+		"invite_id", id,
+	)
+
+	invite, err := s.inviteRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to get invite",
+			"invite_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to get invite: %w", err)
+	}
+	if invite == nil || invite.RedeemedAt != nil {
+		s.logger.WarnContext(ctx, "UserService: Invite not found or already redeemed",
+			"invite_id", id,
+		)
+		return errors.New(InviteNotFoundErr)
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.inviteRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityUserInvite, EntityID: id, Action: models.AuditActionDelete, Before: invite.ToResponse()})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "UserService: Failed to revoke invite",
+			"invite_id", id,
+			"error", err,
+		)
+		return fmt.Errorf("failed to revoke invite: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "UserService: Invite revoked",
+		"invite_id", id,
+	)
+
+	return nil
+}
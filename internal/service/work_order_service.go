@@ -0,0 +1,683 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const (
+	WorkOrderNotFoundErr          = "work order not found"
+	WorkOrderPartNotFoundErr      = "one or more plane parts not found"
+	WorkOrderAssigneeNotFoundErr  = "assignee not found"
+	WorkOrderAssigneeRoleErr      = "work orders can only be assigned to mechanics"
+	WorkOrderTransitionErr        = "invalid work order status transition"
+	WorkOrderUnassignedErr        = "work order has no assigned mechanic"
+	WorkOrderNotAssigneeErr       = "only the assigned mechanic can update this work order"
+	WorkOrderItemsMismatchErr     = "sign-off must cover every part on the work order exactly once"
+	WorkOrderReplacementExistsErr = "replacement serial number already exists"
+)
+
+type WorkOrderService struct {
+	tx            *repository.Transactor
+	workOrderRepo *repository.WorkOrderRepository
+	planePartRepo *repository.PlanePartRepository
+	userRepo      *repository.UserRepository
+	catalogRepo   *repository.CatalogPartRepository
+	airworthiness *AirworthinessService
+	audit         *AuditService
+	logger        *util.Logger
+}
+
+func NewWorkOrderService(tx *repository.Transactor, workOrderRepo *repository.WorkOrderRepository, planePartRepo *repository.PlanePartRepository, userRepo *repository.UserRepository, catalogRepo *repository.CatalogPartRepository, airworthiness *AirworthinessService, audit *AuditService, logger *util.Logger) *WorkOrderService {
+	return &WorkOrderService{
+		tx:            tx,
+		workOrderRepo: workOrderRepo,
+		planePartRepo: planePartRepo,
+		userRepo:      userRepo,
+		catalogRepo:   catalogRepo,
+		airworthiness: airworthiness,
+		audit:         audit,
+		logger:        logger,
+	}
+}
+
+// auditWorkOrder is the audited form of a work order: its response without
+// the nested part details, which are audited as parts in their own right.
+func auditWorkOrder(workOrder *models.WorkOrder) models.WorkOrderResponse {
+	resp := workOrder.ToResponse()
+	for i := range resp.Parts {
+		resp.Parts[i].Part = nil
+	}
+	return resp
+}
+
+func (s *WorkOrderService) CreateWorkOrder(ctx context.Context, req *models.CreateWorkOrderRequest, actorID int64) (*models.WorkOrderResponse, error) {
+	s.logger.InfoContext(ctx, "WorkOrderService: Creating work order",
+		"title", req.Title,
+		"part_ids", req.PartIDs,
+	)
+
+	partIDs := make([]int64, 0, len(req.PartIDs))
+	seen := make(map[int64]bool, len(req.PartIDs))
+	for _, id := range req.PartIDs {
+		if !seen[id] {
+			seen[id] = true
+			partIDs = append(partIDs, id)
+		}
+	}
+
+	parts, err := s.planePartRepo.GetByIDs(ctx, partIDs)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to verify parts",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to verify parts: %w", err)
+	}
+	if len(parts) != len(partIDs) {
+		s.logger.WarnContext(ctx, "WorkOrderService: Parts not found",
+			"requested", len(partIDs),
+			"found", len(parts),
+		)
+		return nil, errors.New(WorkOrderPartNotFoundErr)
+	}
+
+	if req.AssignedTo != nil {
+		if err := s.verifyMechanic(ctx, *req.AssignedTo); err != nil {
+			return nil, err
+		}
+	}
+
+	workOrder := &models.WorkOrder{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      models.WorkOrderStatusOpen,
+		AssignedTo:  req.AssignedTo,
+		CreatedBy:   actorRef(actorID),
+		Parts:       make([]models.WorkOrderPart, len(partIDs)),
+	}
+	for i, id := range partIDs {
+		workOrder.Parts[i] = models.WorkOrderPart{PartID: id}
+	}
+
+	err = s.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := s.workOrderRepo.Create(ctx, workOrder); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityWorkOrder, EntityID: workOrder.ID, Action: models.AuditActionCreate, After: auditWorkOrder(workOrder)})
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to create work order",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to create work order: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "WorkOrderService: Work order created successfully",
+		"work_order_id", workOrder.ID,
+		"parts", len(partIDs),
+	)
+
+	return s.GetWorkOrder(ctx, workOrder.ID)
+}
+
+func (s *WorkOrderService) GetWorkOrder(ctx context.Context, id int64) (*models.WorkOrderResponse, error) {
+	s.logger.InfoContext(ctx, "WorkOrderService: GetWorkOrder",
+		"work_order_id", id,
+	)
+
+	workOrder, err := s.getWorkOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := workOrder.ToResponse()
+	return &resp, nil
+}
+
+func (s *WorkOrderService) ListWorkOrders(ctx context.Context, query *models.WorkOrderQuery) (*models.PaginatedResponse[models.WorkOrderResponse], error) {
+	s.logger.InfoContext(ctx, "WorkOrderService: ListWorkOrders",
+		"status", query.Status,
+	)
+
+	query.Normalize()
+	workOrders, total, err := s.workOrderRepo.List(ctx, query.Status, query.AssignedTo, query.Offset(), query.PageSize)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to list work orders",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to list work orders: %w", err)
+	}
+
+	responses := make([]models.WorkOrderResponse, len(workOrders))
+	for i, workOrder := range workOrders {
+		responses[i] = workOrder.ToResponse()
+	}
+
+	return &models.PaginatedResponse[models.WorkOrderResponse]{
+		Data:     responses,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+func (s *WorkOrderService) AssignWorkOrder(ctx context.Context, id int64, req *models.AssignWorkOrderRequest) (*models.WorkOrderResponse, error) {
+	s.logger.InfoContext(ctx, "WorkOrderService: AssignWorkOrder",
+		"work_order_id", id,
+		"user_id", req.UserID,
+	)
+
+	if err := s.verifyMechanic(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	var workOrder *models.WorkOrder
+	err := s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		workOrder, err = s.getWorkOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if workOrder.Status != models.WorkOrderStatusOpen && workOrder.Status != models.WorkOrderStatusInProgress {
+			s.logger.WarnContext(ctx, "WorkOrderService: Cannot assign work order in this status",
+				"work_order_id", id,
+				"status", workOrder.Status,
+			)
+			return errors.New(WorkOrderTransitionErr)
+		}
+
+		before := auditWorkOrder(workOrder)
+		workOrder.AssignedTo = &req.UserID
+		assigned, err := s.workOrderRepo.Assign(ctx, workOrder)
+		if err != nil {
+			return err
+		}
+		if !assigned {
+			return errors.New(WorkOrderTransitionErr)
+		}
+		return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityWorkOrder, EntityID: id, Action: models.AuditActionAssign, Before: before, After: auditWorkOrder(workOrder)})
+	})
+	if err != nil {
+		switch err.Error() {
+		case WorkOrderNotFoundErr, WorkOrderTransitionErr:
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to assign work order",
+			"work_order_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to assign work order: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "WorkOrderService: AssignWorkOrder successful",
+		"work_order_id", id,
+		"user_id", req.UserID,
+	)
+
+	resp := workOrder.ToResponse()
+	return &resp, nil
+}
+
+func (s *WorkOrderService) StartWorkOrder(ctx context.Context, id int64, actorID int64, actorRole string) (*models.WorkOrderResponse, error) {
+	s.logger.InfoContext(ctx, "WorkOrderService: StartWorkOrder",
+		"work_order_id", id,
+		"actor_id", actorID,
+	)
+
+	var workOrder *models.WorkOrder
+	err := s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		workOrder, err = s.getWorkOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !models.CanTransitionWorkOrderStatus(workOrder.Status, models.WorkOrderStatusInProgress) {
+			s.logger.WarnContext(ctx, "WorkOrderService: Work order is not open",
+				"work_order_id", id,
+				"status", workOrder.Status,
+			)
+			return errors.New(WorkOrderTransitionErr)
+		}
+		if err := s.checkAssignee(ctx, workOrder, actorID, actorRole); err != nil {
+			return err
+		}
+
+		before := auditWorkOrder(workOrder)
+		workOrder.Status = models.WorkOrderStatusInProgress
+		return s.changeStatus(ctx, workOrder, before, models.WorkOrderStatusOpen, models.AuditActionStart)
+	})
+	if err != nil {
+		switch err.Error() {
+		case WorkOrderNotFoundErr, WorkOrderTransitionErr, WorkOrderUnassignedErr, WorkOrderNotAssigneeErr:
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to start work order",
+			"work_order_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to start work order: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "WorkOrderService: StartWorkOrder successful",
+		"work_order_id", id,
+	)
+
+	resp := workOrder.ToResponse()
+	return &resp, nil
+}
+
+// SignOffWorkOrder records what was done to each part and applies it. The
+// work order stays locked from the checks to the write, so two sign-offs
+// can't both pass and replace a part twice.
+func (s *WorkOrderService) SignOffWorkOrder(ctx context.Context, id int64, req *models.SignOffWorkOrderRequest, actorID int64, actorRole string) (*models.WorkOrderResponse, error) {
+	s.logger.InfoContext(ctx, "WorkOrderService: SignOffWorkOrder",
+		"work_order_id", id,
+		"actor_id", actorID,
+	)
+
+	var replacements map[int64]*models.PlanePart
+	err := s.tx.InTx(ctx, func(ctx context.Context) error {
+		workOrder, err := s.getWorkOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !models.CanTransitionWorkOrderStatus(workOrder.Status, models.WorkOrderStatusSignedOff) {
+			s.logger.WarnContext(ctx, "WorkOrderService: Work order is not in progress",
+				"work_order_id", id,
+				"status", workOrder.Status,
+			)
+			return errors.New(WorkOrderTransitionErr)
+		}
+		if err := s.checkAssignee(ctx, workOrder, actorID, actorRole); err != nil {
+			return err
+		}
+		before := auditWorkOrder(workOrder)
+
+		replacements, err = s.planSignOff(ctx, workOrder, req)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		workOrder.Status = models.WorkOrderStatusSignedOff
+		workOrder.SignedOffBy = actorRef(actorID)
+		workOrder.SignedOffAt = &now
+
+		signedOff, err := s.workOrderRepo.SignOff(ctx, workOrder, replacements, actorRef(actorID))
+		if err != nil {
+			return err
+		}
+		if !signedOff {
+			return errors.New(WorkOrderTransitionErr)
+		}
+		if err := s.audit.Record(ctx, signOffAuditChanges(before, workOrder, replacements)...); err != nil {
+			return err
+		}
+		return s.groundIfOverLimit(ctx, replacements, actorID)
+	})
+	if err != nil {
+		switch err.Error() {
+		case WorkOrderNotFoundErr, WorkOrderTransitionErr, WorkOrderUnassignedErr, WorkOrderNotAssigneeErr,
+			WorkOrderItemsMismatchErr, WorkOrderReplacementExistsErr,
+			LimitRaiseErr, CyclesLimitRaiseErr, CalendarRaiseErr,
+			CatalogPartNotFoundErr, CatalogFieldErr, CatalogLimitErr:
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to sign off work order",
+			"work_order_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to sign off work order: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "WorkOrderService: SignOffWorkOrder successful",
+		"work_order_id", id,
+		"replacements", len(replacements),
+	)
+
+	return s.GetWorkOrder(ctx, id)
+}
+
+// planSignOff checks that req covers every part on the work order exactly
+// once, records each part's action on its line and builds the replacement
+// parts, keyed by the ID of the part each one replaces.
+func (s *WorkOrderService) planSignOff(ctx context.Context, workOrder *models.WorkOrder, req *models.SignOffWorkOrderRequest) (map[int64]*models.PlanePart, error) {
+	items := make(map[int64]models.SignOffItemRequest, len(req.Items))
+	for _, item := range req.Items {
+		if _, dup := items[item.PartID]; dup {
+			return nil, errors.New(WorkOrderItemsMismatchErr)
+		}
+		items[item.PartID] = item
+	}
+	if len(items) != len(workOrder.Parts) {
+		return nil, errors.New(WorkOrderItemsMismatchErr)
+	}
+
+	replacements := make(map[int64]*models.PlanePart)
+	serials := make(map[string]bool)
+	catalogParts := make(map[int64]*models.CatalogPart)
+	for i := range workOrder.Parts {
+		line := &workOrder.Parts[i]
+		item, ok := items[line.PartID]
+		if !ok {
+			s.logger.WarnContext(ctx, "WorkOrderService: Sign-off is missing a part",
+				"work_order_id", workOrder.ID,
+				"part_id", line.PartID,
+			)
+			return nil, errors.New(WorkOrderItemsMismatchErr)
+		}
+
+		action := item.Action
+		line.Action = &action
+		if action != models.WorkOrderActionReplace {
+			continue
+		}
+
+		if serials[item.Replacement.SerialNumber] {
+			return nil, errors.New(WorkOrderReplacementExistsErr)
+		}
+		serials[item.Replacement.SerialNumber] = true
+
+		existing, err := s.planePartRepo.GetBySerialNumberWithDeleted(ctx, item.Replacement.SerialNumber)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "WorkOrderService: Failed to check replacement serial",
+				"serial_number", item.Replacement.SerialNumber,
+				"error", err,
+			)
+			return nil, fmt.Errorf("failed to check replacement serial: %w", err)
+		}
+		if existing != nil {
+			s.logger.WarnContext(ctx, "WorkOrderService: Replacement serial already exists",
+				"serial_number", item.Replacement.SerialNumber,
+			)
+			return nil, errors.New(WorkOrderReplacementExistsErr)
+		}
+
+		replacement := newReplacementPart(line.Part, item.Replacement)
+		if err := s.checkReplacement(ctx, line.Part, replacement, catalogParts); err != nil {
+			return nil, err
+		}
+		replacements[line.PartID] = replacement
+	}
+
+	return replacements, nil
+}
+
+// checkReplacement holds a replacement to the rules for editing the part it
+// replaces. A catalog part keeps the catalog's name and may not exceed its
+// defaults; any other part may tighten the removed part's limits but not
+// raise them. catalogParts caches the entries already loaded.
+func (s *WorkOrderService) checkReplacement(ctx context.Context, removed, replacement *models.PlanePart, catalogParts map[int64]*models.CatalogPart) error {
+	if removed.CatalogPartID == nil {
+		if replacement.UsageLimitHours > removed.UsageLimitHours {
+			return errors.New(LimitRaiseErr)
+		}
+		if removed.UsageLimitCycles != nil && *replacement.UsageLimitCycles > *removed.UsageLimitCycles {
+			return errors.New(CyclesLimitRaiseErr)
+		}
+		if removed.CalendarLimitDays != nil && *replacement.CalendarLimitDays > *removed.CalendarLimitDays {
+			return errors.New(CalendarRaiseErr)
+		}
+		return nil
+	}
+
+	catalogPart, ok := catalogParts[*removed.CatalogPartID]
+	if !ok {
+		var err error
+		catalogPart, err = s.catalogRepo.GetByID(ctx, *removed.CatalogPartID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "WorkOrderService: Failed to get catalog part",
+				"catalog_part_id", *removed.CatalogPartID,
+				"error", err,
+			)
+			return fmt.Errorf("failed to get catalog part: %w", err)
+		}
+		if catalogPart == nil {
+			s.logger.WarnContext(ctx, "WorkOrderService: Catalog part not found",
+				"catalog_part_id", *removed.CatalogPartID,
+			)
+			return errors.New(CatalogPartNotFoundErr)
+		}
+		catalogParts[catalogPart.ID] = catalogPart
+	}
+
+	if replacement.PartName != removed.PartName {
+		s.logger.WarnContext(ctx, "WorkOrderService: Refusing to rename catalog replacement",
+			"catalog_part_id", catalogPart.ID,
+			"serial_number", replacement.SerialNumber,
+		)
+		return errors.New(CatalogFieldErr)
+	}
+	if !catalogPart.WithinDefaults(replacement) {
+		s.logger.WarnContext(ctx, "WorkOrderService: Replacement limits exceed catalog defaults",
+			"catalog_part_id", catalogPart.ID,
+			"serial_number", replacement.SerialNumber,
+		)
+		return errors.New(CatalogLimitErr)
+	}
+	return nil
+}
+
+// groundIfOverLimit grounds each plane a replacement was installed on when
+// the replacement, or any other part there, has overrun a life limit.
+func (s *WorkOrderService) groundIfOverLimit(ctx context.Context, replacements map[int64]*models.PlanePart, actorID int64) error {
+	checked := make(map[int64]bool)
+	for _, replacement := range replacements {
+		if replacement.PlaneID == nil || checked[*replacement.PlaneID] {
+			continue
+		}
+		checked[*replacement.PlaneID] = true
+		if _, err := s.airworthiness.GroundIfOverLimit(ctx, *replacement.PlaneID, actorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// signOffAuditChanges lists what a sign-off changed: the work order itself,
+// parts whose usage was reset, and replaced parts with their replacements.
+func signOffAuditChanges(before models.WorkOrderResponse, workOrder *models.WorkOrder, replacements map[int64]*models.PlanePart) []AuditChange {
+	changes := []AuditChange{{EntityType: models.AuditEntityWorkOrder, EntityID: workOrder.ID, Action: models.AuditActionSignOff, Before: before, After: auditWorkOrder(workOrder)}}
+	for _, line := range workOrder.Parts {
+		if line.Part == nil || line.Action == nil {
+			continue
+		}
+		old := *line.Part
+		after := old
+
+		switch *line.Action {
+		case models.WorkOrderActionReset:
+			if old.UsageHours == 0 && old.UsageCycles == 0 {
+				continue
+			}
+			after.UsageHours = 0
+			after.UsageCycles = 0
+			changes = append(changes, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: old.ID, Action: models.AuditActionUsage, Before: old, After: after})
+
+		case models.WorkOrderActionReplace:
+			if old.PlaneID != nil {
+				after.PlaneID = nil
+				changes = append(changes, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: old.ID, Action: models.AuditActionRemove, Before: old, After: after})
+			}
+			if replacement := replacements[old.ID]; replacement != nil {
+				changes = append(changes, AuditChange{EntityType: models.AuditEntityPlanePart, EntityID: replacement.ID, Action: models.AuditActionCreate, After: replacement})
+			}
+		}
+	}
+	return changes
+}
+
+func (s *WorkOrderService) CloseWorkOrder(ctx context.Context, id int64) (*models.WorkOrderResponse, error) {
+	s.logger.InfoContext(ctx, "WorkOrderService: CloseWorkOrder",
+		"work_order_id", id,
+	)
+
+	var workOrder *models.WorkOrder
+	err := s.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		workOrder, err = s.getWorkOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !models.CanTransitionWorkOrderStatus(workOrder.Status, models.WorkOrderStatusClosed) {
+			s.logger.WarnContext(ctx, "WorkOrderService: Work order is not signed off",
+				"work_order_id", id,
+				"status", workOrder.Status,
+			)
+			return errors.New(WorkOrderTransitionErr)
+		}
+
+		before := auditWorkOrder(workOrder)
+		now := time.Now()
+		workOrder.Status = models.WorkOrderStatusClosed
+		workOrder.ClosedAt = &now
+		return s.changeStatus(ctx, workOrder, before, models.WorkOrderStatusSignedOff, models.AuditActionClose)
+	})
+	if err != nil {
+		switch err.Error() {
+		case WorkOrderNotFoundErr, WorkOrderTransitionErr:
+			return nil, err
+		}
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to close work order",
+			"work_order_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to close work order: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "WorkOrderService: CloseWorkOrder successful",
+		"work_order_id", id,
+	)
+
+	resp := workOrder.ToResponse()
+	return &resp, nil
+}
+
+// changeStatus writes the status set on workOrder, provided it is still in
+// fromStatus, and audits the change.
+func (s *WorkOrderService) changeStatus(ctx context.Context, workOrder *models.WorkOrder, before models.WorkOrderResponse, fromStatus, action string) error {
+	changed, err := s.workOrderRepo.ChangeStatus(ctx, workOrder, fromStatus)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		s.logger.WarnContext(ctx, "WorkOrderService: Work order status changed concurrently",
+			"work_order_id", workOrder.ID,
+			"from", fromStatus,
+		)
+		return errors.New(WorkOrderTransitionErr)
+	}
+	return s.audit.Record(ctx, AuditChange{EntityType: models.AuditEntityWorkOrder, EntityID: workOrder.ID, Action: action, Before: before, After: auditWorkOrder(workOrder)})
+}
+
+func (s *WorkOrderService) getWorkOrder(ctx context.Context, id int64) (*models.WorkOrder, error) {
+	workOrder, err := s.workOrderRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to get work order",
+			"work_order_id", id,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get work order: %w", err)
+	}
+	if workOrder == nil {
+		s.logger.WarnContext(ctx, "WorkOrderService: Work order not found",
+			"work_order_id", id,
+		)
+		return nil, errors.New(WorkOrderNotFoundErr)
+	}
+	return workOrder, nil
+}
+
+// getWorkOrderForUpdate is getWorkOrder that also locks the work order until
+// the transaction in ctx ends.
+func (s *WorkOrderService) getWorkOrderForUpdate(ctx context.Context, id int64) (*models.WorkOrder, error) {
+	workOrder, err := s.workOrderRepo.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if workOrder == nil {
+		s.logger.WarnContext(ctx, "WorkOrderService: Work order not found",
+			"work_order_id", id,
+		)
+		return nil, errors.New(WorkOrderNotFoundErr)
+	}
+	return workOrder, nil
+}
+
+func (s *WorkOrderService) verifyMechanic(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "WorkOrderService: Failed to verify assignee",
+			"user_id", userID,
+			"error", err,
+		)
+		return fmt.Errorf("failed to verify assignee: %w", err)
+	}
+	if user == nil {
+		s.logger.WarnContext(ctx, "WorkOrderService: Assignee not found",
+			"user_id", userID,
+		)
+		return errors.New(WorkOrderAssigneeNotFoundErr)
+	}
+	if user.Role != models.RoleMechanic {
+		s.logger.WarnContext(ctx, "WorkOrderService: Assignee is not a mechanic",
+			"user_id", userID,
+			"role", user.Role,
+		)
+		return errors.New(WorkOrderAssigneeRoleErr)
+	}
+	return nil
+}
+
+// checkAssignee allows the assigned mechanic, or an admin acting on their
+// behalf, to move the work order forward.
+func (s *WorkOrderService) checkAssignee(ctx context.Context, workOrder *models.WorkOrder, actorID int64, actorRole string) error {
+	if workOrder.AssignedTo == nil {
+		s.logger.WarnContext(ctx, "WorkOrderService: Work order is unassigned",
+			"work_order_id", workOrder.ID,
+		)
+		return errors.New(WorkOrderUnassignedErr)
+	}
+	if actorRole != models.RoleAdmin && *workOrder.AssignedTo != actorID {
+		s.logger.WarnContext(ctx, "WorkOrderService: Actor is not the assignee",
+			"work_order_id", workOrder.ID,
+			"actor_id", actorID,
+			"assigned_to", *workOrder.AssignedTo,
+		)
+		return errors.New(WorkOrderNotAssigneeErr)
+	}
+	return nil
+}
+
+func newReplacementPart(removed *models.PlanePart, req *models.ReplacementPartRequest) *models.PlanePart {
+	part := &models.PlanePart{
+		CatalogPartID:     removed.CatalogPartID,
+		PartName:          removed.PartName,
+		SerialNumber:      req.SerialNumber,
+		Category:          removed.Category,
+		UsageHours:        req.UsageHours,
+		UsageLimitHours:   removed.UsageLimitHours,
+		UsageCycles:       req.UsageCycles,
+		UsageLimitCycles:  removed.UsageLimitCycles,
+		CalendarLimitDays: removed.CalendarLimitDays,
+	}
+	if req.PartName != nil {
+		part.PartName = *req.PartName
+	}
+	if req.UsageLimitHours != nil {
+		part.UsageLimitHours = *req.UsageLimitHours
+	}
+	if req.UsageLimitCycles != nil {
+		part.UsageLimitCycles = req.UsageLimitCycles
+	}
+	if req.CalendarLimitDays != nil {
+		part.CalendarLimitDays = req.CalendarLimitDays
+	}
+	return part
+}
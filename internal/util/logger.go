@@ -2,98 +2,243 @@ package util
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	gormLogger "gorm.io/gorm/logger"
 )
 
-type Logger struct{}
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// LevelFatal ranks above slog.LevelError. Fatal logs at it and exits.
+const LevelFatal = slog.Level(12)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are matched against lower-cased log keys; any key containing
+// one has its value replaced, wherever the entry was logged from.
+var sensitiveKeys = []string{"token", "password", "secret", "cookie", "authorization"}
+
+// Logger writes leveled, structured entries as text or JSON. The *Context
+// methods add the request ID carried by ctx, so every line logged while
+// serving a request can be correlated.
+type Logger struct {
+	slog *slog.Logger
+}
 
+type LoggerOptions struct {
+	Level  string    // debug, info (default), warn or error
+	Format string    // text (default) or json
+	Output io.Writer // defaults to os.Stdout
+}
+
+// NewLogger returns a text logger at info level on stdout.
 func NewLogger() *Logger {
-	return &Logger{}
+	logger, _ := NewLoggerWithOptions(LoggerOptions{})
+	return logger
 }
 
-func formatTime() string {
-	return time.Now().Format("2006-01-02 15:04:05")
+func NewLoggerWithOptions(opts LoggerOptions) (*Logger, error) {
+	level, err := ParseLogLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level, ReplaceAttr: replaceAttr}
+	var handler slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", LogFormatText:
+		handler = slog.NewTextHandler(output, handlerOpts)
+	case LogFormatJSON:
+		handler = slog.NewJSONHandler(output, handlerOpts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be text or json", opts.Format)
+	}
+
+	return &Logger{slog: slog.New(contextHandler{handler})}, nil
+}
+
+// ParseLogLevel reads a level name. An empty name means info.
+func ParseLogLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q: must be debug, info, warn or error", name)
+	}
 }
 
 func (l *Logger) Info(message string, keysAndValues ...interface{}) {
-	logWithFormat("INFO", message, keysAndValues...)
+	l.log(context.Background(), slog.LevelInfo, message, keysAndValues...)
 }
 
 func (l *Logger) Warn(message string, keysAndValues ...interface{}) {
-	logWithFormat("WARN", message, keysAndValues...)
+	l.log(context.Background(), slog.LevelWarn, message, keysAndValues...)
 }
 
 func (l *Logger) Error(message string, keysAndValues ...interface{}) {
-	logWithFormat("ERROR", message, keysAndValues...)
+	l.log(context.Background(), slog.LevelError, message, keysAndValues...)
 }
 
 func (l *Logger) Debug(message string, keysAndValues ...interface{}) {
-	logWithFormat("DEBUG", message, keysAndValues...)
+	l.log(context.Background(), slog.LevelDebug, message, keysAndValues...)
 }
 
 func (l *Logger) Fatal(message string, keysAndValues ...interface{}) {
-	logWithFormat("FATAL", message, keysAndValues...)
+	l.log(context.Background(), LevelFatal, message, keysAndValues...)
 	os.Exit(1)
 }
 
-func logWithFormat(level, message string, keysAndValues ...interface{}) {
-	logLine := fmt.Sprintf("[%s] %s: %s", formatTime(), level, message)
+func (l *Logger) InfoContext(ctx context.Context, message string, keysAndValues ...interface{}) {
+	l.log(ctx, slog.LevelInfo, message, keysAndValues...)
+}
+
+func (l *Logger) WarnContext(ctx context.Context, message string, keysAndValues ...interface{}) {
+	l.log(ctx, slog.LevelWarn, message, keysAndValues...)
+}
 
-	if len(keysAndValues) > 0 {
-		logLine += " |"
-		for i := 0; i < len(keysAndValues); i += 2 {
-			if i+1 < len(keysAndValues) {
-				key, ok := keysAndValues[i].(string)
-				if ok {
-					logLine += fmt.Sprintf(" %s=%v", key, keysAndValues[i+1])
-				}
-			}
+func (l *Logger) ErrorContext(ctx context.Context, message string, keysAndValues ...interface{}) {
+	l.log(ctx, slog.LevelError, message, keysAndValues...)
+}
+
+func (l *Logger) DebugContext(ctx context.Context, message string, keysAndValues ...interface{}) {
+	l.log(ctx, slog.LevelDebug, message, keysAndValues...)
+}
+
+// Enabled reports whether entries at level are written, so callers can skip
+// building expensive values.
+func (l *Logger) Enabled(ctx context.Context, level slog.Level) bool {
+	return l.slog.Enabled(ctx, level)
+}
+
+func (l *Logger) log(ctx context.Context, level slog.Level, message string, keysAndValues ...interface{}) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	l.slog.Log(ctx, level, message, keysAndValues...)
+}
+
+// replaceAttr names the fatal level and redacts sensitive values.
+func replaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := a.Value.Any().(slog.Level); ok && level == LevelFatal {
+			return slog.String(slog.LevelKey, "FATAL")
 		}
+		return a
+	}
+	if isSensitiveKey(a.Key) {
+		return slog.String(a.Key, redacted)
 	}
+	return a
+}
 
-	fmt.Println(logLine)
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
 }
 
-type GormLogger struct{}
+// contextHandler adds the request ID stored on the context.
+type contextHandler struct {
+	slog.Handler
+}
 
-func NewGormLogger() *GormLogger {
-	return &GormLogger{}
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// GormLogger sends GORM output through Logger and times every query for
+// the db_query_duration_seconds metric. Failed queries are logged as errors;
+// every other query is logged at debug level.
+type GormLogger struct {
+	logger *Logger
+}
+
+func NewGormLogger(logger *Logger) *GormLogger {
+	return &GormLogger{logger: logger}
 }
 
 func (l *GormLogger) Printf(format string, args ...interface{}) {
-	msg := fmt.Sprintf(format, args...)
-	logWithFormat("SQL", msg)
+	l.logger.Debug(fmt.Sprintf(format, args...))
 }
 
+// LogMode is a no-op: the level is set on Logger.
 func (l *GormLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
 	return l
 }
 
 func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
-	logWithFormat("SQL-INFO", msg, args...)
+	l.logger.InfoContext(ctx, "GORM: "+fmt.Sprintf(msg, args...))
 }
 
 func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
-	logWithFormat("SQL-WARN", msg, args...)
+	l.logger.WarnContext(ctx, "GORM: "+fmt.Sprintf(msg, args...))
 }
 
 func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
-	logWithFormat("SQL-ERROR", msg, args...)
+	l.logger.ErrorContext(ctx, "GORM: "+fmt.Sprintf(msg, args...))
 }
 
 func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
 	elapsed := time.Since(begin)
 	sql, rows := fc()
-	logWithFormat("SQL-TRACE", "SQL executed",
-		"elapsed", elapsed,
-		"sql", sql,
-		"rows", rows,
-		"error", err,
-	)
+	failed := err != nil && !errors.Is(err, gormLogger.ErrRecordNotFound)
+	ObserveDBQuery(sql, failed, elapsed)
+
+	switch {
+	case failed:
+		l.logger.ErrorContext(ctx, "GORM: Query failed",
+			"elapsed", elapsed,
+			"sql", sql,
+			"rows", rows,
+			"error", err,
+		)
+	case l.logger.Enabled(ctx, slog.LevelDebug):
+		l.logger.DebugContext(ctx, "GORM: Query executed",
+			"elapsed", elapsed,
+			"sql", sql,
+			"rows", rows,
+		)
+	}
+}
+
+// ParamsFilter keeps bound values out of logged SQL, so password hashes and
+// token hashes never reach the log. Placeholders are left in place.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
 }
 
 func EnsureLogDirectory() error {
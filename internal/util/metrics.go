@@ -0,0 +1,93 @@
+package util
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsRegistry holds every metric served on /metrics. Collectors that
+// need the database, such as the fleet gauges, are registered by main.
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests served, by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency, by method, route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency, by operation and outcome.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation", "status"})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		dbQueryDuration,
+	)
+}
+
+// MetricsHandler serves MetricsRegistry in the Prometheus text format.
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(MetricsRegistry, promhttp.HandlerOpts{})
+}
+
+// NewMetricsServer serves MetricsHandler at /metrics and nothing else. It
+// is meant for a listener that only the scraper can reach.
+func NewMetricsServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", MetricsHandler())
+	return NewHTTPServer(mux, ServerOptions{
+		Addr:              addr,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	})
+}
+
+// ObserveHTTPRequest records one request. route should be the route
+// template, such as /api/planes/:id, so series don't grow with IDs.
+func ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpRequestDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveDBQuery records one query, labelled by its leading SQL keyword.
+func ObserveDBQuery(sql string, failed bool, elapsed time.Duration) {
+	status := "ok"
+	if failed {
+		status = "error"
+	}
+	dbQueryDuration.WithLabelValues(queryOperation(sql), status).Observe(elapsed.Seconds())
+}
+
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "other"
+	}
+	switch op := strings.ToLower(fields[0]); op {
+	case "select", "insert", "update", "delete":
+		return op
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,33 @@
+package util
+
+import "context"
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	actorIDKey
+)
+
+// WithRequestID returns a copy of ctx carrying the request ID, so code below
+// the HTTP layer can tag logs and audit entries with it.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the request ID stored by WithRequestID, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithActorID returns a copy of ctx carrying the authenticated user's ID.
+func WithActorID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, actorIDKey, userID)
+}
+
+// ActorID returns the user ID stored by WithActorID.
+func ActorID(ctx context.Context) (int64, bool) {
+	id, ok := ctx.Value(actorIDKey).(int64)
+	return id, ok
+}
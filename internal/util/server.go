@@ -0,0 +1,87 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ServerOptions bounds how long a connection may take at each stage. The
+// write timeout covers the whole response; export handlers extend it for
+// their own streams.
+type ServerOptions struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+}
+
+func NewHTTPServer(handler http.Handler, opts ServerOptions) *http.Server {
+	return &http.Server{
+		Addr:              opts.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: opts.ReadHeaderTimeout,
+		ReadTimeout:       opts.ReadTimeout,
+		WriteTimeout:      opts.WriteTimeout,
+		IdleTimeout:       opts.IdleTimeout,
+	}
+}
+
+// RunServer serves until ctx is done, then stops accepting connections and
+// waits up to shutdownTimeout for in-flight requests. It returns nil after a
+// clean drain, or the error that stopped the server early or cut the drain
+// short.
+func RunServer(ctx context.Context, srv *http.Server, shutdownTimeout time.Duration, logger *Logger) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("HTTP server listening", "addr", srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("http server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	logger.Info("Shutting down HTTP server, draining connections", "timeout", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		return fmt.Errorf("failed to drain connections: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("http server stopped: %w", err)
+	}
+
+	logger.Info("HTTP server stopped")
+	return nil
+}
+
+// RunServers runs each server with RunServer. When one stops on its own the
+// others are shut down too, so the process never keeps serving half its
+// listeners. The errors of every server are returned together.
+func RunServers(ctx context.Context, shutdownTimeout time.Duration, logger *Logger, srvs ...*http.Server) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(srvs))
+	var wg sync.WaitGroup
+	for i, srv := range srvs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errs[i] = RunServer(ctx, srv, shutdownTimeout, logger); errs[i] != nil {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
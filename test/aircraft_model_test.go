@@ -0,0 +1,131 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestCheckSlots(t *testing.T) {
+	aircraftModel := models.AircraftModel{
+		ID:   2,
+		Name: "A320",
+		Slots: []models.AircraftModelSlot{
+			{ID: 10, Name: "Engine", Category: "engine", CatalogPartID: int64Ptr(4), Quantity: 2},
+			{ID: 11, Name: "Main gear", Category: "landing gear", Quantity: 2},
+			{ID: 12, Name: "Nose gear", Category: "landing gear", Quantity: 1},
+			{ID: 13, Name: "APU", Category: "apu", Quantity: 1},
+		},
+	}
+	parts := []models.PlanePart{
+		{ID: 1, Category: "engine", CatalogPartID: int64Ptr(4)},
+		{ID: 2, Category: "Engine"},
+		{ID: 3, Category: "landing gear"},
+		{ID: 4, Category: "Landing Gear"},
+		{ID: 5, Category: "landing gear"},
+		{ID: 6, Category: "landing gear"},
+		{ID: 7, Category: "avionics"},
+	}
+
+	report := aircraftModel.CheckSlots(parts)
+
+	assert.Equal(t, int64(2), report.AircraftModelID)
+	assert.False(t, report.Complete)
+	assert.Equal(t, []int64{1}, report.Slots[0].PartIDs)
+	assert.Equal(t, 1, report.Slots[0].Missing, "a category match does not fill a part-number slot")
+	assert.Equal(t, []int64{3, 4, 6}, report.Slots[1].PartIDs, "overflow lands in the first matching slot")
+	assert.Equal(t, 1, report.Slots[1].Surplus)
+	assert.Equal(t, []int64{5}, report.Slots[2].PartIDs)
+	assert.Equal(t, 0, report.Slots[2].Missing)
+	assert.Equal(t, 1, report.Slots[3].Missing)
+	assert.Empty(t, report.Slots[3].PartIDs)
+	assert.Equal(t, []int64{2, 7}, report.UnslottedPartIDs)
+}
+
+func TestCheckSlotsComplete(t *testing.T) {
+	aircraftModel := models.AircraftModel{
+		Slots: []models.AircraftModelSlot{{ID: 1, Name: "Engine", Category: "engine", Quantity: 1}},
+	}
+
+	report := aircraftModel.CheckSlots([]models.PlanePart{{ID: 1, Category: "engine"}, {ID: 2, Category: "engine"}})
+	assert.True(t, report.Complete, "surplus parts do not make a plane incomplete")
+	assert.Equal(t, 1, report.Slots[0].Surplus)
+
+	empty := models.AircraftModel{}
+	report = empty.CheckSlots(nil)
+	assert.True(t, report.Complete)
+	assert.Empty(t, report.Slots)
+	assert.NotNil(t, report.UnslottedPartIDs)
+}
+
+func TestAircraftModelSlotLookup(t *testing.T) {
+	aircraftModel := models.AircraftModel{Slots: []models.AircraftModelSlot{{ID: 1, Name: "Main gear"}}}
+
+	assert.Equal(t, int64(1), aircraftModel.Slot("main GEAR").ID)
+	assert.Nil(t, aircraftModel.Slot("Nose gear"))
+}
+
+func TestAircraftModelResponse(t *testing.T) {
+	aircraftModel := models.AircraftModel{
+		ID:           2,
+		Name:         "A320",
+		Manufacturer: "Airbus",
+		Slots: []models.AircraftModelSlot{
+			{ID: 1, AircraftModelID: 2, Name: "Engine", Category: "engine", Quantity: 2},
+			{ID: 2, AircraftModelID: 2, Name: "APU", CatalogPartID: int64Ptr(4), Quantity: 1},
+		},
+	}
+
+	resp := aircraftModel.ToResponse()
+	assert.Equal(t, "Airbus", resp.Manufacturer)
+	assert.Equal(t, models.AircraftModelSlotResponse{ID: 2, Name: "APU", CatalogPartID: int64Ptr(4), Quantity: 1}, resp.Slots[1])
+
+	empty := models.AircraftModel{Name: "B737"}
+	body, err := json.Marshal(empty.ToResponse())
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"slots":[]`, "a model without slots lists none rather than null")
+}
+
+func TestAircraftModelRequestBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/aircraft-models", func(c *gin.Context) {
+		var req models.CreateAircraftModelRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	tests := map[string]int{
+		`{"name":"A320","manufacturer":"Airbus"}`:                                                                                  http.StatusOK,
+		`{"name":"A320","manufacturer":"Airbus","slots":[{"name":"Engine","catalog_part_id":4,"quantity":2}]}`:                     http.StatusOK,
+		`{"name":"A320","manufacturer":"Airbus","slots":[{"name":"APU","category":"apu","quantity":1}]}`:                           http.StatusOK,
+		`{"name":"A320","manufacturer":"Airbus","slots":[{"name":"APU","quantity":1}]}`:                                            http.StatusBadRequest,
+		`{"name":"A320","manufacturer":"Airbus","slots":[{"name":"APU","category":"apu","quantity":0}]}`:                           http.StatusBadRequest,
+		`{"name":"A320","manufacturer":"Airbus","slots":[{"name":"Engine","category":"engine","catalog_part_id":4,"quantity":2}]}`: http.StatusBadRequest,
+		`{"manufacturer":"Airbus"}`: http.StatusBadRequest,
+	}
+
+	for body, want := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/aircraft-models", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, want, w.Code, body)
+	}
+}
@@ -0,0 +1,158 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func TestAuditDiff(t *testing.T) {
+	before := []byte(`{"id":1,"tail_number":"N101","model":"A320","retired":false}`)
+	after := []byte(`{"id":1,"tail_number":"N102","model":"A320","note":"x"}`)
+
+	changes, err := models.AuditDiff(before, after)
+
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+	assert.JSONEq(t, `"N101"`, string(changes["tail_number"].From))
+	assert.JSONEq(t, `"N102"`, string(changes["tail_number"].To))
+	assert.JSONEq(t, `null`, string(changes["retired"].To))
+	assert.JSONEq(t, `null`, string(changes["note"].From))
+}
+
+func TestAuditHashChain(t *testing.T) {
+	actor := int64(3)
+	after := `{"id":7,"tail_number":"N101"}`
+	first := &models.AuditEntry{
+		ActorID:    &actor,
+		EntityType: models.AuditEntityPlane,
+		EntityID:   7,
+		Action:     models.AuditActionCreate,
+		After:      &after,
+		CreatedAt:  time.Date(2026, 10, 16, 9, 0, 0, 123456789, time.UTC),
+		PrevHash:   models.AuditGenesisHash,
+	}
+	first.Hash = first.ComputeHash()
+
+	second := *first
+	second.ID = 2
+	second.Action = models.AuditActionDelete
+	second.PrevHash = first.Hash
+	second.Hash = second.ComputeHash()
+
+	assert.Len(t, first.Hash, 64)
+	assert.NotEqual(t, first.Hash, second.Hash)
+
+	// Reading back from PostgreSQL drops nanoseconds and changes the zone;
+	// neither may change the hash.
+	stored := *first
+	stored.CreatedAt = first.CreatedAt.Truncate(time.Microsecond).In(time.FixedZone("PHT", 8*3600))
+	assert.Equal(t, first.Hash, stored.ComputeHash())
+
+	// Any edit to a hashed field is detectable.
+	tampered := *first
+	edited := `{"id":7,"tail_number":"N999"}`
+	tampered.After = &edited
+	assert.NotEqual(t, first.Hash, tampered.ComputeHash())
+
+	tampered = *first
+	tampered.ActorID = nil
+	assert.NotEqual(t, first.Hash, tampered.ComputeHash())
+}
+
+func TestAuditEntryResponse(t *testing.T) {
+	before := `{"model":"A320"}`
+	entry := models.AuditEntry{ID: 1, Before: &before}
+
+	body, err := json.Marshal(entry.ToResponse())
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"before":{"model":"A320"}`)
+	assert.Contains(t, string(body), `"after":null`)
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var seen string
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		seen = util.RequestID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	tests := map[string]bool{
+		"trace-abc_123.4":  true,
+		"":                 false,
+		"bad id\nInjected": false,
+	}
+	for header, kept := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(middleware.RequestIDHeader, header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		got := w.Header().Get(middleware.RequestIDHeader)
+		assert.Equal(t, got, seen, header)
+		if kept {
+			assert.Equal(t, header, got)
+		} else {
+			assert.Len(t, got, 32, header)
+		}
+	}
+}
+
+func newMockCatalogService(t *testing.T) (*service.CatalogService, sqlmock.Sqlmock) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db), logger)
+	return service.NewCatalogService(repository.NewTransactor(db), repository.NewCatalogPartRepository(db), auditSvc, logger), mock
+}
+
+func TestAuditCommitsWithChange(t *testing.T) {
+	catalogSvc, mock := newMockCatalogService(t)
+	mock.ExpectQuery(`FROM "catalog_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "catalog_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectExec(`pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT "hash" FROM "audit_log"`).WillReturnRows(sqlmock.NewRows([]string{"hash"}))
+	mock.ExpectQuery(`INSERT INTO "audit_log"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	resp, err := catalogSvc.CreateCatalogPart(context.Background(), &models.CreateCatalogPartRequest{PartNumber: "PN-1", Manufacturer: "Acme", Description: "Pump", DefaultLimitHours: 100})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(7), resp.ID)
+	}
+}
+
+func TestAuditFailureRollsBackChange(t *testing.T) {
+	catalogSvc, mock := newMockCatalogService(t)
+	mock.ExpectQuery(`FROM "catalog_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "catalog_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectExec(`pg_advisory_xact_lock`).WillReturnError(errors.New("lock timeout"))
+	mock.ExpectRollback()
+
+	resp, err := catalogSvc.CreateCatalogPart(context.Background(), &models.CreateCatalogPartRequest{PartNumber: "PN-1", Manufacturer: "Acme", Description: "Pump", DefaultLimitHours: 100})
+
+	assert.Nil(t, resp)
+	assert.ErrorContains(t, err, "lock timeout")
+}
@@ -0,0 +1,338 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/config"
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/routers"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// stubValidator checks the token signature and reports sessions listed in
+// revoked as revoked, standing in for the session table.
+type stubValidator struct {
+	jwtSvc  *service.JWTService
+	revoked map[int64]bool
+	err     error
+}
+
+func (v stubValidator) ValidateAccessToken(_ context.Context, token string) (*service.JWTClaims, error) {
+	claims, err := v.jwtSvc.ValidateToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if v.err != nil {
+		return nil, v.err
+	}
+	if v.revoked[claims.SessionID] {
+		return nil, errors.New(service.SessionRevokedErr)
+	}
+	return claims, nil
+}
+
+var testAuthConfig = config.AuthConfig{
+	Secret:             "authorization-test-secret",
+	AccessTokenExpiry:  15 * time.Minute,
+	RefreshTokenExpiry: 720 * time.Hour,
+}
+
+// newAuthzRouter wires the real routes over a database that refuses every
+// connection, so requests that get past authorization fail with something
+// other than 401/403.
+func newAuthzRouter(t *testing.T) (*gin.Engine, *service.JWTService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: "host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=1",
+	}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	logger := util.NewLogger()
+	txr := repository.NewTransactor(db)
+	userRepo := repository.NewUserRepository(db)
+	planeRepo := repository.NewPlaneRepository(db)
+	planePartRepo := repository.NewPlanePartRepository(db)
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db), logger)
+	airworthinessSvc := service.NewAirworthinessService(txr, planeRepo, planePartRepo, auditSvc, logger)
+	catalogRepo := repository.NewCatalogPartRepository(db)
+	aircraftModelRepo := repository.NewAircraftModelRepository(db)
+	jwtSvc := service.NewJWTService(testAuthConfig)
+	sessionSvc := service.NewSessionService(repository.NewSessionRepository(db), userRepo, jwtSvc, testAuthConfig, logger)
+	auth := stubValidator{jwtSvc: jwtSvc}
+
+	userCtrl := controller.NewUserController(service.NewUserService(txr, userRepo, repository.NewUserInviteRepository(db), sessionSvc, auditSvc, logger), sessionSvc)
+	planeCtrl := controller.NewPlaneController(service.NewPlaneService(txr, planeRepo, planePartRepo, aircraftModelRepo, auditSvc, logger))
+	planePartCtrl := controller.NewPlanePartController(service.NewPlanePartService(txr, planeRepo, planePartRepo,
+		repository.NewPartUsageRepository(db), repository.NewPartInstallationRepository(db), catalogRepo, airworthinessSvc, auditSvc, logger))
+	flightRepo := repository.NewFlightRepository(db)
+	flightCtrl := controller.NewFlightController(service.NewFlightService(txr, planeRepo, flightRepo, airworthinessSvc, auditSvc, logger))
+	forecastCtrl := controller.NewForecastController(service.NewForecastService(planeRepo, planePartRepo, flightRepo, logger))
+	workOrderCtrl := controller.NewWorkOrderController(service.NewWorkOrderService(
+		txr, repository.NewWorkOrderRepository(db), planePartRepo, userRepo, catalogRepo, airworthinessSvc, auditSvc, logger))
+
+	router := gin.New()
+	api := router.Group("/api")
+	routers.SetupUserRoutes(api, userCtrl, auth, logger)
+	routers.SetupPlaneRoutes(api, planeCtrl, planePartCtrl, flightCtrl, forecastCtrl, auth, logger)
+	routers.SetupWorkOrderRoutes(api, workOrderCtrl, auth, logger)
+	routers.SetupSearchRoutes(api, controller.NewSearchController(service.NewSearchService(repository.NewSearchRepository(db), logger)), auth, logger)
+	routers.SetupImportRoutes(api, controller.NewImportController(service.NewImportService(
+		txr, repository.NewImportRepository(db), planeRepo, planePartRepo, catalogRepo, airworthinessSvc, auditSvc, logger)), auth, logger)
+	routers.SetupAuditRoutes(api, controller.NewAuditController(auditSvc), auth, logger)
+	routers.SetupExportRoutes(api, controller.NewExportController(service.NewExportService(planeRepo, planePartRepo, logger)), auth, logger)
+	routers.SetupAirworthinessRoutes(api, controller.NewAirworthinessController(airworthinessSvc), auth, logger)
+	routers.SetupLimitExtensionRoutes(api, controller.NewLimitExtensionController(service.NewLimitExtensionService(
+		txr, repository.NewLimitExtensionRepository(db), planePartRepo, auditSvc, logger)), auth, logger)
+	routers.SetupCatalogRoutes(api, controller.NewCatalogController(service.NewCatalogService(txr, catalogRepo, auditSvc, logger)), auth, logger)
+	routers.SetupAircraftModelRoutes(api, controller.NewAircraftModelController(service.NewAircraftModelService(
+		txr, aircraftModelRepo, planeRepo, planePartRepo, catalogRepo, repository.NewImportRepository(db), airworthinessSvc, auditSvc, logger)), auth, logger)
+
+	return router, jwtSvc
+}
+
+func TestRoleAuthorization(t *testing.T) {
+	router, jwtSvc := newAuthzRouter(t)
+
+	// The user token belongs to user 1, which the /api/users/1 cases rely on.
+	ids := map[string]int64{models.RoleUser: 1, models.RoleMechanic: 2, models.RoleAdmin: 3}
+	tokens := map[string]string{}
+	for role, id := range ids {
+		token, err := jwtSvc.GenerateToken(id, role, role, id)
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+		tokens[role] = token
+	}
+
+	tests := []struct {
+		method  string
+		path    string
+		body    string
+		allowed []string
+	}{
+		{http.MethodGet, "/api/planes", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodPost, "/api/planes", "{}", []string{"admin"}},
+		{http.MethodPut, "/api/planes/1", "{}", []string{"admin"}},
+		{http.MethodPut, "/api/planes/1/status", `{"status":"in_maintenance"}`, []string{"mechanic", "admin"}},
+		{http.MethodPut, "/api/planes/1/status", `{"status":"retired"}`, []string{"admin"}},
+		{http.MethodGet, "/api/planes/1/status/history", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/1/airworthiness", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodDelete, "/api/planes/1", "", []string{"admin"}},
+		{http.MethodPost, "/api/planes/1/restore", "", []string{"admin"}},
+		{http.MethodDelete, "/api/planes/1/purge", "", []string{"admin"}},
+		{http.MethodGet, "/api/planes?deleted=include", "", []string{"admin"}},
+		{http.MethodPost, "/api/planes/1/parts", "{}", []string{"admin"}},
+		{http.MethodGet, "/api/planes/parts/1", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodPut, "/api/planes/parts/1", "{}", []string{"admin"}},
+		{http.MethodDelete, "/api/planes/parts/1", "", []string{"admin"}},
+		{http.MethodPost, "/api/planes/parts/1/restore", "", []string{"admin"}},
+		{http.MethodDelete, "/api/planes/parts/1/purge", "", []string{"admin"}},
+		{http.MethodGet, "/api/planes/parts?deleted=only", "", []string{"admin"}},
+		{http.MethodGet, "/api/planes/parts/spares?deleted=include", "", []string{"admin"}},
+		{http.MethodGet, "/api/planes/maintenance/alerts?deleted=include", "", []string{"admin"}},
+		{http.MethodGet, "/api/planes/export?deleted=include", "", []string{"admin"}},
+		{http.MethodPut, "/api/planes/parts/1/usage", "{}", []string{"mechanic", "admin"}},
+		{http.MethodPost, "/api/planes/parts/1/usage/history", "{}", []string{"mechanic", "admin"}},
+		{http.MethodPost, "/api/planes/parts/1/install", "{}", []string{"mechanic", "admin"}},
+		{http.MethodPost, "/api/planes/parts/1/remove", "{}", []string{"mechanic", "admin"}},
+		{http.MethodPost, "/api/planes/parts/1/extensions", "{}", []string{"mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/parts/1/extensions", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/extensions?status=pending", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/extensions/1", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodPost, "/api/planes/extensions/1/approve", "", []string{"admin"}},
+		{http.MethodPost, "/api/planes/extensions/1/reject", "", []string{"admin"}},
+		{http.MethodPost, "/api/planes/1/flights", "{}", []string{"mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/maintenance/alerts", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/maintenance/alerts/export?format=ndjson", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/parts/export", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/export", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodPost, "/api/planes/import?dry_run=true", `{"planes":[{"tail_number":"N1","model":"A320"}]}`, []string{"admin"}},
+		{http.MethodGet, "/api/search?q=N123", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/catalog", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/catalog/1", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodPost, "/api/catalog", "{}", []string{"admin"}},
+		{http.MethodPut, "/api/catalog/1", "{}", []string{"admin"}},
+		{http.MethodDelete, "/api/catalog/1", "", []string{"admin"}},
+		{http.MethodGet, "/api/aircraft-models", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/aircraft-models/1", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodPost, "/api/aircraft-models", "{}", []string{"admin"}},
+		{http.MethodPut, "/api/aircraft-models/1", "{}", []string{"admin"}},
+		{http.MethodDelete, "/api/aircraft-models/1", "", []string{"admin"}},
+		{http.MethodPost, "/api/aircraft-models/1/planes", "{}", []string{"admin"}},
+		{http.MethodGet, "/api/planes/1/forecast", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/planes/maintenance/forecast?days=60", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodGet, "/api/work-orders", "", []string{"user", "mechanic", "admin"}},
+		{http.MethodPost, "/api/work-orders", "{}", []string{"mechanic", "admin"}},
+		{http.MethodPost, "/api/work-orders/1/assign", "{}", []string{"admin"}},
+		{http.MethodPost, "/api/work-orders/1/start", "", []string{"mechanic", "admin"}},
+		{http.MethodPost, "/api/work-orders/1/sign-off", "{}", []string{"mechanic", "admin"}},
+		{http.MethodPost, "/api/work-orders/1/close", "", []string{"admin"}},
+		{http.MethodGet, "/api/users", "", []string{"admin"}},
+		{http.MethodGet, "/api/users/1", "", []string{"user", "admin"}},
+		{http.MethodPut, "/api/users/1", `{"name":"Renamed"}`, []string{"user", "admin"}},
+		{http.MethodPut, "/api/users/1", `{"role":"admin"}`, []string{"admin"}},
+		{http.MethodDelete, "/api/users/1", "", []string{"admin"}},
+		{http.MethodPost, "/api/users/1/restore", "", []string{"admin"}},
+		{http.MethodDelete, "/api/users/1/purge", "", []string{"admin"}},
+		{http.MethodDelete, "/api/users/1/sessions", "", []string{"user", "admin"}},
+		{http.MethodPost, "/api/users/invites", `{"role":"mechanic"}`, []string{"admin"}},
+		{http.MethodGet, "/api/users/invites", "", []string{"admin"}},
+		{http.MethodDelete, "/api/users/invites/1", "", []string{"admin"}},
+		{http.MethodGet, "/api/audit?entity_type=plane", "", []string{"admin"}},
+		{http.MethodGet, "/api/audit/verify", "", []string{"admin"}},
+	}
+
+	for _, tt := range tests {
+		for role, token := range tokens {
+			t.Run(role+" "+tt.method+" "+tt.path+" "+tt.body, func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Authorization", "Bearer "+token)
+
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				assert.NotEqual(t, http.StatusUnauthorized, w.Code)
+				if slices.Contains(tt.allowed, role) {
+					assert.NotEqual(t, http.StatusForbidden, w.Code)
+				} else {
+					assert.Equal(t, http.StatusForbidden, w.Code)
+				}
+			})
+		}
+	}
+}
+
+func TestUnauthenticatedRequestsRejected(t *testing.T) {
+	router, _ := newAuthzRouter(t)
+
+	for _, path := range []string{"/api/planes", "/api/users", "/api/work-orders"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, path)
+	}
+}
+
+func TestRegisterElevatedRoleRequiresInvite(t *testing.T) {
+	router, _ := newAuthzRouter(t)
+
+	for _, role := range []string{models.RoleMechanic, models.RoleAdmin} {
+		body := `{"name":"intruder","password":"secret123","role":"` + role + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/users/register", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, role)
+		assert.Contains(t, w.Body.String(), "invite is required")
+	}
+}
+
+func TestAuthMiddlewareChecksSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtSvc := service.NewJWTService(testAuthConfig)
+	logger := util.NewLogger()
+
+	token, err := jwtSvc.GenerateToken(1, "pilot", models.RoleUser, 42)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	claims, err := jwtSvc.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), claims.SessionID)
+
+	tests := []struct {
+		name      string
+		validator stubValidator
+		want      int
+	}{
+		{"active session", stubValidator{jwtSvc: jwtSvc}, http.StatusOK},
+		{"revoked session", stubValidator{jwtSvc: jwtSvc, revoked: map[int64]bool{42: true}}, http.StatusUnauthorized},
+		{"session lookup failure", stubValidator{jwtSvc: jwtSvc, err: errors.New("connection refused")}, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/secure", middleware.AuthMiddleware(logger, tt.validator), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestRefreshWithoutCookieRejected(t *testing.T) {
+	router, _ := newAuthzRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/refresh", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestConcurrentRefreshRevokesSession(t *testing.T) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	sessionSvc := service.NewSessionService(repository.NewSessionRepository(db), repository.NewUserRepository(db),
+		service.NewJWTService(testAuthConfig), testAuthConfig, logger)
+
+	expires := time.Now().Add(time.Hour)
+	mock.ExpectQuery(`FROM "refresh_tokens"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "session_id", "expires_at"}).AddRow(7, 3, expires))
+	mock.ExpectQuery(`FROM "sessions"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "expires_at"}).AddRow(3, 4, expires))
+	mock.ExpectQuery(`FROM "users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).AddRow(4, "mechanic", models.RoleUser))
+	// Another refresh used the token between the read and the rotation.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM "refresh_tokens" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "session_id", "expires_at", "used_at"}).AddRow(7, 3, expires, time.Now()))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "sessions" SET "revoked_at"=\$1,"revoked_reason"=\$2`).
+		WithArgs(sqlmock.AnyArg(), models.SessionRevokedTokenReuse, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	pair, err := sessionSvc.Refresh(context.Background(), "refresh-token")
+
+	assert.Nil(t, pair)
+	assert.EqualError(t, err, service.SessionRevokedErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
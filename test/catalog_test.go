@@ -0,0 +1,177 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+func TestCatalogAppliesTo(t *testing.T) {
+	catalogPart := models.CatalogPart{}
+	assert.True(t, catalogPart.AppliesTo("A320"), "no models means any aircraft")
+
+	catalogPart.SetApplicableModels([]string{"A320", " a320 ", "A321", ""})
+	assert.Len(t, catalogPart.ApplicableModels, 2)
+	assert.True(t, catalogPart.AppliesTo("a321"))
+	assert.False(t, catalogPart.AppliesTo("B737"))
+	assert.Equal(t, []string{"A320", "A321"}, catalogPart.ToResponse().ApplicableModels)
+}
+
+func TestCatalogInherit(t *testing.T) {
+	catalogPart := models.CatalogPart{
+		ID:                 4,
+		Description:        "Fan blade",
+		Category:           "engine",
+		DefaultLimitHours:  5000,
+		DefaultLimitCycles: intPtr(3000),
+	}
+
+	part := models.PlanePart{SerialNumber: "SN-1"}
+	catalogPart.Inherit(&part)
+	assert.Equal(t, int64(4), *part.CatalogPartID)
+	assert.Equal(t, "Fan blade", part.PartName)
+	assert.Equal(t, "engine", part.Category)
+	assert.Equal(t, 5000.0, part.UsageLimitHours)
+	assert.Equal(t, 3000, *part.UsageLimitCycles)
+	assert.Nil(t, part.CalendarLimitDays)
+	assert.True(t, catalogPart.WithinDefaults(&part))
+
+	tighter := models.PlanePart{UsageLimitHours: 4000}
+	catalogPart.Inherit(&tighter)
+	assert.Equal(t, 4000.0, tighter.UsageLimitHours, "a given limit overrides the default")
+	assert.True(t, catalogPart.WithinDefaults(&tighter))
+
+	looser := models.PlanePart{UsageLimitHours: 6000}
+	catalogPart.Inherit(&looser)
+	assert.False(t, catalogPart.WithinDefaults(&looser))
+
+	looser = models.PlanePart{UsageLimitCycles: intPtr(3500)}
+	catalogPart.Inherit(&looser)
+	assert.False(t, catalogPart.WithinDefaults(&looser))
+}
+
+func TestCreatePartRequestCatalogBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/parts", func(c *gin.Context) {
+		var req models.CreatePlanePartRequest
+		req.PlaneID = 1
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	tests := map[string]int{
+		`{"serial_number":"SN-1","catalog_part_id":4}`:                                                                         http.StatusOK,
+		`{"serial_number":"SN-1","catalog_part_id":4,"usage_limit_hours":4000}`:                                                http.StatusOK,
+		`{"serial_number":"SN-1","part_name":"Fan blade","category":"engine","usage_limit_hours":5000}`:                        http.StatusOK,
+		`{"serial_number":"SN-1","part_name":"Fan blade","category":"engine"}`:                                                 http.StatusBadRequest,
+		`{"serial_number":"SN-1","usage_limit_hours":5000}`:                                                                    http.StatusBadRequest,
+		`{"serial_number":"SN-1","catalog_part_id":4,"part_name":"Fan blade"}`:                                                 http.StatusBadRequest,
+		`{"serial_number":"SN-1","catalog_part_id":4,"category":"engine"}`:                                                     http.StatusBadRequest,
+		`{"serial_number":"SN-1","catalog_part_id":null,"part_name":"Fan blade","category":"engine","usage_limit_hours":5000}`: http.StatusOK,
+	}
+
+	for body, want := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/parts", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, want, w.Code, body+" "+w.Body.String())
+	}
+}
+
+func catalogRow() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "part_number", "description", "category", "default_limit_hours", "default_limit_cycles"}).
+		AddRow(4, "PN-FAN", "Fan blade", "engine", 5000, 3000)
+}
+
+func TestUpdatePartLinksSpareToCatalog(t *testing.T) {
+	planePartSvc, mock := newMockPlanePartService(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM "plane_parts" .* FOR UPDATE`).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "part_name", "serial_number", "category", "usage_limit_hours"}).AddRow(5, "Blade", "SN-1", "misc", 4000))
+	mock.ExpectQuery(`FROM "catalog_parts"`).WillReturnRows(catalogRow())
+	mock.ExpectQuery(`FROM "catalog_part_models"`).WillReturnRows(sqlmock.NewRows([]string{"catalog_part_id", "model"}))
+	mock.ExpectExec(`UPDATE "plane_parts" SET .*"catalog_part_id"=\$\d+`).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	w := updatePart(planePartSvc, `{"catalog_part_id":4}`)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"catalog_part_id":4`)
+	assert.Contains(t, w.Body.String(), `"part_name":"Fan blade"`)
+	assert.Contains(t, w.Body.String(), `"usage_limit_cycles":3000`, "an unset limit takes the catalog default")
+}
+
+func TestUpdatePartCatalogLinkChecks(t *testing.T) {
+	partColumns := []string{"id", "plane_id", "catalog_part_id", "part_name", "category", "usage_limit_hours", "usage_limit_cycles"}
+
+	t.Run("limits looser than the defaults", func(t *testing.T) {
+		planePartSvc, mock := newMockPlanePartService(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM "plane_parts" .* FOR UPDATE`).WillReturnRows(sqlmock.NewRows(partColumns).AddRow(5, nil, nil, "Blade", "misc", 6000, nil))
+		mock.ExpectQuery(`FROM "catalog_parts"`).WillReturnRows(catalogRow())
+		mock.ExpectQuery(`FROM "catalog_part_models"`).WillReturnRows(sqlmock.NewRows([]string{"catalog_part_id", "model"}))
+		mock.ExpectRollback()
+
+		w := updatePart(planePartSvc, `{"catalog_part_id":4}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), service.CatalogLimitErr)
+	})
+
+	t.Run("installed on a model the entry does not fit", func(t *testing.T) {
+		planePartSvc, mock := newMockPlanePartService(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM "plane_parts" .* FOR UPDATE`).WillReturnRows(sqlmock.NewRows(partColumns).AddRow(5, 1, nil, "Blade", "misc", 4000, nil))
+		mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusActive))
+		mock.ExpectQuery(`FROM "catalog_parts"`).WillReturnRows(catalogRow())
+		mock.ExpectQuery(`FROM "catalog_part_models"`).WillReturnRows(sqlmock.NewRows([]string{"catalog_part_id", "model"}).AddRow(4, "B737"))
+		mock.ExpectRollback()
+
+		w := updatePart(planePartSvc, `{"catalog_part_id":4}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), service.CatalogModelErr)
+	})
+
+	t.Run("already linked to another entry", func(t *testing.T) {
+		planePartSvc, mock := newMockPlanePartService(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM "plane_parts" .* FOR UPDATE`).WillReturnRows(sqlmock.NewRows(partColumns).AddRow(5, nil, 3, "Pump", "hydraulics", 800, nil))
+		mock.ExpectRollback()
+
+		w := updatePart(planePartSvc, `{"catalog_part_id":4}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), service.CatalogRelinkErr)
+	})
+
+	t.Run("renaming a linked part", func(t *testing.T) {
+		planePartSvc, mock := newMockPlanePartService(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM "plane_parts" .* FOR UPDATE`).WillReturnRows(sqlmock.NewRows(partColumns).AddRow(5, nil, 4, "Fan blade", "engine", 4000, 3000))
+		mock.ExpectQuery(`FROM "catalog_parts"`).WillReturnRows(catalogRow())
+		mock.ExpectQuery(`FROM "catalog_part_models"`).WillReturnRows(sqlmock.NewRows([]string{"catalog_part_id", "model"}))
+		mock.ExpectRollback()
+
+		w := updatePart(planePartSvc, `{"catalog_part_id":4,"part_name":"Big blade"}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), service.CatalogFieldErr)
+	})
+}
@@ -0,0 +1,182 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/config"
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+)
+
+// clearConfigEnv blanks every variable config reads, which Load treats as
+// unset, so the host environment can't leak into a test.
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"CONFIG_FILE", "PORT", "METRICS_ADDR", "HTTP_READ_HEADER_TIMEOUT", "HTTP_READ_TIMEOUT", "HTTP_WRITE_TIMEOUT",
+		"HTTP_IDLE_TIMEOUT", "HTTP_SHUTDOWN_TIMEOUT", "GOOSE_DBSTRING", "DB_MAX_OPEN_CONNS",
+		"DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME", "DB_CONN_MAX_IDLE_TIME", "SECRET",
+		"ACCESS_TOKEN_EXP", "REFRESH_TOKEN_EXP", "ORIGIN", "LOG_LEVEL", "LOG_FORMAT",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestConfigLoadPrecedence(t *testing.T) {
+	clearConfigEnv(t)
+	dir := t.TempDir()
+	yamlPath := writeFile(t, dir, "config.yaml", `
+server:
+  port: 9000
+  write_timeout: 90s
+database:
+  dsn: postgres://yaml
+auth:
+  secret: yaml-secret
+cors:
+  origins:
+    - https://app.example.com/
+log:
+  level: warn
+`)
+	envPath := writeFile(t, dir, ".env", "CONFIG_FILE="+yamlPath+"\nPORT=9100\nLOG_LEVEL=debug\nACCESS_TOKEN_EXP=30\n")
+	t.Setenv("PORT", "9200")
+	t.Setenv("REFRESH_TOKEN_EXP", "48h")
+	t.Setenv("ORIGIN", "https://app.example.com, http://localhost:5173")
+
+	cfg, err := config.Load(envPath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9200, cfg.Server.Port, "environment beats .env and YAML")
+	assert.Equal(t, ":9200", cfg.Server.Addr())
+	assert.Equal(t, "debug", cfg.Log.Level, ".env beats YAML")
+	assert.Equal(t, 90*time.Second, cfg.Server.WriteTimeout, "YAML beats defaults")
+	assert.Equal(t, 30*time.Second, cfg.Server.ReadTimeout, "default kept")
+	assert.Equal(t, "postgres://yaml", cfg.Database.DSN)
+	assert.Equal(t, 30*time.Minute, cfg.Auth.AccessTokenExpiry, "bare number is minutes")
+	assert.Equal(t, 48*time.Hour, cfg.Auth.RefreshTokenExpiry)
+	assert.Equal(t, []string{"https://app.example.com", "http://localhost:5173"}, cfg.CORS.Origins)
+}
+
+func TestConfigLoadWithoutEnvFile(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("GOOSE_DBSTRING", "postgres://env")
+	t.Setenv("SECRET", "env-secret")
+	t.Setenv("ORIGIN", "http://localhost:3000")
+
+	cfg, err := config.Load(filepath.Join(t.TempDir(), ".env"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Server.Port)
+	assert.Equal(t, "127.0.0.1:9090", cfg.Server.MetricsAddr, "metrics stay on loopback by default")
+	assert.Equal(t, 25, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 720*time.Hour, cfg.Auth.RefreshTokenExpiry)
+}
+
+func TestConfigLoadReportsUnparsableValues(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("PORT", "eighty")
+	t.Setenv("HTTP_WRITE_TIMEOUT", "60")
+
+	_, err := config.Load(filepath.Join(t.TempDir(), ".env"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `PORT must be an integer, got "eighty"`)
+	assert.Contains(t, err.Error(), `HTTP_WRITE_TIMEOUT must be a duration such as 30s or 5m, got "60"`)
+}
+
+func TestConfigLoadRejectsUnknownYAMLKeys(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("CONFIG_FILE", writeFile(t, t.TempDir(), "config.yaml", "server:\n  prot: 9000\n"))
+
+	_, err := config.Load(filepath.Join(t.TempDir(), ".env"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field prot not found")
+}
+
+func TestConfigValidateReportsEveryProblem(t *testing.T) {
+	cfg := config.Default()
+	cfg.Server.Port = 0
+	cfg.Server.MetricsAddr = "9090"
+	cfg.Database.MaxIdleConns = 50
+	cfg.Auth.RefreshTokenExpiry = time.Minute
+	cfg.CORS.Origins = []string{"*", "app.example.com", "https://ok.example.com"}
+	cfg.Log.Format = "xml"
+
+	err := cfg.Validate()
+
+	assert.Error(t, err)
+	for _, want := range []string{
+		"server.port (PORT) must be between 1 and 65535",
+		"server.metrics_addr (METRICS_ADDR) must be host:port, such as 127.0.0.1:9090",
+		"database.dsn (GOOSE_DBSTRING) is required",
+		"database.max_idle_conns (DB_MAX_IDLE_CONNS) must not exceed database.max_open_conns",
+		"auth.secret (SECRET) is required",
+		"auth.refresh_token_expiry (REFRESH_TOKEN_EXP) must be longer than auth.access_token_expiry",
+		`cors.origins (ORIGIN) has an invalid origin "*"`,
+		`cors.origins (ORIGIN) has an invalid origin "app.example.com"`,
+		"log.format (LOG_FORMAT) must be text or json",
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+	assert.NotContains(t, err.Error(), "ok.example.com")
+}
+
+func TestConfigValidateMetricsAddr(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.DSN = "postgres://test"
+	cfg.Auth.Secret = "secret"
+	cfg.CORS.Origins = []string{"http://localhost:5173"}
+
+	cfg.Server.MetricsAddr = ":9090"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Server.MetricsAddr = "0.0.0.0:8080"
+	assert.ErrorContains(t, cfg.Validate(), "server.metrics_addr (METRICS_ADDR) must not use server.port")
+
+	cfg.Server.MetricsAddr = "localhost:metrics"
+	assert.ErrorContains(t, cfg.Validate(), "server.metrics_addr (METRICS_ADDR) must have a port between 1 and 65535")
+}
+
+func TestCORSMiddlewareAllowsConfiguredOrigins(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.CORSMiddleware([]string{"https://app.example.com", "http://localhost:5173"}))
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, origin := range []string{"https://app.example.com", "http://localhost:5173"} {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, origin, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "Origin", w.Header().Get("Vary"))
+	}
+
+	req := httptest.NewRequest(http.MethodOptions, "/ping", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func TestExportQueryValidation(t *testing.T) {
+	router, jwtSvc := newAuthzRouter(t)
+	token, err := jwtSvc.GenerateToken(1, "user", models.RoleUser, 1)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	for _, path := range []string{
+		"/api/planes/export?format=xlsx",
+		"/api/planes/parts/export?sort=password",
+		"/api/planes/maintenance/alerts/export?threshold=-5",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
+
+func TestExportFailureBeforeFirstRowIsJSONError(t *testing.T) {
+	router, jwtSvc := newAuthzRouter(t)
+	token, err := jwtSvc.GenerateToken(1, "user", models.RoleUser, 1)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	// The test database refuses connections, so the query fails before any
+	// row is written.
+	req := httptest.NewRequest(http.MethodGet, "/api/planes/parts/export?format=csv&category=engine", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), "failed to export")
+}
+
+func TestExportOutlivesServerWriteTimeout(t *testing.T) {
+	db, mock := newMockDB(t)
+	exportSvc := service.NewExportService(repository.NewPlaneRepository(db), repository.NewPlanePartRepository(db), util.NewLogger())
+	exportCtrl := controller.NewExportController(exportSvc)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/planes/export", exportCtrl.ExportPlanes)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve a port: %v", err)
+	}
+	srv := util.NewHTTPServer(router, util.ServerOptions{WriteTimeout: 200 * time.Millisecond})
+	go srv.Serve(listener)
+	defer srv.Shutdown(context.Background())
+
+	// The query alone takes longer than the server's write timeout.
+	mock.ExpectQuery(`FROM "planes"`).
+		WillDelayFor(500 * time.Millisecond).
+		WillReturnRows(planeRow(models.PlaneStatusActive))
+
+	resp, err := http.Get("http://" + listener.Addr().String() + "/planes/export?format=ndjson")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `"tail_number":"N100"`)
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+const flightBody = `{"departure_at":"2026-10-01T06:00:00Z","arrival_at":"2026-10-01T08:00:00Z"}`
+
+func newMockFlightService(t *testing.T) (*service.FlightService, sqlmock.Sqlmock) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	txr := repository.NewTransactor(db)
+	planeRepo := repository.NewPlaneRepository(db)
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db), logger)
+	airworthinessSvc := service.NewAirworthinessService(txr, planeRepo, repository.NewPlanePartRepository(db), auditSvc, logger)
+	return service.NewFlightService(txr, planeRepo, repository.NewFlightRepository(db), airworthinessSvc, auditSvc, logger), mock
+}
+
+func recordFlight(flightSvc *service.FlightService) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/planes/:id/flights", controller.NewFlightController(flightSvc).RecordFlight)
+
+	req := httptest.NewRequest(http.MethodPost, "/planes/1/flights", strings.NewReader(flightBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func planeRow(status string) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "tail_number", "model", "status"}).AddRow(1, "N100", "A320", status)
+}
+
+func expectAuditAppend(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT "hash" FROM "audit_log"`).WillReturnRows(sqlmock.NewRows([]string{"hash"}))
+	mock.ExpectQuery(`INSERT INTO "audit_log"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+func TestRecordFlightRefusesGroundedPlane(t *testing.T) {
+	flightSvc, mock := newMockFlightService(t)
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusGrounded))
+
+	w := recordFlight(flightSvc)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), service.PlaneNotOperationalErr)
+}
+
+func TestRecordFlightGroundsPlaneWithPartsOverLimit(t *testing.T) {
+	flightSvc, mock := newMockFlightService(t)
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusActive))
+	mock.ExpectBegin()
+	// A calendar limit that lapsed without a write: nothing grounded the
+	// plane yet.
+	mock.ExpectQuery(`FROM "plane_parts" WHERE plane_id = .*LOCALTIMESTAMP`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "plane_id", "serial_number"}).AddRow(5, 1, "SN-CAL"))
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusActive))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "planes" SET "status"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "plane_status_changes"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	w := recordFlight(flightSvc)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), service.PlanePartsOverLimitErr)
+}
+
+func TestRecordFlightFailsWhenGroundingFails(t *testing.T) {
+	flightSvc, mock := newMockFlightService(t)
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusActive))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`INSERT INTO "flights"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE plane_id = .* FOR UPDATE`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery(`INSERT INTO "part_usage_entries"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(`UPDATE "plane_parts"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM "planes" .* FOR UPDATE`).WillReturnRows(planeRow(models.PlaneStatusActive))
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	resp, err := flightSvc.RecordFlight(context.Background(), 1, &models.CreateFlightRequest{
+		DepartureAt: time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC),
+		ArrivalAt:   time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+	}, 3)
+
+	assert.Nil(t, resp)
+	assert.ErrorContains(t, err, "connection reset")
+}
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+func TestDailyRates(t *testing.T) {
+	now := time.Now()
+	u := models.PlaneUtilization{WindowStart: now.AddDate(0, 0, -10), Hours: 50, Cycles: 20}
+
+	hours, cycles := u.DailyRates(now)
+
+	assert.InDelta(t, 5, hours, 0.001)
+	assert.InDelta(t, 2, cycles, 0.001)
+
+	// Planes created today are averaged over at least one day.
+	u = models.PlaneUtilization{WindowStart: now.Add(-time.Hour), Hours: 3}
+	hours, _ = u.DailyRates(now)
+	assert.InDelta(t, 3, hours, 0.001)
+}
+
+func TestForecastHoursDrive(t *testing.T) {
+	now := time.Now()
+	planeID := int64(7)
+	part := models.PlanePart{
+		ID:              1,
+		PlaneID:         &planeID,
+		UsageHours:      900,
+		UsageLimitHours: 1000,
+		InstalledAt:     now,
+	}
+
+	f := part.Forecast(now, 10, 0)
+
+	assert.Equal(t, planeID, f.PlaneID)
+	assert.Equal(t, models.LimitDriverHours, f.LimitDriver)
+	assert.InDelta(t, 10, *f.DaysRemaining, 0.001)
+	assert.InDelta(t, 100, f.RemainingHours, 0.001)
+	assert.False(t, f.Overdue)
+}
+
+func TestForecastEarliestLimitWins(t *testing.T) {
+	now := time.Now()
+	part := models.PlanePart{
+		UsageHours:        0,
+		UsageLimitHours:   1000,
+		UsageCycles:       90,
+		UsageLimitCycles:  intPtr(100),
+		CalendarLimitDays: intPtr(365),
+		InServiceAt:       now,
+	}
+
+	f := part.Forecast(now, 10, 2)
+
+	assert.Equal(t, models.LimitDriverCycles, f.LimitDriver)
+	assert.InDelta(t, 5, *f.DaysRemaining, 0.001)
+	assert.Equal(t, 10, *f.RemainingCycles)
+}
+
+func TestForecastIdlePlaneFallsBackToCalendar(t *testing.T) {
+	now := time.Now()
+	part := models.PlanePart{
+		UsageHours:        100,
+		UsageLimitHours:   1000,
+		CalendarLimitDays: intPtr(30),
+		InServiceAt:       now.AddDate(0, 0, -20),
+	}
+
+	f := part.Forecast(now, 0, 0)
+
+	assert.Equal(t, models.LimitDriverCalendar, f.LimitDriver)
+	assert.InDelta(t, 10, *f.DaysRemaining, 0.01)
+
+	part.CalendarLimitDays = nil
+	f = part.Forecast(now, 0, 0)
+	assert.Nil(t, f.DueAt)
+	assert.Nil(t, f.DaysRemaining)
+}
+
+func TestForecastExhaustedLimitIsOverdue(t *testing.T) {
+	now := time.Now()
+	part := models.PlanePart{
+		UsageHours:      1000,
+		UsageLimitHours: 1000,
+		InstalledAt:     now,
+	}
+
+	f := part.Forecast(now, 0, 0)
+
+	assert.True(t, f.Overdue)
+	assert.Equal(t, now, *f.DueAt)
+}
+
+func TestSortForecasts(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	forecasts := []models.PartForecast{
+		{PartID: 1},
+		{PartID: 2, DueAt: &later},
+		{PartID: 3, DueAt: &now},
+	}
+
+	models.SortForecasts(forecasts)
+
+	assert.Equal(t, int64(3), forecasts[0].PartID)
+	assert.Equal(t, int64(2), forecasts[1].PartID)
+	assert.Equal(t, int64(1), forecasts[2].PartID)
+}
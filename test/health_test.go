@@ -0,0 +1,62 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/JasperRosales/aircraft-system-be/database"
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/routers"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func TestLatestMigration(t *testing.T) {
+	version, err := database.LatestMigration()
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, version, int64(20261016153000))
+}
+
+func TestHealthProbesWithDatabaseDown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: "host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=1",
+	}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	healthSvc := service.NewHealthService(repository.NewHealthRepository(db), 20261016153000, util.NewLogger())
+	router := gin.New()
+	routers.SetupHealthRoutes(&router.RouterGroup, controller.NewHealthController(healthSvc))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "liveness does not depend on the database")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var report models.ReadinessReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.False(t, report.Ready)
+	assert.Equal(t, models.HealthStatusFail, report.Database.Status)
+	assert.Equal(t, "database unreachable", report.Database.Error)
+	assert.Equal(t, int64(20261016153000), report.Migrations.Expected)
+}
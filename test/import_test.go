@@ -0,0 +1,158 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func TestParsePlanesCSV(t *testing.T) {
+	rows, errs := service.ParsePlanesCSV(strings.NewReader("\ufeffModel,Tail_Number\nA320, N101\nB737,N102\n"))
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []models.ImportPlaneRow{
+		{TailNumber: "N101", Model: "A320"},
+		{TailNumber: "N102", Model: "B737"},
+	}, rows)
+
+	_, errs = service.ParsePlanesCSV(strings.NewReader("tail_number,colour\nN101,red\n"))
+	assert.ElementsMatch(t, []models.ImportRowError{
+		{Section: models.ImportSectionPlanes, Field: "colour", Message: "unknown column"},
+		{Section: models.ImportSectionPlanes, Field: "model", Message: "missing column"},
+	}, errs)
+}
+
+func TestParsePartsCSV(t *testing.T) {
+	csv := "tail_number,part_name,serial_number,category,usage_hours,usage_limit_hours,usage_limit_cycles\n" +
+		"N101,Fan Blade,SN-1,engine,120.5,1000,500\n" +
+		",Spare Pump,SN-2,hydraulics,,800,\n" +
+		"N101,Bad Row,SN-3,engine,lots,1000,\n"
+
+	rows, errs := service.ParsePartsCSV(strings.NewReader(csv))
+
+	assert.Len(t, rows, 3)
+	assert.Equal(t, 120.5, rows[0].UsageHours)
+	assert.Equal(t, 500, *rows[0].UsageLimitCycles)
+	assert.Equal(t, "", rows[1].TailNumber)
+	assert.Nil(t, rows[1].UsageLimitCycles)
+	assert.Equal(t, []models.ImportRowError{
+		{Section: models.ImportSectionParts, Row: 3, Field: "usage_hours", Message: `"lots" is not a number`},
+	}, errs)
+}
+
+func TestValidateImportRows(t *testing.T) {
+	req := &models.ImportRequest{
+		Planes: []models.ImportPlaneRow{
+			{TailNumber: "N101", Model: "A320"},
+			{TailNumber: "N101", Model: "A321"},
+			{TailNumber: "N", Model: ""},
+		},
+		Parts: []models.ImportPartRow{
+			{TailNumber: "N101", PartName: "Fan Blade", SerialNumber: "SN-1", Category: "engine", UsageLimitHours: 1000},
+			{PartName: "Pump", SerialNumber: "SN-1", Category: "hydraulics", UsageHours: -1, UsageLimitCycles: intPtr(0)},
+			{PartName: "x", SerialNumber: "SN-3", Category: "engine"},
+		},
+	}
+	prior := []models.ImportRowError{{Section: models.ImportSectionParts, Row: 3, Field: "usage_hours", Message: "bad"}}
+
+	errs := service.ValidateImportRows(req, prior)
+
+	assert.ElementsMatch(t, []models.ImportRowError{
+		{Section: models.ImportSectionPlanes, Row: 2, Field: "tail_number", Message: "duplicates row 1"},
+		{Section: models.ImportSectionPlanes, Row: 3, Field: "tail_number", Message: "must be 2 to 50 characters"},
+		{Section: models.ImportSectionPlanes, Row: 3, Field: "model", Message: "is required"},
+		{Section: models.ImportSectionParts, Row: 2, Field: "usage_hours", Message: "must not be negative"},
+		{Section: models.ImportSectionParts, Row: 2, Field: "usage_limit_hours", Message: "must be greater than 0"},
+		{Section: models.ImportSectionParts, Row: 2, Field: "usage_limit_cycles", Message: "must be greater than 0"},
+		{Section: models.ImportSectionParts, Row: 2, Field: "serial_number", Message: "duplicates row 1"},
+	}, errs)
+}
+
+func TestParsePartsCSVWithPartNumbers(t *testing.T) {
+	rows, errs := service.ParsePartsCSV(strings.NewReader("tail_number,part_number,serial_number\nN101,PN-FAN,SN-1\n"))
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []models.ImportPartRow{{TailNumber: "N101", PartNumber: "PN-FAN", SerialNumber: "SN-1"}}, rows)
+}
+
+func TestValidateImportRowsWithPartNumbers(t *testing.T) {
+	req := &models.ImportRequest{
+		Parts: []models.ImportPartRow{
+			{PartNumber: "PN-FAN", SerialNumber: "SN-1"},
+			{PartNumber: "PN-FAN", SerialNumber: "SN-2", PartName: "Fan blade", Category: "engine", UsageLimitHours: -1},
+			{SerialNumber: "SN-3", PartName: "Pump", Category: "hydraulics"},
+		},
+	}
+
+	errs := service.ValidateImportRows(req, nil)
+
+	assert.ElementsMatch(t, []models.ImportRowError{
+		{Section: models.ImportSectionParts, Row: 2, Field: "part_name", Message: "not allowed with part_number"},
+		{Section: models.ImportSectionParts, Row: 2, Field: "category", Message: "not allowed with part_number"},
+		{Section: models.ImportSectionParts, Row: 2, Field: "usage_limit_hours", Message: "must be greater than 0"},
+		{Section: models.ImportSectionParts, Row: 3, Field: "usage_limit_hours", Message: "must be greater than 0"},
+	}, errs)
+}
+
+func TestImportChecksCatalogLinks(t *testing.T) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	txr := repository.NewTransactor(db)
+	planeRepo := repository.NewPlaneRepository(db)
+	planePartRepo := repository.NewPlanePartRepository(db)
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db), logger)
+	importSvc := service.NewImportService(txr, repository.NewImportRepository(db), planeRepo, planePartRepo,
+		repository.NewCatalogPartRepository(db), service.NewAirworthinessService(txr, planeRepo, planePartRepo, auditSvc, logger), auditSvc, logger)
+
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(sqlmock.NewRows([]string{"id", "tail_number", "model"}).AddRow(1, "N100", "B737"))
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id", "serial_number"}))
+	mock.ExpectQuery(`FROM "catalog_parts"`).WillReturnRows(catalogRow())
+	mock.ExpectQuery(`FROM "catalog_part_models"`).WillReturnRows(sqlmock.NewRows([]string{"catalog_part_id", "model"}).AddRow(4, "A320"))
+
+	resp, err := importSvc.Import(context.Background(), &models.ImportRequest{
+		Planes: []models.ImportPlaneRow{{TailNumber: "N101", Model: "A320"}},
+		Parts: []models.ImportPartRow{
+			{TailNumber: "N101", PartNumber: "PN-FAN", SerialNumber: "SN-1"},
+			{TailNumber: "N100", PartNumber: "PN-FAN", SerialNumber: "SN-2"},
+			{TailNumber: "N101", PartNumber: "PN-FAN", SerialNumber: "SN-3", UsageLimitHours: 6000},
+			{PartNumber: "PN-NONE", SerialNumber: "SN-4"},
+		},
+	}, true, 0)
+
+	if assert.NoError(t, err) {
+		assert.False(t, resp.Valid)
+		assert.Equal(t, []models.ImportRowError{
+			{Section: models.ImportSectionParts, Row: 2, Field: "part_number", Message: service.CatalogModelErr},
+			{Section: models.ImportSectionParts, Row: 3, Field: "part_number", Message: service.CatalogLimitErr},
+			{Section: models.ImportSectionParts, Row: 4, Field: "part_number", Message: service.CatalogPartNotFoundErr},
+		}, resp.Errors)
+	}
+}
+
+func TestImportRejectsEmptyBody(t *testing.T) {
+	router, jwtSvc := newAuthzRouter(t)
+	token, err := jwtSvc.GenerateToken(3, "admin", models.RoleAdmin, 3)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	for _, path := range []string{"/api/planes/import", "/api/planes/import?dry_run=maybe"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"planes":[],"parts":[]}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+)
+
+func sparePartRow() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "serial_number", "part_name", "usage_hours", "usage_cycles"}).
+		AddRow(5, "SN-5", "Fan blade", 300, 120)
+}
+
+func installedPartRow() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "serial_number", "part_name", "plane_id", "usage_hours", "usage_cycles"}).
+		AddRow(5, "SN-5", "Fan blade", 1, 300, 120)
+}
+
+func TestInstallPartOpensInstallation(t *testing.T) {
+	svc, mock := newMockPlanePartService(t)
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sparePartRow())
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusActive))
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).WillReturnRows(sparePartRow())
+	mock.ExpectExec(`UPDATE "plane_parts" SET "installed_at"=\$1,"plane_id"=\$2 WHERE`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "part_installations"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE plane_id = \$1`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	resp, err := svc.InstallPart(context.Background(), 5, &models.InstallPartRequest{PlaneID: 1}, 7)
+
+	if assert.NoError(t, err) && assert.NotNil(t, resp.PlaneID) {
+		assert.Equal(t, int64(1), *resp.PlaneID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInstallPartRefusesPlaneOutOfService(t *testing.T) {
+	for _, status := range []string{models.PlaneStatusGrounded, models.PlaneStatusRetired} {
+		t.Run(status, func(t *testing.T) {
+			svc, mock := newMockPlanePartService(t)
+			mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sparePartRow())
+			mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(status))
+
+			resp, err := svc.InstallPart(context.Background(), 5, &models.InstallPartRequest{PlaneID: 1}, 7)
+
+			assert.Nil(t, resp)
+			assert.EqualError(t, err, service.PlaneNotOperationalErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInstallPartRefusesInstalledPart(t *testing.T) {
+	svc, mock := newMockPlanePartService(t)
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(installedPartRow())
+
+	resp, err := svc.InstallPart(context.Background(), 5, &models.InstallPartRequest{PlaneID: 2}, 7)
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, service.PartInstalledErr)
+}
+
+func TestInstallPartRefusesConcurrentInstall(t *testing.T) {
+	svc, mock := newMockPlanePartService(t)
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sparePartRow())
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusActive))
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	// Another request installed the part between the read and the lock.
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).WillReturnRows(installedPartRow())
+	mock.ExpectRollback()
+
+	resp, err := svc.InstallPart(context.Background(), 5, &models.InstallPartRequest{PlaneID: 1}, 7)
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, service.PartInstalledErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemovePartClosesInstallation(t *testing.T) {
+	svc, mock := newMockPlanePartService(t)
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(installedPartRow())
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).WillReturnRows(installedPartRow())
+	mock.ExpectExec(`UPDATE "part_installations" SET "cycles_at_removal"=\$1,"hours_at_removal"=\$2,"removal_reason"=\$3,"removed_at"=\$4,"removed_by"=\$5 WHERE part_id = \$6 AND removed_at IS NULL`).
+		WithArgs(120, 300.0, "bird strike", sqlmock.AnyArg(), 7, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "plane_parts" SET "plane_id"=\$1`).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	resp, err := svc.RemovePart(context.Background(), 5, &models.RemovePartRequest{Reason: "bird strike"}, 7)
+
+	if assert.NoError(t, err) {
+		assert.Nil(t, resp.PlaneID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemovePartRefusesSparePart(t *testing.T) {
+	svc, mock := newMockPlanePartService(t)
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sparePartRow())
+
+	resp, err := svc.RemovePart(context.Background(), 5, &models.RemovePartRequest{}, 7)
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, service.PartNotInstalledErr)
+}
+
+func TestGetPartHistoryBySerialReportsEachInstallation(t *testing.T) {
+	svc, mock := newMockPlanePartService(t)
+	installed := time.Now().AddDate(0, 0, -30)
+	removed := installed.AddDate(0, 0, 10)
+	reinstalled := removed.AddDate(0, 0, 5)
+	mock.ExpectQuery(`FROM "plane_parts" WHERE serial_number = \$1`).WithArgs("SN-5", 1).WillReturnRows(installedPartRow())
+	mock.ExpectQuery(`FROM "part_installations" WHERE part_id = \$1 ORDER BY installed_at, id`).WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "part_id", "plane_id", "installed_at", "removed_at", "hours_at_install", "cycles_at_install", "hours_at_removal", "cycles_at_removal"}).
+			AddRow(1, 5, 2, installed, removed, 100, 40, 180, 70).
+			AddRow(2, 5, 1, reinstalled, nil, 180, 70, nil, nil))
+	mock.ExpectQuery(`FROM "planes" WHERE "planes"."id" IN`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tail_number"}).AddRow(1, "N100").AddRow(2, "N200"))
+
+	history, err := svc.GetPartHistoryBySerial(context.Background(), "SN-5")
+
+	if assert.NoError(t, err) && assert.Len(t, history.Installations, 2) {
+		first, current := history.Installations[0], history.Installations[1]
+		assert.Equal(t, "N200", first.TailNumber)
+		assert.Equal(t, 80.0, first.HoursAccrued)
+		assert.Equal(t, 30, first.CyclesAccrued)
+		assert.InDelta(t, 10, first.DurationDays, 0.001)
+		// The open installation runs up to the part's current totals.
+		assert.Equal(t, "N100", current.TailNumber)
+		assert.Nil(t, current.RemovedAt)
+		assert.Equal(t, 120.0, current.HoursAccrued)
+		assert.Equal(t, 50, current.CyclesAccrued)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
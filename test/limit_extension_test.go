@@ -0,0 +1,139 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func newMockLimitExtensionService(t *testing.T) (*service.LimitExtensionService, sqlmock.Sqlmock) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db), logger)
+	return service.NewLimitExtensionService(repository.NewTransactor(db), repository.NewLimitExtensionRepository(db),
+		repository.NewPlanePartRepository(db), auditSvc, logger), mock
+}
+
+func pendingExtensionRow(requestedBy int64) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "part_id", "requested_hours", "justification", "status", "requested_by"}).
+		AddRow(3, 5, 200, "borescope inspection found no wear", models.ExtensionStatusPending, requestedBy)
+}
+
+func TestExtensionRaisesEffectiveLimit(t *testing.T) {
+	now := time.Now()
+	part := models.PlanePart{UsageHours: 1100, UsageLimitHours: 1000, InstalledAt: now}
+	assert.True(t, part.OverLimit(now))
+
+	part.ExtensionHours = 200
+	assert.Equal(t, 1200.0, part.EffectiveLimitHours())
+	assert.False(t, part.OverLimit(now), "an approved extension clears the overrun")
+
+	percent, _ := part.LifeUsed(now)
+	assert.InDelta(t, 91.67, percent, 0.01)
+
+	f := part.Forecast(now, 10, 0)
+	assert.InDelta(t, 100, f.RemainingHours, 0.001)
+
+	resp := part.ToResponse()
+	assert.Equal(t, 1000.0, resp.UsageLimitHours, "the original limit is reported unchanged")
+	assert.Equal(t, 1200.0, resp.EffectiveLimitHours)
+}
+
+func TestRequestExtensionStartsPending(t *testing.T) {
+	extensionSvc, mock := newMockLimitExtensionService(t)
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id", "usage_limit_hours"}).AddRow(5, 1000))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "part_limit_extensions"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	resp, err := extensionSvc.RequestExtension(context.Background(), 5,
+		&models.CreateLimitExtensionRequest{RequestedHours: 200, Justification: "borescope inspection found no wear"}, 7)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, models.ExtensionStatusPending, resp.Status)
+		assert.Equal(t, int64(7), *resp.RequestedBy)
+	}
+}
+
+func TestRequesterCannotApproveOwnExtension(t *testing.T) {
+	extensionSvc, mock := newMockLimitExtensionService(t)
+	mock.ExpectQuery(`FROM "part_limit_extensions"`).WillReturnRows(pendingExtensionRow(7))
+
+	resp, err := extensionSvc.ApproveExtension(context.Background(), 3, &models.ReviewLimitExtensionRequest{}, 7)
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, service.LimitExtensionSelfReviewErr)
+}
+
+func TestApprovedExtensionAddsHoursToPart(t *testing.T) {
+	extensionSvc, mock := newMockLimitExtensionService(t)
+	mock.ExpectQuery(`FROM "part_limit_extensions"`).WillReturnRows(pendingExtensionRow(7))
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id", "usage_limit_hours"}).AddRow(5, 1000))
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "part_limit_extensions" SET .* WHERE id = \$\d+ AND status = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "plane_parts" SET "extension_hours"=extension_hours \+ \$1`).
+		WithArgs(200.0, int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	resp, err := extensionSvc.ApproveExtension(context.Background(), 3, &models.ReviewLimitExtensionRequest{Note: "approved per inspection"}, 8)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, models.ExtensionStatusApproved, resp.Status)
+		assert.Equal(t, int64(8), *resp.ReviewedBy)
+	}
+}
+
+// updatePart sends body to PUT /planes/parts/5.
+func updatePart(planePartSvc *service.PlanePartService, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/planes/parts/:partId", controller.NewPlanePartController(planePartSvc).UpdatePart)
+
+	req := httptest.NewRequest(http.MethodPut, "/planes/parts/5", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUpdatePartRefusesRaisingLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+		want string
+	}{
+		{"hours", `{"usage_limit_hours":1500}`, service.LimitRaiseErr},
+		{"cycles", `{"usage_limit_cycles":600}`, service.CyclesLimitRaiseErr},
+		{"calendar", `{"calendar_limit_days":400}`, service.CalendarRaiseErr},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			planePartSvc, mock := newMockPlanePartService(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery(`FROM "plane_parts" .* FOR UPDATE`).WillReturnRows(
+				sqlmock.NewRows([]string{"id", "usage_limit_hours", "usage_limit_cycles", "calendar_limit_days"}).AddRow(5, 1000, 500, 365))
+			mock.ExpectRollback()
+
+			w := updatePart(planePartSvc, tc.body)
+
+			assert.Equal(t, http.StatusConflict, w.Code)
+			assert.Contains(t, w.Body.String(), tc.want)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+func TestPaginationQueryNormalize(t *testing.T) {
+	q := models.PaginationQuery{}
+	q.Normalize()
+	assert.Equal(t, 1, q.Page)
+	assert.Equal(t, models.DefaultPageSize, q.PageSize)
+	assert.Equal(t, 0, q.Offset())
+
+	q = models.PaginationQuery{Page: 3, PageSize: 500}
+	q.Normalize()
+	assert.Equal(t, models.MaxPageSize, q.PageSize)
+	assert.Equal(t, 200, q.Offset())
+}
+
+func TestPartQueryBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var bound models.MaintenanceAlertQuery
+	router := gin.New()
+	router.GET("/alerts", func(c *gin.Context) {
+		bound = models.MaintenanceAlertQuery{}
+		if err := c.ShouldBindQuery(&bound); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/alerts?threshold=90&page=2&page_size=10&category=engine&min_usage_hours=100&installed_from=2026-01-01&installed_to=2026-03-31&sort=installed_at&order=desc&installed=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 90.0, *bound.Threshold)
+	assert.Equal(t, 2, bound.Page)
+	assert.Equal(t, 10, bound.PageSize)
+	assert.Equal(t, "engine", bound.Category)
+	assert.Equal(t, 100.0, *bound.MinUsageHours)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), bound.InstalledFrom.UTC())
+	assert.Equal(t, "installed_at", bound.Sort)
+	assert.Equal(t, models.SortDesc, bound.Order)
+	assert.Nil(t, bound.Installed, "installed is set by the endpoint, not the query string")
+
+	for _, query := range []string{"sort=password", "order=sideways", "page=-1", "page_size=1000", "installed_from=yesterday", "deleted=all"} {
+		req := httptest.NewRequest(http.MethodGet, "/alerts?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestSearchQueryValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/search", func(c *gin.Context) {
+		var query models.SearchQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	tests := map[string]int{
+		"q=SN-12":           http.StatusOK,
+		"q=fan&type=part":   http.StatusOK,
+		"q=N1&type=plane":   http.StatusOK,
+		"":                  http.StatusBadRequest,
+		"q=x":               http.StatusBadRequest,
+		"q=fan&type=flight": http.StatusBadRequest,
+	}
+
+	for query, want := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/search?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, want, w.Code, query)
+	}
+}
+
+func TestDeletedFilter(t *testing.T) {
+	assert.False(t, models.PlaneQuery{}.IncludesDeleted())
+	assert.True(t, models.PartQuery{DeletedFilter: models.DeletedFilter{Deleted: models.DeletedOnly}}.IncludesDeleted())
+
+	alerts := models.MaintenanceAlertQuery{}
+	alerts.Deleted = models.DeletedInclude
+	assert.True(t, alerts.IncludesDeleted(), "alerts inherit the filter from PartQuery")
+}
+
+func TestDeletedAtInResponse(t *testing.T) {
+	plane := models.Plane{ID: 1, TailNumber: "N1"}
+	assert.Nil(t, plane.ToResponse().DeletedAt)
+
+	now := time.Now()
+	plane.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+	assert.Equal(t, now, *plane.ToResponse().DeletedAt)
+}
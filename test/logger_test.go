@@ -0,0 +1,74 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func TestLoggerJSONWithRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := util.NewLoggerWithOptions(util.LoggerOptions{Format: "json", Output: &buf})
+	assert.NoError(t, err)
+
+	ctx := util.WithRequestID(context.Background(), "req-42")
+	logger.InfoContext(ctx, "PlaneService: GetPlane", "plane_id", 7)
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "PlaneService: GetPlane", entry["msg"])
+	assert.Equal(t, "req-42", entry["request_id"])
+	assert.Equal(t, 7.0, entry["plane_id"])
+}
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := util.NewLoggerWithOptions(util.LoggerOptions{Output: &buf})
+	assert.NoError(t, err)
+
+	logger.Info("Auth", "token", "eyJhbGciOi", "Authorization", "Bearer eyJhbGciOi", "new_password", "hunter22", "session_cookie", "abc", "user_id", 3)
+
+	out := buf.String()
+	assert.NotContains(t, out, "eyJhbGciOi")
+	assert.NotContains(t, out, "hunter22")
+	assert.NotContains(t, out, "abc")
+	assert.Equal(t, 4, strings.Count(out, "[REDACTED]"))
+	assert.Contains(t, out, "user_id=3")
+}
+
+func TestLoggerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := util.NewLoggerWithOptions(util.LoggerOptions{Level: "warn", Output: &buf})
+	assert.NoError(t, err)
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+
+	out := buf.String()
+	assert.NotContains(t, out, "msg=debug")
+	assert.NotContains(t, out, "msg=info")
+	assert.Contains(t, out, "msg=warn")
+	assert.Contains(t, out, "msg=error")
+
+	_, err = util.NewLoggerWithOptions(util.LoggerOptions{Level: "verbose"})
+	assert.Error(t, err)
+	_, err = util.NewLoggerWithOptions(util.LoggerOptions{Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestGormLoggerHidesBoundValues(t *testing.T) {
+	gormLogger := util.NewGormLogger(util.NewLogger())
+
+	sql, params := gormLogger.ParamsFilter(context.Background(), "UPDATE users SET password_hash = $1", "$2a$10$secret")
+	assert.Equal(t, "UPDATE users SET password_hash = $1", sql)
+	assert.Empty(t, params)
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/middleware"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// findMetric returns the series of family name whose labels include want.
+func findMetric(t *testing.T, name string, want map[string]string) *dto.Metric {
+	families, err := util.MetricsRegistry.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	next:
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			for k, v := range want {
+				if labels[k] != v {
+					continue next
+				}
+			}
+			return metric
+		}
+	}
+	return nil
+}
+
+func TestMetricsMiddlewareUsesRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.MetricsMiddleware())
+	router.GET("/metrics-test/planes/:id", func(c *gin.Context) { c.Status(http.StatusTeapot) })
+	router.GET("/metrics", gin.WrapH(util.MetricsHandler()))
+
+	for _, path := range []string{"/metrics-test/planes/1", "/metrics-test/planes/2", "/metrics-test/nowhere"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	metric := findMetric(t, "http_requests_total", map[string]string{"route": "/metrics-test/planes/:id", "status": "418"})
+	if assert.NotNil(t, metric) {
+		assert.Equal(t, 2.0, metric.GetCounter().GetValue())
+	}
+	assert.NotNil(t, findMetric(t, "http_requests_total", map[string]string{"route": "unmatched", "status": "404"}))
+	assert.NotNil(t, findMetric(t, "http_request_duration_seconds", map[string]string{"route": "/metrics-test/planes/:id"}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.Contains(w.Body.String(), `http_requests_total{method="GET",route="/metrics-test/planes/:id",status="418"} 2`))
+}
+
+func TestObserveDBQuery(t *testing.T) {
+	util.ObserveDBQuery(`UPDATE "plane_parts" SET "usage_hours"=$1`, false, 3*time.Millisecond)
+	util.ObserveDBQuery("BEGIN", true, time.Millisecond)
+
+	metric := findMetric(t, "db_query_duration_seconds", map[string]string{"operation": "update", "status": "ok"})
+	if assert.NotNil(t, metric) {
+		assert.GreaterOrEqual(t, metric.GetHistogram().GetSampleCount(), uint64(1))
+	}
+	assert.NotNil(t, findMetric(t, "db_query_duration_seconds", map[string]string{"operation": "other", "status": "error"}))
+}
+
+func TestFleetCollectorReportsFleetAndPlaneTotals(t *testing.T) {
+	db, mock := newMockDB(t)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(service.NewFleetCollector(repository.NewPlaneRepository(db), repository.NewPlanePartRepository(db), util.NewLogger()))
+	mock.ExpectQuery(`SELECT status, COUNT\(\*\)`).WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("grounded", 2))
+	mock.ExpectQuery(`COUNT\(\*\) FILTER .* GROUP BY "plane_parts"."plane_id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"plane_id", "above80", "above90", "over_limit"}).
+			AddRow(1, 5, 3, 1).
+			AddRow(2, 2, 1, 0))
+
+	families, err := registry.Gather()
+
+	assert.NoError(t, err)
+	fleet := map[string]float64{}
+	byPlane := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			switch family.GetName() {
+			case "fleet_plane_parts_life_used":
+				assert.Len(t, labels, 1, "no per-plane labels on fleet totals")
+				fleet[labels["threshold"]] = metric.GetGauge().GetValue()
+			case "fleet_plane_parts_life_used_by_plane":
+				byPlane[labels["plane_id"]+"/"+labels["threshold"]] = metric.GetGauge().GetValue()
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"80": 7, "90": 4, "100": 1}, fleet)
+	assert.Equal(t, map[string]float64{"1/80": 5, "1/90": 3, "1/100": 1, "2/80": 2, "2/90": 1, "2/100": 0}, byPlane)
+}
+
+func TestMetricsServerServesOnlyMetrics(t *testing.T) {
+	srv := util.NewMetricsServer("127.0.0.1:0")
+
+	w := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+
+	w = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/planes", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func newMockPlanePartService(t *testing.T) (*service.PlanePartService, sqlmock.Sqlmock) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	txr := repository.NewTransactor(db)
+	planeRepo := repository.NewPlaneRepository(db)
+	planePartRepo := repository.NewPlanePartRepository(db)
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db), logger)
+	airworthinessSvc := service.NewAirworthinessService(txr, planeRepo, planePartRepo, auditSvc, logger)
+	return service.NewPlanePartService(txr, planeRepo, planePartRepo, repository.NewPartUsageRepository(db),
+		repository.NewPartInstallationRepository(db), repository.NewCatalogPartRepository(db), airworthinessSvc, auditSvc, logger), mock
+}
+
+// deltaHours matches the delta_hours argument of a ledger insert.
+type deltaHours float64
+
+func (d deltaHours) Match(v driver.Value) bool {
+	f, ok := v.(float64)
+	return ok && f == float64(d)
+}
+
+func TestUpdatePartUsageTakesDeltaFromLockedPart(t *testing.T) {
+	planePartSvc, mock := newMockPlanePartService(t)
+	mock.ExpectBegin()
+	// Another reading moved the part to 120 hours since the caller last
+	// looked; the delta is taken from the locked row.
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "serial_number", "usage_hours", "usage_limit_hours"}).AddRow(5, "SN-1", 120, 1000))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery(`INSERT INTO "part_usage_entries"`).
+		WithArgs(int64(5), deltaHours(30), 0, nil, models.UsageSourceManual, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectQuery(`SUM\(delta_hours\)`).WillReturnRows(sqlmock.NewRows([]string{"hours", "cycles"}).AddRow(150, 0))
+	mock.ExpectExec(`UPDATE "plane_parts"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	resp, err := planePartSvc.UpdatePartUsage(context.Background(), 5, &models.UpdatePartUsageRequest{UsageHours: 150}, 0)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, 150.0, resp.UsageHours)
+	}
+}
+
+func TestUpdatePartUsageRefusesNegativeDeltaInsideLock(t *testing.T) {
+	planePartSvc, mock := newMockPlanePartService(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "usage_hours", "usage_cycles"}).AddRow(5, 120, 4))
+	mock.ExpectRollback()
+
+	cycles := -1
+	resp, err := planePartSvc.UpdatePartUsage(context.Background(), 5, &models.UpdatePartUsageRequest{UsageHours: 130, UsageCycles: &cycles}, 0)
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, service.NegativeCyclesErr)
+}
+
+func TestLogPartUsageRejectsSystemSources(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/usage", func(c *gin.Context) {
+		var req models.LogPartUsageRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	tests := map[string]int{
+		`{"delta_hours":2}`:                            http.StatusOK,
+		`{"delta_hours":2,"source":"manual"}`:          http.StatusOK,
+		`{"delta_hours":-2,"source":"correction"}`:     http.StatusOK,
+		`{"delta_hours":2,"source":"flight"}`:          http.StatusBadRequest,
+		`{"delta_hours":-2,"source":"work_order"}`:     http.StatusBadRequest,
+		`{"delta_hours":2,"source":"initial"}`:         http.StatusBadRequest,
+		`{"delta_hours":2,"source":"engine overhaul"}`: http.StatusBadRequest,
+	}
+
+	for body, want := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/usage", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, want, w.Code, body)
+	}
+}
+
+func TestUpdatePartWritesOnlyEditedColumns(t *testing.T) {
+	planePartSvc, mock := newMockPlanePartService(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "part_name", "serial_number", "category", "usage_hours", "usage_limit_hours"}).
+			AddRow(5, "Blade", "SN-1", "engine", 120, 1000))
+	// Usage totals, the plane and the install date are absent, so a flight
+	// or installation committed meanwhile is not written over.
+	mock.ExpectExec(`UPDATE "plane_parts" SET "calendar_limit_days"=\$1,"catalog_part_id"=\$2,"category"=\$3,"part_name"=\$4,"serial_number"=\$5,"usage_limit_cycles"=\$6,"usage_limit_hours"=\$7 WHERE id = \$8 AND "plane_parts"."deleted_at" IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	limit := 900.0
+	resp, err := planePartSvc.UpdatePart(context.Background(), 5, &models.UpdatePlanePartRequest{UsageLimitHours: &limit}, 0)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, 900.0, resp.UsageLimitHours)
+		assert.Equal(t, 120.0, resp.UsageHours)
+	}
+}
+
+// TestPartUsageLedgerTrigger checks that the newest migration defining the
+// ledger trigger rejects deletes as well as updates.
+func TestPartUsageLedgerTrigger(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "database", "migrations", "*.sql"))
+	assert.NoError(t, err)
+	sort.Strings(files)
+
+	var definition string
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		up, _, _ := strings.Cut(string(content), "-- +goose Down")
+		if _, trigger, ok := strings.Cut(up, "CREATE TRIGGER trg_part_usage_entries_append_only"); ok {
+			definition = trigger
+		}
+	}
+
+	assert.Contains(t, definition, "BEFORE UPDATE OR DELETE ON part_usage_entries")
+}
@@ -7,18 +7,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/routers"
 )
 
 func TestPing(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	router := gin.New()
-	router.GET("/ping", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "Aircraft API is running...",
-			"version": "1.0.0",
-		})
-	})
+	routers.SetupHealthRoutes(&router.RouterGroup, controller.NewHealthController(nil))
 
 	req, err := http.NewRequest(http.MethodGet, "/ping", nil)
 	if err != nil {
@@ -28,7 +26,10 @@ func TestPing(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusOK, w.Code)                            
-	assert.Contains(t, w.Body.String(), "Aircraft API is running...") 
-	assert.Contains(t, w.Body.String(), "1.0.0")                      
+	healthz := httptest.NewRecorder()
+	router.ServeHTTP(healthz, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+	assert.Equal(t, healthz.Body.String(), w.Body.String(), "/ping answers like /healthz")
 }
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestLifeUsedHoursOnly(t *testing.T) {
+	part := models.PlanePart{
+		UsageHours:      250,
+		UsageLimitHours: 1000,
+		InstalledAt:     time.Now(),
+	}
+
+	percent, driver := part.LifeUsed(time.Now())
+
+	assert.InDelta(t, 25, percent, 0.001)
+	assert.Equal(t, models.LimitDriverHours, driver)
+}
+
+func TestLifeUsedCyclesDrive(t *testing.T) {
+	part := models.PlanePart{
+		UsageHours:       100,
+		UsageLimitHours:  1000,
+		UsageCycles:      450,
+		UsageLimitCycles: intPtr(500),
+		InstalledAt:      time.Now(),
+	}
+
+	percent, driver := part.LifeUsed(time.Now())
+
+	assert.InDelta(t, 90, percent, 0.001)
+	assert.Equal(t, models.LimitDriverCycles, driver)
+}
+
+func TestLifeUsedCalendarDrive(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	part := models.PlanePart{
+		UsageHours:        10,
+		UsageLimitHours:   1000,
+		CalendarLimitDays: intPtr(100),
+		InServiceAt:       now.AddDate(0, 0, -75),
+	}
+
+	percent, driver := part.LifeUsed(now)
+
+	assert.InDelta(t, 75, percent, 0.001)
+	assert.Equal(t, models.LimitDriverCalendar, driver)
+	assert.Equal(t, now.AddDate(0, 0, 25), *part.CalendarDueAt())
+}
+
+func TestCalendarLifeCarriesOverReinstall(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	part := models.PlanePart{
+		UsageLimitHours:   1000,
+		CalendarLimitDays: intPtr(100),
+		InServiceAt:       now.AddDate(0, 0, -75),
+		InstalledAt:       now.AddDate(0, 0, -75),
+	}
+	before, _ := part.LifeUsed(now)
+
+	// Pulled and refitted today.
+	part.InstalledAt = now
+	after, driver := part.LifeUsed(now)
+
+	assert.InDelta(t, before, after, 0.001)
+	assert.InDelta(t, 75, after, 0.001)
+	assert.Equal(t, models.LimitDriverCalendar, driver)
+	assert.Equal(t, now.AddDate(0, 0, 25), *part.CalendarDueAt())
+}
+
+func TestInstallKeepsInServiceDate(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "usage_hours", "in_service_at"}).AddRow(5, 300, time.Now().AddDate(-1, 0, 0)))
+	// Only the plane and install date move; in_service_at is left alone.
+	mock.ExpectExec(`UPDATE "plane_parts" SET "installed_at"=\$1,"plane_id"=\$2 WHERE`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "part_installations"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectCommit()
+
+	installation, err := repository.NewPartInstallationRepository(db).Install(context.Background(), 5, 1, nil)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, 300.0, installation.HoursAtInstall)
+	}
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+)
+
+func TestPlaneStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{models.PlaneStatusActive, models.PlaneStatusInMaintenance, true},
+		{models.PlaneStatusInMaintenance, models.PlaneStatusActive, true},
+		{models.PlaneStatusActive, models.PlaneStatusGrounded, true},
+		{models.PlaneStatusGrounded, models.PlaneStatusInMaintenance, true},
+		{models.PlaneStatusGrounded, models.PlaneStatusRetired, true},
+		{models.PlaneStatusGrounded, models.PlaneStatusActive, false},
+		{models.PlaneStatusActive, models.PlaneStatusActive, false},
+		{models.PlaneStatusRetired, models.PlaneStatusActive, false},
+		{models.PlaneStatusRetired, models.PlaneStatusInMaintenance, false},
+		{"", models.PlaneStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, models.CanTransitionPlaneStatus(tt.from, tt.to), tt.from+" -> "+tt.to)
+	}
+}
+
+func TestPlaneOperational(t *testing.T) {
+	for status, want := range map[string]bool{
+		models.PlaneStatusActive:        true,
+		models.PlaneStatusInMaintenance: true,
+		models.PlaneStatusGrounded:      false,
+		models.PlaneStatusRetired:       false,
+	} {
+		plane := models.Plane{Status: status}
+		assert.Equal(t, want, plane.Operational(), status)
+	}
+}
+
+func TestPartOverLimit(t *testing.T) {
+	now := time.Now()
+	part := models.PlanePart{UsageHours: 1000, UsageLimitHours: 1000, InstalledAt: now}
+	assert.False(t, part.OverLimit(now), "a part at exactly its limit has not overrun it")
+
+	part.UsageHours = 1000.5
+	assert.True(t, part.OverLimit(now))
+
+	part = models.PlanePart{UsageLimitHours: 1000, CalendarLimitDays: intPtr(30), InServiceAt: now.AddDate(0, 0, -31)}
+	assert.True(t, part.OverLimit(now), "calendar overruns count too")
+}
@@ -0,0 +1,154 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/controller"
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func newMockPlaneService(t *testing.T) (*service.PlaneService, sqlmock.Sqlmock) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db), logger)
+	return service.NewPlaneService(repository.NewTransactor(db), repository.NewPlaneRepository(db), repository.NewPlanePartRepository(db),
+		repository.NewAircraftModelRepository(db), auditSvc, logger), mock
+}
+
+func deletedPlaneRow() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "tail_number", "model", "status", "deleted_at"}).
+		AddRow(1, "N100", "A320", models.PlaneStatusActive, time.Now())
+}
+
+func historyRow(exists bool) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"exists"}).AddRow(exists)
+}
+
+func TestPurgePlaneRefusedWithHistory(t *testing.T) {
+	planeSvc, mock := newMockPlaneService(t)
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(deletedPlaneRow())
+	mock.ExpectBegin()
+	mock.ExpectQuery(`EXISTS \(SELECT 1 FROM flights WHERE plane_id = `).WillReturnRows(historyRow(true))
+	mock.ExpectCommit()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.DELETE("/planes/:id/purge", controller.NewPlaneController(planeSvc).PurgePlane)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/planes/1/purge", nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), service.PlaneHasHistoryErr)
+}
+
+func TestPurgePlaneWithoutHistory(t *testing.T) {
+	planeSvc, mock := newMockPlaneService(t)
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(deletedPlaneRow())
+	mock.ExpectBegin()
+	mock.ExpectQuery(`EXISTS`).WillReturnRows(historyRow(false))
+	mock.ExpectExec(`DELETE FROM "planes" WHERE deleted_at IS NOT NULL AND "planes"."id" = \$1`).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	assert.NoError(t, planeSvc.PurgePlane(context.Background(), 1))
+}
+
+func TestPurgePartRefusedWithHistory(t *testing.T) {
+	planePartSvc, mock := newMockPlanePartService(t)
+	mock.ExpectQuery(`FROM "plane_parts"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "serial_number", "deleted_at"}).AddRow(5, "SN-1", time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`EXISTS \(SELECT 1 FROM part_usage_entries WHERE part_id = `).WillReturnRows(historyRow(true))
+	mock.ExpectCommit()
+
+	err := planePartSvc.PurgePart(context.Background(), 5)
+
+	assert.EqualError(t, err, service.PartHasHistoryErr)
+}
+
+func TestRestorePlaneRestoresItsParts(t *testing.T) {
+	planeSvc, mock := newMockPlaneService(t)
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(deletedPlaneRow())
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`UPDATE "plane_parts" SET "deleted_at"=\$1 WHERE .* RETURNING`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "plane_id", "serial_number"}).AddRow(5, 1, "SN-1").AddRow(6, 1, "SN-2"))
+	mock.ExpectExec(`UPDATE "planes" SET "deleted_at"=\$1 WHERE id = \$2 AND deleted_at IS NOT NULL`).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+
+	resp, err := planeSvc.RestorePlane(context.Background(), 1)
+
+	if assert.NoError(t, err) {
+		assert.Nil(t, resp.DeletedAt)
+	}
+}
+
+func TestDeleteUserRevokesSessionsInSameTransaction(t *testing.T) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	userRepo := repository.NewUserRepository(db)
+	sessionSvc := service.NewSessionService(repository.NewSessionRepository(db), userRepo, service.NewJWTService(testAuthConfig), testAuthConfig, logger)
+	userSvc := service.NewUserService(repository.NewTransactor(db), userRepo, repository.NewUserInviteRepository(db), sessionSvc,
+		service.NewAuditService(repository.NewAuditRepository(db), logger), logger)
+
+	mock.ExpectQuery(`FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).AddRow(4, "mechanic", models.RoleUser))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "users" SET "deleted_at"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	// Revoking fails: the soft delete must not commit without it.
+	mock.ExpectExec(`UPDATE "sessions" SET`).WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	err := userSvc.Delete(context.Background(), 4)
+
+	assert.ErrorContains(t, err, "connection reset")
+}
+
+func TestUpdatePlaneDoesNotRestoreDeletedPlane(t *testing.T) {
+	planeSvc, mock := newMockPlaneService(t)
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusActive))
+	mock.ExpectBegin()
+	// The plane was deleted after it was read: the update matches nothing
+	// and must not fall back to an upsert that clears deleted_at.
+	mock.ExpectExec(`UPDATE "planes" SET "model"=\$1,"tail_number"=\$2 WHERE id = \$3 AND "planes"."deleted_at" IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	model := "A321"
+	resp, err := planeSvc.UpdatePlane(context.Background(), 1, &models.UpdatePlaneRequest{Model: &model})
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, service.PlaneNotFoundErr)
+}
+
+func TestUpdateUserDoesNotRestoreDeletedUser(t *testing.T) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	userRepo := repository.NewUserRepository(db)
+	sessionSvc := service.NewSessionService(repository.NewSessionRepository(db), userRepo, service.NewJWTService(testAuthConfig), testAuthConfig, logger)
+	userSvc := service.NewUserService(repository.NewTransactor(db), userRepo, repository.NewUserInviteRepository(db), sessionSvc,
+		service.NewAuditService(repository.NewAuditRepository(db), logger), logger)
+
+	mock.ExpectQuery(`FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).AddRow(4, "mechanic", models.RoleUser))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "users" SET "name"=\$1,"password"=\$2,"role"=\$3 WHERE id = \$4 AND "users"."deleted_at" IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	resp, err := userSvc.Update(context.Background(), 4, &models.UpdateRequest{Name: "renamed"}, 4, models.RoleAdmin)
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, service.UserNotFoundErr)
+}
@@ -0,0 +1,147 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/JasperRosales/aircraft-system-be/database"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+// startSlowServer runs a server whose only handler takes delay to answer and
+// signals started when a request arrives. Cancelling the returned context
+// starts shutdown; RunServer's result is sent on done.
+func startSlowServer(t *testing.T, delay, shutdownTimeout time.Duration) (string, chan struct{}, context.CancelFunc, chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve a port: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	started := make(chan struct{}, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		time.Sleep(delay)
+		io.WriteString(w, "done")
+	})
+	srv := util.NewHTTPServer(handler, util.ServerOptions{
+		Addr:              addr,
+		ReadHeaderTimeout: time.Second,
+		WriteTimeout:      5 * time.Second,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- util.RunServer(ctx, srv, shutdownTimeout, util.NewLogger()) }()
+
+	for i := 0; i < 100; i++ {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			return "http://" + addr, started, cancel, done
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	cancel()
+	t.Fatalf("Server did not start listening on %s", addr)
+	return "", nil, nil, nil
+}
+
+func TestRunServerDrainsInFlightRequests(t *testing.T) {
+	url, started, cancel, done := startSlowServer(t, 300*time.Millisecond, 5*time.Second)
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		responses <- result{status: resp.StatusCode, body: string(body)}
+	}()
+
+	<-started
+	cancel()
+
+	res := <-responses
+	assert.NoError(t, res.err)
+	assert.Equal(t, http.StatusOK, res.status)
+	assert.Equal(t, "done", res.body)
+	assert.NoError(t, <-done)
+
+	_, err := http.Get(url)
+	assert.Error(t, err, "no new connections after shutdown")
+}
+
+func TestRunServerShutdownTimeout(t *testing.T) {
+	url, started, cancel, done := startSlowServer(t, 2*time.Second, 100*time.Millisecond)
+
+	go http.Get(url)
+	<-started
+	cancel()
+
+	err := <-done
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRunServersStopsAllWhenOneFails(t *testing.T) {
+	url, _, cancel, _ := startSlowServer(t, 0, time.Second)
+	defer cancel()
+
+	// The second server asks for the port the slow server already holds;
+	// its failure has to take the healthy one down with it.
+	addr := strings.TrimPrefix(url, "http://")
+	healthy := util.NewHTTPServer(http.NotFoundHandler(), util.ServerOptions{Addr: "127.0.0.1:0"})
+	taken := util.NewHTTPServer(http.NotFoundHandler(), util.ServerOptions{Addr: addr})
+
+	done := make(chan error, 1)
+	go func() { done <- util.RunServers(context.Background(), time.Second, util.NewLogger(), healthy, taken) }()
+
+	select {
+	case err := <-done:
+		assert.ErrorContains(t, err, "address already in use")
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunServers kept running after a server failed")
+	}
+}
+
+func TestConfigurePool(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN: "host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=1",
+	}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	assert.NoError(t, database.ConfigurePool(db, database.PoolOptions{
+		MaxOpenConns:    7,
+		MaxIdleConns:    3,
+		ConnMaxLifetime: time.Minute,
+	}))
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.Equal(t, 7, sqlDB.Stats().MaxOpenConnections)
+
+	assert.NoError(t, database.Close(db))
+	assert.Error(t, sqlDB.Ping(), "pool is closed")
+}
@@ -0,0 +1,311 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JasperRosales/aircraft-system-be/internal/models"
+	"github.com/JasperRosales/aircraft-system-be/internal/repository"
+	"github.com/JasperRosales/aircraft-system-be/internal/service"
+	"github.com/JasperRosales/aircraft-system-be/internal/util"
+)
+
+func TestWorkOrderStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{models.WorkOrderStatusOpen, models.WorkOrderStatusInProgress, true},
+		{models.WorkOrderStatusOpen, models.WorkOrderStatusSignedOff, false},
+		{models.WorkOrderStatusOpen, models.WorkOrderStatusClosed, false},
+		{models.WorkOrderStatusInProgress, models.WorkOrderStatusSignedOff, true},
+		{models.WorkOrderStatusInProgress, models.WorkOrderStatusOpen, false},
+		{models.WorkOrderStatusInProgress, models.WorkOrderStatusClosed, false},
+		{models.WorkOrderStatusSignedOff, models.WorkOrderStatusClosed, true},
+		{models.WorkOrderStatusSignedOff, models.WorkOrderStatusInProgress, false},
+		{models.WorkOrderStatusClosed, models.WorkOrderStatusOpen, false},
+		{models.WorkOrderStatusClosed, models.WorkOrderStatusClosed, false},
+		{"unknown", models.WorkOrderStatusClosed, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, models.CanTransitionWorkOrderStatus(tt.from, tt.to), tt.from+" -> "+tt.to)
+	}
+}
+
+func newMockWorkOrderService(t *testing.T) (*service.WorkOrderService, sqlmock.Sqlmock) {
+	db, mock := newMockDB(t)
+	logger := util.NewLogger()
+	txr := repository.NewTransactor(db)
+	planePartRepo := repository.NewPlanePartRepository(db)
+	auditSvc := service.NewAuditService(repository.NewAuditRepository(db), logger)
+	airworthinessSvc := service.NewAirworthinessService(txr, repository.NewPlaneRepository(db), planePartRepo, auditSvc, logger)
+	return service.NewWorkOrderService(txr, repository.NewWorkOrderRepository(db), planePartRepo, repository.NewUserRepository(db),
+		repository.NewCatalogPartRepository(db), airworthinessSvc, auditSvc, logger), mock
+}
+
+// expectWorkOrderLocked expects the locked read of a work order in status
+// with no parts.
+func expectWorkOrderLocked(mock sqlmock.Sqlmock, status string) {
+	mock.ExpectQuery(`FROM "work_orders" WHERE .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "status", "assigned_to"}).AddRow(1, "Replace fan blade", status, 7))
+	mock.ExpectQuery(`FROM "work_order_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id", "work_order_id", "part_id"}))
+}
+
+func TestCloseWorkOrderRequiresSignOff(t *testing.T) {
+	tests := []struct {
+		status string
+		closes bool
+	}{
+		{models.WorkOrderStatusOpen, false},
+		{models.WorkOrderStatusInProgress, false},
+		{models.WorkOrderStatusSignedOff, true},
+		{models.WorkOrderStatusClosed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			workOrderSvc, mock := newMockWorkOrderService(t)
+			mock.ExpectBegin()
+			expectWorkOrderLocked(mock, tt.status)
+			if tt.closes {
+				mock.ExpectExec(`UPDATE "work_orders" SET .*"status"=\$\d+.* WHERE status = \$\d+ AND "id" = \$\d+`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditAppend(mock)
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			resp, err := workOrderSvc.CloseWorkOrder(context.Background(), 1)
+
+			if !tt.closes {
+				assert.Nil(t, resp)
+				assert.EqualError(t, err, service.WorkOrderTransitionErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, models.WorkOrderStatusClosed, resp.Status)
+				assert.NotNil(t, resp.ClosedAt)
+			}
+		})
+	}
+}
+
+func TestStartWorkOrderRefusedWhenStatusMovedOn(t *testing.T) {
+	workOrderSvc, mock := newMockWorkOrderService(t)
+	mock.ExpectBegin()
+	expectWorkOrderLocked(mock, models.WorkOrderStatusOpen)
+	// The write is conditional on the status read, so it can never put an
+	// in_progress status back over a sign-off.
+	mock.ExpectExec(`UPDATE "work_orders" SET .* WHERE status = \$\d+ AND "id" = \$\d+`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), models.WorkOrderStatusInProgress, sqlmock.AnyArg(), models.WorkOrderStatusOpen, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	resp, err := workOrderSvc.StartWorkOrder(context.Background(), 1, 7, models.RoleMechanic)
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, service.WorkOrderTransitionErr)
+}
+
+// expectSignOffLocked expects the locked read of an in-progress work order
+// assigned to mechanic 7 whose only line is part.
+func expectSignOffLocked(mock sqlmock.Sqlmock, part *sqlmock.Rows) {
+	mock.ExpectQuery(`FROM "work_orders" WHERE .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "status", "assigned_to"}).AddRow(1, "Replace fan blade", models.WorkOrderStatusInProgress, 7))
+	mock.ExpectQuery(`FROM "work_order_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id", "work_order_id", "part_id"}).AddRow(3, 1, 5))
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(part)
+}
+
+// expectReplacementSerialFree expects the check that a replacement serial
+// is not taken.
+func expectReplacementSerialFree(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`FROM "plane_parts" WHERE serial_number = `).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+}
+
+// expectSignedOffRead expects the reads that return a signed-off work order
+// whose only line is part 5 with action.
+func expectSignedOffRead(mock sqlmock.Sqlmock, action string, replacementPartID interface{}) {
+	mock.ExpectQuery(`FROM "work_orders"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "status", "assigned_to"}).AddRow(1, "Replace fan blade", models.WorkOrderStatusSignedOff, 7))
+	mock.ExpectQuery(`FROM "work_order_parts"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "work_order_id", "part_id", "action", "replacement_part_id"}).AddRow(3, 1, 5, action, replacementPartID))
+	mock.ExpectQuery(`FROM "plane_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+}
+
+func signOffRequest(body string) *models.SignOffWorkOrderRequest {
+	var req models.SignOffWorkOrderRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		panic(err)
+	}
+	return &req
+}
+
+var signOffPartColumns = []string{"id", "plane_id", "catalog_part_id", "part_name", "serial_number", "category", "usage_hours", "usage_limit_hours", "usage_limit_cycles"}
+
+func replaceRequest(replacement string) *models.SignOffWorkOrderRequest {
+	return signOffRequest(`{"items":[{"part_id":5,"action":"replace","replacement":` + replacement + `}]}`)
+}
+
+func TestSignOffHoldsReplacementToRemovedPart(t *testing.T) {
+	tests := []struct {
+		name        string
+		catalog     bool
+		replacement string
+		want        string
+	}{
+		{"catalog part renamed", true, `{"serial_number":"SN-2","part_name":"Big blade"}`, service.CatalogFieldErr},
+		{"catalog part looser than defaults", true, `{"serial_number":"SN-2","usage_limit_hours":6000}`, service.CatalogLimitErr},
+		{"hours limit raised", false, `{"serial_number":"SN-2","usage_limit_hours":1500}`, service.LimitRaiseErr},
+		{"cycle limit raised", false, `{"serial_number":"SN-2","usage_limit_cycles":900}`, service.CyclesLimitRaiseErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workOrderSvc, mock := newMockWorkOrderService(t)
+			mock.ExpectBegin()
+			if tt.catalog {
+				expectSignOffLocked(mock, sqlmock.NewRows(signOffPartColumns).AddRow(5, 1, 4, "Fan blade", "SN-1", "engine", 100, 5000, 3000))
+				expectReplacementSerialFree(mock)
+				mock.ExpectQuery(`FROM "catalog_parts"`).WillReturnRows(catalogRow())
+				mock.ExpectQuery(`FROM "catalog_part_models"`).WillReturnRows(sqlmock.NewRows([]string{"catalog_part_id", "model"}))
+			} else {
+				expectSignOffLocked(mock, sqlmock.NewRows(signOffPartColumns).AddRow(5, 1, nil, "Pump", "SN-1", "hydraulics", 100, 1000, 600))
+				expectReplacementSerialFree(mock)
+			}
+			mock.ExpectRollback()
+
+			resp, err := workOrderSvc.SignOffWorkOrder(context.Background(), 1, replaceRequest(tt.replacement), 7, models.RoleMechanic)
+
+			assert.Nil(t, resp)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestSignOffGroundsPlaneWhenReplacementIsOverLimit(t *testing.T) {
+	workOrderSvc, mock := newMockWorkOrderService(t)
+	mock.ExpectBegin()
+	expectSignOffLocked(mock, sqlmock.NewRows(signOffPartColumns).AddRow(5, 1, nil, "Pump", "SN-1", "hydraulics", 100, 1000, nil))
+	expectReplacementSerialFree(mock)
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "work_orders" SET .* WHERE status = `).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).WillReturnRows(sqlmock.NewRows(signOffPartColumns).AddRow(5, 1, nil, "Pump", "SN-1", "hydraulics", 100, 1000, nil))
+	mock.ExpectExec(`UPDATE "part_installations"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "plane_parts" SET "plane_id"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "plane_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectQuery(`INSERT INTO "part_usage_entries"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`INSERT INTO "part_installations"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(`UPDATE "work_order_parts"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	// The replacement arrives with more hours than its limit allows.
+	mock.ExpectQuery(`FROM "plane_parts" WHERE plane_id = .*LOCALTIMESTAMP`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "plane_id", "serial_number"}).AddRow(9, 1, "SN-2"))
+	mock.ExpectQuery(`FROM "planes"`).WillReturnRows(planeRow(models.PlaneStatusActive))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "planes" SET "status"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "plane_status_changes"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	expectAuditAppend(mock)
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+	expectSignedOffRead(mock, models.WorkOrderActionReplace, 9)
+
+	resp, err := workOrderSvc.SignOffWorkOrder(context.Background(), 1, replaceRequest(`{"serial_number":"SN-2","usage_hours":1200}`), 7, models.RoleMechanic)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, models.WorkOrderStatusSignedOff, resp.Status)
+	}
+}
+
+func TestSignOffResetWritesNegativeLedgerEntry(t *testing.T) {
+	workOrderSvc, mock := newMockWorkOrderService(t)
+	mock.ExpectBegin()
+	expectSignOffLocked(mock, sqlmock.NewRows(signOffPartColumns).AddRow(5, 1, nil, "Pump", "SN-1", "hydraulics", 100, 1000, nil))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "work_orders" SET .* WHERE status = `).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "plane_id", "usage_hours", "usage_cycles"}).AddRow(5, 1, 100, 40))
+	// The overhaul zeroes the part through the ledger, not by overwriting
+	// its totals.
+	mock.ExpectQuery(`INSERT INTO "part_usage_entries"`).
+		WithArgs(int64(5), deltaHours(-100), -40, int64(7), models.UsageSourceWorkOrder, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SUM\(delta_hours\)`).WillReturnRows(sqlmock.NewRows([]string{"hours", "cycles"}).AddRow(0, 0))
+	mock.ExpectExec(`UPDATE "plane_parts" SET "usage_cycles"=\$1,"usage_hours"=\$2`).WithArgs(0, 0.0, int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "work_order_parts" SET "action"=\$1,"replacement_part_id"=\$2 WHERE "id" = \$3`).
+		WithArgs(models.WorkOrderActionReset, nil, int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+	expectSignedOffRead(mock, models.WorkOrderActionReset, nil)
+
+	resp, err := workOrderSvc.SignOffWorkOrder(context.Background(), 1, signOffRequest(`{"items":[{"part_id":5,"action":"reset"}]}`), 7, models.RoleMechanic)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, models.WorkOrderStatusSignedOff, resp.Status)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSignOffReplaceRemovesPartThenInstallsReplacement(t *testing.T) {
+	workOrderSvc, mock := newMockWorkOrderService(t)
+	mock.ExpectBegin()
+	expectSignOffLocked(mock, sqlmock.NewRows(signOffPartColumns).AddRow(5, 1, nil, "Pump", "SN-1", "hydraulics", 100, 1000, nil))
+	expectReplacementSerialFree(mock)
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "work_orders" SET .* WHERE status = `).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows(signOffPartColumns).AddRow(5, 1, nil, "Pump", "SN-1", "hydraulics", 100, 1000, nil))
+	// The old part comes off the plane first, then the replacement goes on
+	// in its place.
+	mock.ExpectExec(`UPDATE "part_installations" SET .* WHERE part_id = \$\d+ AND removed_at IS NULL`).
+		WithArgs(0, 100.0, "replaced under work order #1", sqlmock.AnyArg(), int64(7), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "plane_parts" SET "plane_id"=\$1`).WithArgs(nil, int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "plane_parts"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectQuery(`INSERT INTO "part_installations"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(`UPDATE "work_order_parts" SET "action"=\$1,"replacement_part_id"=\$2 WHERE "id" = \$3`).
+		WithArgs(models.WorkOrderActionReplace, int64(9), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM "plane_parts" WHERE plane_id = .*LOCALTIMESTAMP`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	expectAuditAppend(mock)
+	mock.ExpectCommit()
+	expectSignedOffRead(mock, models.WorkOrderActionReplace, 9)
+
+	resp, err := workOrderSvc.SignOffWorkOrder(context.Background(), 1, replaceRequest(`{"serial_number":"SN-2"}`), 7, models.RoleMechanic)
+
+	if assert.NoError(t, err) && assert.Len(t, resp.Parts, 1) {
+		assert.Equal(t, int64(9), *resp.Parts[0].ReplacementPartID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSignOffRequiresEachPartExactlyOnce(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"other part", `{"items":[{"part_id":6,"action":"reset"}]}`},
+		{"extra part", `{"items":[{"part_id":5,"action":"reset"},{"part_id":6,"action":"reset"}]}`},
+		{"duplicate part", `{"items":[{"part_id":5,"action":"reset"},{"part_id":5,"action":"reset"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workOrderSvc, mock := newMockWorkOrderService(t)
+			mock.ExpectBegin()
+			expectSignOffLocked(mock, sqlmock.NewRows(signOffPartColumns).AddRow(5, 1, nil, "Pump", "SN-1", "hydraulics", 100, 1000, nil))
+			mock.ExpectRollback()
+
+			resp, err := workOrderSvc.SignOffWorkOrder(context.Background(), 1, signOffRequest(tt.body), 7, models.RoleMechanic)
+
+			assert.Nil(t, resp)
+			assert.EqualError(t, err, service.WorkOrderItemsMismatchErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}